@@ -0,0 +1,20 @@
+package api
+
+import "github.com/gin-gonic/gin"
+
+const clientIPKey = "client_ip"
+
+// SetClientIP stores the address of the client resolved from the trusted proxies.
+func SetClientIP(c *gin.Context, ip string) {
+	c.Set(clientIPKey, ip)
+}
+
+// GetClientIP returns the address of the client. Requests that did not pass the
+// client IP middleware get the address of the connection, which can not be
+// spoofed by headers.
+func GetClientIP(c *gin.Context) string {
+	if ip := c.GetString(clientIPKey); ip != "" {
+		return ip
+	}
+	return c.RemoteIP()
+}
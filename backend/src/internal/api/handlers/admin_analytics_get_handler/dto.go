@@ -0,0 +1,90 @@
+package admin_analytics_get_handler
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/ruslanonly/blindtyping/src/internal/services/analytics_service"
+	"github.com/ruslanonly/blindtyping/src/internal/shared/proto"
+)
+
+const (
+	defaultPeriod = 30 * 24 * time.Hour
+	defaultWeeks  = 8
+)
+
+type FunnelStep struct {
+	Step  string `json:"step" example:"test_completed"`
+	Users int64  `json:"users" example:"120"`
+} //@name AdminAnalyticsGetHandler.FunnelStep
+
+type Cohort struct {
+	Week   string  `json:"week" example:"2025-10-13T00:00:00Z"`
+	Users  int64   `json:"users" example:"40"`
+	Active []int64 `json:"active" example:"32,18,11"`
+} //@name AdminAnalyticsGetHandler.Cohort
+
+type ResponseBody struct {
+	Funnel    []FunnelStep `json:"funnel"`
+	Retention []Cohort     `json:"retention"`
+} //@name AdminAnalyticsGetHandler.ResponseBody
+
+func newReportIn(c *gin.Context) (*analytics_service.ReportIn, error) {
+	in := &analytics_service.ReportIn{
+		To:    time.Now(),
+		Weeks: defaultWeeks,
+	}
+
+	if to := c.Query("to"); to != "" {
+		parsed, err := proto.UnmarshalTime(to)
+		if err != nil {
+			return nil, err
+		}
+		in.To = parsed
+	}
+
+	in.From = in.To.Add(-defaultPeriod)
+	if from := c.Query("from"); from != "" {
+		parsed, err := proto.UnmarshalTime(from)
+		if err != nil {
+			return nil, err
+		}
+		in.From = parsed
+	}
+
+	if weeks := c.Query("weeks"); weeks != "" {
+		parsed, err := strconv.Atoi(weeks)
+		if err != nil {
+			return nil, err
+		}
+		in.Weeks = parsed
+	}
+
+	return in, nil
+}
+
+func newResponseBody(out *analytics_service.ReportOut) *ResponseBody {
+	body := &ResponseBody{
+		Funnel:    make([]FunnelStep, 0, len(out.Funnel)),
+		Retention: make([]Cohort, 0, len(out.Cohorts)),
+	}
+
+	for _, step := range out.Funnel {
+		body.Funnel = append(body.Funnel, FunnelStep{
+			Step:  string(step.Name),
+			Users: step.Users,
+		})
+	}
+
+	for _, cohort := range out.Cohorts {
+		body.Retention = append(body.Retention, Cohort{
+			Week:   proto.MarshalTime(cohort.Week),
+			Users:  cohort.Users,
+			Active: cohort.Active,
+		})
+	}
+
+	return body
+}
@@ -0,0 +1,102 @@
+package admin_analytics_get_handler
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/ruslanonly/blindtyping/src/internal"
+	"github.com/ruslanonly/blindtyping/src/internal/api/middleware"
+	"github.com/ruslanonly/blindtyping/src/internal/services/analytics_service"
+	"github.com/ruslanonly/blindtyping/src/internal/shared/proto"
+)
+
+const handlerName = "admin_analytics_get_handler"
+
+type analyticsService interface {
+	Report(ctx context.Context, in *analytics_service.ReportIn) (*analytics_service.ReportOut, error)
+}
+
+type Handler struct {
+	analyticsService analyticsService
+	logger           internal.Logger
+}
+
+func (h *Handler) handleError(ctx context.Context, c *gin.Context, err error) {
+	var (
+		status  = http.StatusInternalServerError
+		message = "something went wrong"
+	)
+
+	switch {
+	case analytics_service.IsInvalidPeriodError(err), analytics_service.IsInvalidWeeksError(err):
+		status = http.StatusBadRequest
+		message = err.Error()
+	}
+
+	ctx = h.logger.WithError(h.logger.WithStatusCode(ctx, status), err)
+
+	switch status {
+	case http.StatusInternalServerError:
+		h.logger.Error(ctx)
+	default:
+		h.logger.Warning(ctx)
+	}
+
+	proto.WriteError(c, status, message)
+}
+
+// Handle godoc
+// @Summary     Продуктовая аналитика
+// @Description Воронка ключевого сценария (регистрация, начало теста, завершение теста, личный рекорд) для пользователей, начавших ее в периоде, и недельное удержание пользователей, зарегистрированных в периоде. Удержание считается по завершенным тестам. Доступно администраторам.
+// @Tags        Admin
+// @Produce     json
+// @Security    ApiKeyAuth
+// @Param       from  query string false "Начало периода в RFC3339 (по умолчанию 30 дней до конца)"
+// @Param       to    query string false "Конец периода в RFC3339 (по умолчанию сейчас)"
+// @Param       weeks query int    false "Сколько недель после регистрации считать удержание (по умолчанию 8, не больше 26)"
+// @Success     200 {object} ResponseBody "Воронка и удержание"
+// @Failure     400 {object} proto.Error "Неверный период или кол-во недель"
+// @Failure     401 {object} proto.Error "Пользователь не авторизован"
+// @Failure     403 {object} proto.Error "Недостаточно прав"
+// @Failure     500 {object} proto.Error "Внутренняя ошибка сервера (смотреть логи)"
+// @Router      /admin/analytics [get]
+func (h *Handler) Handle(c *gin.Context) {
+	ctx := h.logger.WithHandlerName(c.Request.Context(), handlerName)
+
+	in, err := newReportIn(c)
+	if err != nil {
+		ctx = h.logger.WithStatusCode(ctx, http.StatusBadRequest)
+		h.logger.Warning(h.logger.WithError(ctx, err))
+		proto.WriteError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	out, err := h.analyticsService.Report(ctx, in)
+	if err != nil {
+		h.handleError(ctx, c, err)
+		return
+	}
+
+	proto.WriteJSON(c, http.StatusOK, newResponseBody(out))
+}
+
+func (h *Handler) Method() string {
+	return http.MethodGet
+}
+
+func (h *Handler) Path() string {
+	return "/admin/analytics"
+}
+
+func (h *Handler) Middleware() []string {
+	return []string{middleware.Auth, middleware.AccessRevocation, middleware.Session, middleware.Admin}
+}
+
+func New(analyticsService analyticsService, logger internal.Logger) *Handler {
+	return &Handler{
+		analyticsService: analyticsService,
+		logger:           logger,
+	}
+}
@@ -0,0 +1,128 @@
+package admin_audit_get_handler
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/ruslanonly/blindtyping/src/internal/models"
+	"github.com/ruslanonly/blindtyping/src/internal/shared/proto"
+)
+
+type Event struct {
+	ID           uint64         `json:"id" example:"1024"`
+	Action       string         `json:"action" example:"login"`
+	UserID       *uint64        `json:"userId" example:"42"`
+	ActorID      *uint64        `json:"actorId" example:"42"`
+	ActorService *string        `json:"actorService" example:"scheduler"`
+	IP           string         `json:"ip" example:"203.0.113.7"`
+	UserAgent    string         `json:"userAgent" example:"Mozilla/5.0"`
+	RequestID    string         `json:"requestId" example:"4bf92f3577b34da6a3ce929d0e0e4736"`
+	Details      map[string]any `json:"details" swaggertype:"object"`
+	CreatedAt    string         `json:"createdAt" example:"2025-10-19T19:02:29+03:00"`
+} //@name AdminAuditGetHandler.Event
+
+type ResponseBody struct {
+	Events []Event `json:"events"`
+} //@name AdminAuditGetHandler.ResponseBody
+
+func parseOptionalID(value string) (*models.ID, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	parsed, err := strconv.ParseUint(value, 10, 64)
+	if err != nil {
+		return nil, err
+	}
+
+	id := models.ID(parsed)
+	return &id, nil
+}
+
+func parseOptionalTime(value string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	parsed, err := proto.UnmarshalTime(value)
+	if err != nil {
+		return nil, err
+	}
+
+	return &parsed, nil
+}
+
+func newFilter(c *gin.Context) (*models.AuditFilter, error) {
+	var (
+		filter = &models.AuditFilter{
+			Action: models.AuditAction(c.Query("action")),
+			IP:     c.Query("ip"),
+		}
+		err error
+	)
+
+	if filter.UserID, err = parseOptionalID(c.Query("userId")); err != nil {
+		return nil, err
+	}
+	if filter.ActorID, err = parseOptionalID(c.Query("actorId")); err != nil {
+		return nil, err
+	}
+	if filter.BeforeID, err = parseOptionalID(c.Query("before")); err != nil {
+		return nil, err
+	}
+	if filter.From, err = parseOptionalTime(c.Query("from")); err != nil {
+		return nil, err
+	}
+	if filter.To, err = parseOptionalTime(c.Query("to")); err != nil {
+		return nil, err
+	}
+
+	if limit := c.Query("limit"); limit != "" {
+		parsed, err := strconv.Atoi(limit)
+		if err != nil {
+			return nil, err
+		}
+		filter.Limit = parsed
+	}
+
+	return filter, nil
+}
+
+func newResponseBody(events []*models.AuditEvent) *ResponseBody {
+	body := make([]Event, 0, len(events))
+	for _, event := range events {
+		var userID *uint64
+		if event.UserID != nil {
+			id := uint64(*event.UserID)
+			userID = &id
+		}
+
+		var actorID *uint64
+		if event.ActorID != nil {
+			id := uint64(*event.ActorID)
+			actorID = &id
+		}
+
+		var actorService *string
+		if event.ActorService != "" {
+			actorService = &event.ActorService
+		}
+
+		body = append(body, Event{
+			ID:           uint64(event.ID),
+			Action:       string(event.Action),
+			UserID:       userID,
+			ActorID:      actorID,
+			ActorService: actorService,
+			IP:           event.IP,
+			UserAgent:    event.UserAgent,
+			RequestID:    event.RequestID,
+			Details:      event.Details,
+			CreatedAt:    proto.MarshalTime(event.CreatedAt),
+		})
+	}
+
+	return &ResponseBody{Events: body}
+}
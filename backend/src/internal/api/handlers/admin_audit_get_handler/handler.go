@@ -0,0 +1,109 @@
+package admin_audit_get_handler
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/ruslanonly/blindtyping/src/internal"
+	"github.com/ruslanonly/blindtyping/src/internal/api/middleware"
+	"github.com/ruslanonly/blindtyping/src/internal/models"
+	"github.com/ruslanonly/blindtyping/src/internal/services/audit_service"
+	"github.com/ruslanonly/blindtyping/src/internal/shared/proto"
+)
+
+const handlerName = "admin_audit_get_handler"
+
+type auditService interface {
+	Search(ctx context.Context, filter *models.AuditFilter) ([]*models.AuditEvent, error)
+}
+
+type Handler struct {
+	auditService auditService
+	logger       internal.Logger
+}
+
+func (h *Handler) handleError(ctx context.Context, c *gin.Context, err error) {
+	var (
+		status  = http.StatusInternalServerError
+		message = "something went wrong serverside"
+	)
+
+	switch {
+	case audit_service.IsInvalidActionError(err), audit_service.IsInvalidPeriodError(err):
+		status = http.StatusBadRequest
+		message = err.Error()
+	}
+
+	ctx = h.logger.WithError(h.logger.WithStatusCode(ctx, status), err)
+
+	switch status {
+	case http.StatusInternalServerError:
+		h.logger.Error(ctx)
+	default:
+		h.logger.Warning(ctx)
+	}
+
+	proto.WriteError(c, status, message)
+}
+
+// Handle godoc
+// @Summary     Поиск по журналу действий
+// @Description Найти записи журнала действий всех пользователей, начиная с новых. Все фильтры необязательные. Для следующей страницы нужно передать id последней записи в before. Доступно только администраторам.
+// @Tags        Admin
+// @Accept      json
+// @Produce     json
+// @Security    ApiKeyAuth
+// @Param       userId query int false "Пользователь, к аккаунту которого относится действие"
+// @Param       actorId query int false "Пользователь, который совершил действие"
+// @Param       action query string false "Действие: login, registration, username_changed, statistics_wiped, statistics_result_deleted, statistics_restored, session_revoked, antifroad_key_rotated, account_deletion_scheduled или account_deleted"
+// @Param       ip query string false "IP адрес клиента"
+// @Param       from query string false "Начало периода (RFC3339)"
+// @Param       to query string false "Конец периода, не включая (RFC3339)"
+// @Param       before query int false "Вернуть записи старше записи с этим id"
+// @Param       limit query int false "Максимальное кол-во записей (по умолчанию 50, не больше 200)"
+// @Success     200 {object} ResponseBody "Записи журнала"
+// @Failure     400 {object} proto.Error "Неверный фильтр или лимит"
+// @Failure     401 {object} proto.Error "Пользователь не авторизован"
+// @Failure     403 {object} proto.Error "Недостаточно прав"
+// @Failure     500 {object} proto.Error "Внутренняя ошибка сервера (смотреть логи)"
+// @Router      /admin/audit [get]
+func (h *Handler) Handle(c *gin.Context) {
+	ctx := h.logger.WithHandlerName(c.Request.Context(), handlerName)
+
+	filter, err := newFilter(c)
+	if err != nil {
+		ctx = h.logger.WithStatusCode(ctx, http.StatusBadRequest)
+		h.logger.Warning(h.logger.WithError(ctx, err))
+		proto.WriteError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	events, err := h.auditService.Search(ctx, filter)
+	if err != nil {
+		h.handleError(ctx, c, err)
+		return
+	}
+
+	proto.WriteJSON(c, http.StatusOK, newResponseBody(events))
+}
+
+func (h *Handler) Method() string {
+	return http.MethodGet
+}
+
+func (h *Handler) Path() string {
+	return "/admin/audit"
+}
+
+func (h *Handler) Middleware() []string {
+	return []string{middleware.Auth, middleware.AccessRevocation, middleware.Session, middleware.Admin}
+}
+
+func New(auditService auditService, logger internal.Logger) *Handler {
+	return &Handler{
+		auditService: auditService,
+		logger:       logger,
+	}
+}
@@ -0,0 +1,89 @@
+package admin_reviews_get_handler
+
+import (
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/ruslanonly/blindtyping/src/internal/models"
+	"github.com/ruslanonly/blindtyping/src/internal/shared/proto"
+)
+
+type Review struct {
+	ID         uint64          `json:"id" example:"1"`
+	UserID     uint64          `json:"userId" example:"42"`
+	UID        string          `json:"uid" example:"0"`
+	Result     json.RawMessage `json:"result" swaggertype:"object" description:"Результат в том виде, в котором он был отправлен"`
+	Score      float64         `json:"score" example:"2.4"`
+	Reasons    []string        `json:"reasons" example:"wpm_spike"`
+	Status     string          `json:"status" example:"pending"`
+	CreatedAt  string          `json:"createdAt" example:"2025-10-19T19:02:29+03:00"`
+	ReviewedBy *uint64         `json:"reviewedBy" example:"2"`
+	ReviewedAt *string         `json:"reviewedAt" example:"2025-10-20T10:00:00+03:00"`
+} //@name AdminReviewsGetHandler.Review
+
+type ResponseBody struct {
+	Reviews []Review `json:"reviews"`
+} //@name AdminReviewsGetHandler.ResponseBody
+
+type Request struct {
+	Status models.ReviewStatus
+	Limit  int
+}
+
+func newRequest(c *gin.Context) (*Request, error) {
+	r := &Request{
+		Status: models.ReviewStatus(c.Query("status")),
+	}
+
+	if limit := c.Query("limit"); limit != "" {
+		parsed, err := strconv.Atoi(limit)
+		if err != nil {
+			return nil, err
+		}
+		r.Limit = parsed
+	}
+
+	return r, nil
+}
+
+func marshalOptionalTime(t *time.Time) *string {
+	if t == nil {
+		return nil
+	}
+	formatted := proto.MarshalTime(*t)
+	return &formatted
+}
+
+func newResponseBody(reviews []*models.StatisticsReview) *ResponseBody {
+	body := make([]Review, 0, len(reviews))
+	for _, review := range reviews {
+		reasons := make([]string, 0, len(review.Reasons))
+		for _, reason := range review.Reasons {
+			reasons = append(reasons, string(reason))
+		}
+
+		var reviewedBy *uint64
+		if review.ReviewedBy != nil {
+			id := uint64(*review.ReviewedBy)
+			reviewedBy = &id
+		}
+
+		body = append(body, Review{
+			ID:         uint64(review.ID),
+			UserID:     uint64(review.UserID),
+			UID:        review.UID,
+			Result:     review.Payload,
+			Score:      review.Score,
+			Reasons:    reasons,
+			Status:     string(review.Status),
+			CreatedAt:  proto.MarshalTime(review.CreatedAt),
+			ReviewedBy: reviewedBy,
+			ReviewedAt: marshalOptionalTime(review.ReviewedAt),
+		})
+	}
+
+	return &ResponseBody{Reviews: body}
+}
@@ -0,0 +1,106 @@
+package admin_reviews_get_handler
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/ruslanonly/blindtyping/src/internal"
+	"github.com/ruslanonly/blindtyping/src/internal/api/middleware"
+	"github.com/ruslanonly/blindtyping/src/internal/models"
+	"github.com/ruslanonly/blindtyping/src/internal/services/statistics_review_service"
+	"github.com/ruslanonly/blindtyping/src/internal/shared/proto"
+)
+
+const handlerName = "admin_reviews_get_handler"
+
+type reviewService interface {
+	List(ctx context.Context, in *statistics_review_service.ListIn) ([]*models.StatisticsReview, error)
+}
+
+type Handler struct {
+	reviewService reviewService
+	logger        internal.Logger
+}
+
+func (h *Handler) handleError(ctx context.Context, c *gin.Context, err error) {
+	var (
+		status  = http.StatusInternalServerError
+		message = "something went wrong serverside"
+	)
+
+	switch {
+	case statistics_review_service.IsInvalidStatusError(err):
+		status = http.StatusBadRequest
+		message = err.Error()
+	}
+
+	ctx = h.logger.WithError(h.logger.WithStatusCode(ctx, status), err)
+
+	switch status {
+	case http.StatusInternalServerError:
+		h.logger.Error(ctx)
+	default:
+		h.logger.Warning(ctx)
+	}
+
+	proto.WriteError(c, status, message)
+}
+
+// Handle godoc
+// @Summary     Очередь проверки результатов
+// @Description Получить результаты, которые детектор аномалий отправил на проверку, начиная со старых. Доступно модераторам и администраторам.
+// @Tags        Admin
+// @Accept      json
+// @Produce     json
+// @Security    ApiKeyAuth
+// @Param       status query string false "Статус: pending (по умолчанию), approved или rejected"
+// @Param       limit query int false "Максимальное кол-во результатов (по умолчанию 50, не больше 200)"
+// @Success     200 {object} ResponseBody "Результаты на проверке"
+// @Failure     400 {object} proto.Error "Неверный статус или лимит"
+// @Failure     401 {object} proto.Error "Пользователь не авторизован"
+// @Failure     403 {object} proto.Error "Недостаточно прав"
+// @Failure     500 {object} proto.Error "Внутренняя ошибка сервера (смотреть логи)"
+// @Router      /admin/reviews [get]
+func (h *Handler) Handle(c *gin.Context) {
+	ctx := h.logger.WithHandlerName(c.Request.Context(), handlerName)
+
+	req, err := newRequest(c)
+	if err != nil {
+		ctx = h.logger.WithStatusCode(ctx, http.StatusBadRequest)
+		h.logger.Warning(h.logger.WithError(ctx, err))
+		proto.WriteError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	reviews, err := h.reviewService.List(ctx, &statistics_review_service.ListIn{
+		Status: req.Status,
+		Limit:  req.Limit,
+	})
+	if err != nil {
+		h.handleError(ctx, c, err)
+		return
+	}
+
+	proto.WriteJSON(c, http.StatusOK, newResponseBody(reviews))
+}
+
+func (h *Handler) Method() string {
+	return http.MethodGet
+}
+
+func (h *Handler) Path() string {
+	return "/admin/reviews"
+}
+
+func (h *Handler) Middleware() []string {
+	return []string{middleware.Auth, middleware.AccessRevocation, middleware.Session, middleware.Moderator}
+}
+
+func New(reviewService reviewService, logger internal.Logger) *Handler {
+	return &Handler{
+		reviewService: reviewService,
+		logger:        logger,
+	}
+}
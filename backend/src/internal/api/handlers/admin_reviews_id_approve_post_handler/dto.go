@@ -0,0 +1,27 @@
+package admin_reviews_id_approve_post_handler
+
+import (
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/ruslanonly/blindtyping/src/internal/api"
+	"github.com/ruslanonly/blindtyping/src/internal/models"
+)
+
+type Request struct {
+	ReviewerID models.ID
+	ReviewID   models.ID
+}
+
+func newRequest(c *gin.Context) (*Request, error) {
+	reviewID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Request{
+		ReviewerID: models.ID(api.GetUserID(c)),
+		ReviewID:   models.ID(reviewID),
+	}, nil
+}
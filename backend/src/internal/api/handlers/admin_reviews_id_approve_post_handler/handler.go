@@ -0,0 +1,111 @@
+package admin_reviews_id_approve_post_handler
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/ruslanonly/blindtyping/src/internal"
+	"github.com/ruslanonly/blindtyping/src/internal/api/middleware"
+	"github.com/ruslanonly/blindtyping/src/internal/services/statistics_review_service"
+	"github.com/ruslanonly/blindtyping/src/internal/services/statistics_service"
+	"github.com/ruslanonly/blindtyping/src/internal/shared/proto"
+)
+
+const handlerName = "admin_reviews_id_approve_post_handler"
+
+type reviewService interface {
+	Approve(ctx context.Context, in *statistics_review_service.ResolveIn) error
+}
+
+type Handler struct {
+	reviewService reviewService
+	logger        internal.Logger
+}
+
+func (h *Handler) handleError(ctx context.Context, c *gin.Context, err error) {
+	var (
+		status  = http.StatusInternalServerError
+		message = "something went wrong serverside"
+	)
+
+	switch {
+	case statistics_review_service.IsReviewNotFoundError(err):
+		status = http.StatusNotFound
+		message = "review not found"
+	case statistics_review_service.IsReviewAlreadyHandledError(err):
+		status = http.StatusConflict
+		message = err.Error()
+	case statistics_service.IsFroadError(err):
+		status = http.StatusConflict
+		message = "result signature is no longer valid"
+	}
+
+	ctx = h.logger.WithError(h.logger.WithStatusCode(ctx, status), err)
+
+	switch status {
+	case http.StatusInternalServerError:
+		h.logger.Error(ctx)
+	default:
+		h.logger.Warning(ctx)
+	}
+
+	proto.WriteError(c, status, message)
+}
+
+// Handle godoc
+// @Summary     Одобрить результат
+// @Description Сохраняет результат так, как будто он только что был отправлен: он попадает в статистику и рекорды. Доступно модераторам и администраторам.
+// @Tags        Admin
+// @Accept      json
+// @Produce     json
+// @Security    ApiKeyAuth
+// @Param       id path int true "ID проверки"
+// @Success     200 "Результат сохранен"
+// @Failure     400 {object} proto.Error "Неверный ID проверки"
+// @Failure     401 {object} proto.Error "Пользователь не авторизован"
+// @Failure     403 {object} proto.Error "Недостаточно прав"
+// @Failure     404 {object} proto.Error "Проверка не найдена"
+// @Failure     409 {object} proto.Error "Результат уже проверен или его подпись больше не действительна"
+// @Failure     500 {object} proto.Error "Внутренняя ошибка сервера (смотреть логи)"
+// @Router      /admin/reviews/{id}/approve [post]
+func (h *Handler) Handle(c *gin.Context) {
+	ctx := h.logger.WithHandlerName(c.Request.Context(), handlerName)
+
+	req, err := newRequest(c)
+	if err != nil {
+		ctx = h.logger.WithStatusCode(ctx, http.StatusBadRequest)
+		h.logger.Warning(h.logger.WithError(ctx, err))
+		proto.WriteError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	err = h.reviewService.Approve(ctx, &statistics_review_service.ResolveIn{
+		ReviewerID: req.ReviewerID,
+		ReviewID:   req.ReviewID,
+	})
+	if err != nil {
+		h.handleError(ctx, c, err)
+		return
+	}
+}
+
+func (h *Handler) Method() string {
+	return http.MethodPost
+}
+
+func (h *Handler) Path() string {
+	return "/admin/reviews/:id/approve"
+}
+
+func (h *Handler) Middleware() []string {
+	return []string{middleware.Auth, middleware.AccessRevocation, middleware.Session, middleware.Moderator}
+}
+
+func New(reviewService reviewService, logger internal.Logger) *Handler {
+	return &Handler{
+		reviewService: reviewService,
+		logger:        logger,
+	}
+}
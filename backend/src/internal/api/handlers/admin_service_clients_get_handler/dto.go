@@ -0,0 +1,53 @@
+package admin_service_clients_get_handler
+
+import (
+	"time"
+
+	"github.com/ruslanonly/blindtyping/src/internal/models"
+	"github.com/ruslanonly/blindtyping/src/internal/shared/proto"
+)
+
+type ServiceClient struct {
+	ID         uint64  `json:"id" example:"1"`
+	Name       string  `json:"name" example:"antifroad-worker"`
+	CreatedBy  *uint64 `json:"createdBy" example:"2"`
+	CreatedAt  string  `json:"createdAt" example:"2025-10-19T19:02:29+03:00"`
+	LastUsedAt *string `json:"lastUsedAt" example:"2025-10-20T10:00:00+03:00"`
+	RevokedAt  *string `json:"revokedAt" example:"2025-10-21T10:00:00+03:00"`
+} //@name AdminServiceClientsGetHandler.ServiceClient
+
+type ResponseBody struct {
+	Clients []ServiceClient `json:"clients"`
+} //@name AdminServiceClientsGetHandler.ResponseBody
+
+func marshalOptionalID(id *models.ID) *uint64 {
+	if id == nil {
+		return nil
+	}
+	value := uint64(*id)
+	return &value
+}
+
+func marshalOptionalTime(t *time.Time) *string {
+	if t == nil {
+		return nil
+	}
+	formatted := proto.MarshalTime(*t)
+	return &formatted
+}
+
+func newResponseBody(clients []*models.ServiceClient) *ResponseBody {
+	body := make([]ServiceClient, 0, len(clients))
+	for _, client := range clients {
+		body = append(body, ServiceClient{
+			ID:         uint64(client.ID),
+			Name:       client.Name,
+			CreatedBy:  marshalOptionalID(client.CreatedBy),
+			CreatedAt:  proto.MarshalTime(client.CreatedAt),
+			LastUsedAt: marshalOptionalTime(client.LastUsedAt),
+			RevokedAt:  marshalOptionalTime(client.RevokedAt),
+		})
+	}
+
+	return &ResponseBody{Clients: body}
+}
@@ -0,0 +1,69 @@
+package admin_service_clients_get_handler
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/ruslanonly/blindtyping/src/internal"
+	"github.com/ruslanonly/blindtyping/src/internal/api/middleware"
+	"github.com/ruslanonly/blindtyping/src/internal/models"
+	"github.com/ruslanonly/blindtyping/src/internal/shared/proto"
+)
+
+const handlerName = "admin_service_clients_get_handler"
+
+type serviceClientService interface {
+	List(ctx context.Context) ([]*models.ServiceClient, error)
+}
+
+type Handler struct {
+	serviceClientService serviceClientService
+	logger               internal.Logger
+}
+
+// Handle godoc
+// @Summary     Учетные данные внутренних сервисов
+// @Description Получить все учетные данные внутренних сервисов, включая отозванные. Секреты не возвращаются. Доступно администраторам.
+// @Tags        Admin
+// @Accept      json
+// @Produce     json
+// @Security    ApiKeyAuth
+// @Success     200 {object} ResponseBody "Учетные данные сервисов"
+// @Failure     401 {object} proto.Error "Пользователь не авторизован"
+// @Failure     403 {object} proto.Error "Недостаточно прав"
+// @Failure     500 {object} proto.Error "Внутренняя ошибка сервера (смотреть логи)"
+// @Router      /admin/service-clients [get]
+func (h *Handler) Handle(c *gin.Context) {
+	ctx := h.logger.WithHandlerName(c.Request.Context(), handlerName)
+
+	clients, err := h.serviceClientService.List(ctx)
+	if err != nil {
+		ctx = h.logger.WithStatusCode(ctx, http.StatusInternalServerError)
+		h.logger.Error(h.logger.WithError(ctx, err))
+		proto.WriteError(c, http.StatusInternalServerError, "something went wrong serverside")
+		return
+	}
+
+	proto.WriteJSON(c, http.StatusOK, newResponseBody(clients))
+}
+
+func (h *Handler) Method() string {
+	return http.MethodGet
+}
+
+func (h *Handler) Path() string {
+	return "/admin/service-clients"
+}
+
+func (h *Handler) Middleware() []string {
+	return []string{middleware.Auth, middleware.AccessRevocation, middleware.Session, middleware.Admin}
+}
+
+func New(serviceClientService serviceClientService, logger internal.Logger) *Handler {
+	return &Handler{
+		serviceClientService: serviceClientService,
+		logger:               logger,
+	}
+}
@@ -0,0 +1,22 @@
+package admin_service_clients_id_delete_handler
+
+import (
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/ruslanonly/blindtyping/src/internal/models"
+)
+
+type Request struct {
+	ClientID models.ID
+}
+
+func newRequest(c *gin.Context) (*Request, error) {
+	clientID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Request{ClientID: models.ID(clientID)}, nil
+}
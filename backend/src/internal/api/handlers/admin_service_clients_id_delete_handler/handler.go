@@ -0,0 +1,105 @@
+package admin_service_clients_id_delete_handler
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/ruslanonly/blindtyping/src/internal"
+	"github.com/ruslanonly/blindtyping/src/internal/api/middleware"
+	"github.com/ruslanonly/blindtyping/src/internal/models"
+	"github.com/ruslanonly/blindtyping/src/internal/services/service_client_service"
+	"github.com/ruslanonly/blindtyping/src/internal/shared/proto"
+)
+
+const handlerName = "admin_service_clients_id_delete_handler"
+
+type serviceClientService interface {
+	Revoke(ctx context.Context, id models.ID) error
+}
+
+type Handler struct {
+	serviceClientService serviceClientService
+	logger               internal.Logger
+}
+
+func (h *Handler) handleError(ctx context.Context, c *gin.Context, err error) {
+	var (
+		status  = http.StatusInternalServerError
+		message = "something went wrong serverside"
+	)
+
+	switch {
+	case service_client_service.IsClientNotFoundError(err):
+		status = http.StatusNotFound
+		message = err.Error()
+	case service_client_service.IsClientAlreadyRevokedError(err):
+		status = http.StatusConflict
+		message = err.Error()
+	}
+
+	ctx = h.logger.WithError(h.logger.WithStatusCode(ctx, status), err)
+
+	switch status {
+	case http.StatusInternalServerError:
+		h.logger.Error(ctx)
+	default:
+		h.logger.Warning(ctx)
+	}
+
+	proto.WriteError(c, status, message)
+}
+
+// Handle godoc
+// @Summary     Отозвать учетные данные внутреннего сервиса
+// @Description Отзывает учетные данные сервиса. Запросы, подписанные его секретом, больше не принимаются. Остальные сервисы продолжают работать. Доступно администраторам.
+// @Tags        Admin
+// @Accept      json
+// @Produce     json
+// @Security    ApiKeyAuth
+// @Param       id path int true "ID учетных данных сервиса"
+// @Success     200 "Учетные данные отозваны"
+// @Failure     400 {object} proto.Error "Неверный ID"
+// @Failure     401 {object} proto.Error "Пользователь не авторизован"
+// @Failure     403 {object} proto.Error "Недостаточно прав"
+// @Failure     404 {object} proto.Error "Учетные данные не найдены"
+// @Failure     409 {object} proto.Error "Учетные данные уже отозваны"
+// @Failure     500 {object} proto.Error "Внутренняя ошибка сервера (смотреть логи)"
+// @Router      /admin/service-clients/{id} [delete]
+func (h *Handler) Handle(c *gin.Context) {
+	ctx := h.logger.WithHandlerName(c.Request.Context(), handlerName)
+
+	req, err := newRequest(c)
+	if err != nil {
+		ctx = h.logger.WithStatusCode(ctx, http.StatusBadRequest)
+		h.logger.Warning(h.logger.WithError(ctx, err))
+		proto.WriteError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	err = h.serviceClientService.Revoke(ctx, req.ClientID)
+	if err != nil {
+		h.handleError(ctx, c, err)
+		return
+	}
+}
+
+func (h *Handler) Method() string {
+	return http.MethodDelete
+}
+
+func (h *Handler) Path() string {
+	return "/admin/service-clients/:id"
+}
+
+func (h *Handler) Middleware() []string {
+	return []string{middleware.Auth, middleware.AccessRevocation, middleware.Session, middleware.Admin}
+}
+
+func New(serviceClientService serviceClientService, logger internal.Logger) *Handler {
+	return &Handler{
+		serviceClientService: serviceClientService,
+		logger:               logger,
+	}
+}
@@ -0,0 +1,46 @@
+package admin_service_clients_post_handler
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/ruslanonly/blindtyping/src/internal/api"
+	"github.com/ruslanonly/blindtyping/src/internal/models"
+	"github.com/ruslanonly/blindtyping/src/internal/shared/proto"
+)
+
+type RequestBody struct {
+	Name string `json:"name" example:"antifroad-worker" description:"Имя сервиса: строчные латинские буквы, цифры, '-' и '_'"`
+} //@name AdminServiceClientsPostHandler.RequestBody
+
+type ResponseBody struct {
+	ID        uint64 `json:"id" example:"1"`
+	Name      string `json:"name" example:"antifroad-worker"`
+	Secret    string `json:"secret" example:"q3Jx0..." description:"Секрет для подписи запросов, показывается только один раз"`
+	CreatedAt string `json:"createdAt" example:"2025-10-19T19:02:29+03:00"`
+} //@name AdminServiceClientsPostHandler.ResponseBody
+
+type Request struct {
+	ActorID models.ID
+	Name    string
+}
+
+func newRequest(c *gin.Context) (*Request, error) {
+	var body RequestBody
+	if err := c.ShouldBindBodyWithJSON(&body); err != nil {
+		return nil, err
+	}
+
+	return &Request{
+		ActorID: models.ID(api.GetUserID(c)),
+		Name:    body.Name,
+	}, nil
+}
+
+func newResponseBody(client *models.ServiceClient) *ResponseBody {
+	return &ResponseBody{
+		ID:        uint64(client.ID),
+		Name:      client.Name,
+		Secret:    client.Secret,
+		CreatedAt: proto.MarshalTime(client.CreatedAt),
+	}
+}
@@ -0,0 +1,109 @@
+package admin_service_clients_post_handler
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/ruslanonly/blindtyping/src/internal"
+	"github.com/ruslanonly/blindtyping/src/internal/api/middleware"
+	"github.com/ruslanonly/blindtyping/src/internal/models"
+	"github.com/ruslanonly/blindtyping/src/internal/services/service_client_service"
+	"github.com/ruslanonly/blindtyping/src/internal/shared/proto"
+)
+
+const handlerName = "admin_service_clients_post_handler"
+
+type serviceClientService interface {
+	Create(ctx context.Context, in *service_client_service.CreateIn) (*models.ServiceClient, error)
+}
+
+type Handler struct {
+	serviceClientService serviceClientService
+	logger               internal.Logger
+}
+
+func (h *Handler) handleError(ctx context.Context, c *gin.Context, err error) {
+	var (
+		status  = http.StatusInternalServerError
+		message = "something went wrong serverside"
+	)
+
+	switch {
+	case service_client_service.IsInvalidNameError(err):
+		status = http.StatusBadRequest
+		message = err.Error()
+	case service_client_service.IsClientAlreadyExistsError(err):
+		status = http.StatusConflict
+		message = err.Error()
+	}
+
+	ctx = h.logger.WithError(h.logger.WithStatusCode(ctx, status), err)
+
+	switch status {
+	case http.StatusInternalServerError:
+		h.logger.Error(ctx)
+	default:
+		h.logger.Warning(ctx)
+	}
+
+	proto.WriteError(c, status, message)
+}
+
+// Handle godoc
+// @Summary     Создать учетные данные внутреннего сервиса
+// @Description Создает учетные данные для вызова служебных ручек (например, антифрода). Сервис подписывает запросы секретом: заголовок X-Service-Signature содержит HMAC-SHA256 от строк "метод\nпуть с query\nX-Service-Timestamp\nsha256 тела в hex". Секрет возвращается только один раз. Доступно администраторам.
+// @Tags        Admin
+// @Accept      json
+// @Produce     json
+// @Security    ApiKeyAuth
+// @Param       body body RequestBody true "Имя сервиса"
+// @Success     201 {object} ResponseBody "Учетные данные созданы"
+// @Failure     400 {object} proto.Error "Неверное имя сервиса"
+// @Failure     401 {object} proto.Error "Пользователь не авторизован"
+// @Failure     403 {object} proto.Error "Недостаточно прав"
+// @Failure     409 {object} proto.Error "Сервис с таким именем уже существует"
+// @Failure     500 {object} proto.Error "Внутренняя ошибка сервера (смотреть логи)"
+// @Router      /admin/service-clients [post]
+func (h *Handler) Handle(c *gin.Context) {
+	ctx := h.logger.WithHandlerName(c.Request.Context(), handlerName)
+
+	req, err := newRequest(c)
+	if err != nil {
+		ctx = h.logger.WithStatusCode(ctx, http.StatusBadRequest)
+		h.logger.Warning(h.logger.WithError(ctx, err))
+		proto.WriteError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	client, err := h.serviceClientService.Create(ctx, &service_client_service.CreateIn{
+		ActorID: req.ActorID,
+		Name:    req.Name,
+	})
+	if err != nil {
+		h.handleError(ctx, c, err)
+		return
+	}
+
+	proto.WriteJSON(c, http.StatusCreated, newResponseBody(client))
+}
+
+func (h *Handler) Method() string {
+	return http.MethodPost
+}
+
+func (h *Handler) Path() string {
+	return "/admin/service-clients"
+}
+
+func (h *Handler) Middleware() []string {
+	return []string{middleware.Auth, middleware.AccessRevocation, middleware.Session, middleware.Admin}
+}
+
+func New(serviceClientService serviceClientService, logger internal.Logger) *Handler {
+	return &Handler{
+		serviceClientService: serviceClientService,
+		logger:               logger,
+	}
+}
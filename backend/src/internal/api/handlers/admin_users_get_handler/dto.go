@@ -0,0 +1,76 @@
+package admin_users_get_handler
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/ruslanonly/blindtyping/src/internal/models"
+	"github.com/ruslanonly/blindtyping/src/internal/shared/proto"
+)
+
+type User struct {
+	ID       uint64  `json:"id" example:"1"`
+	Email    string  `json:"email" example:"ffh@example.com"`
+	Username string  `json:"username" example:"ffh255"`
+	Provider string  `json:"provider" example:"github"`
+	Role     string  `json:"role" example:"user"`
+	JoinedAt string  `json:"joinedAt" example:"2025-10-19T19:02:29+03:00"`
+	BannedAt *string `json:"bannedAt" example:"2025-10-20T19:02:29+03:00"`
+} //@name AdminUsersGetHandler.User
+
+type ResponseBody struct {
+	Users []User `json:"users"`
+} //@name AdminUsersGetHandler.ResponseBody
+
+type Request struct {
+	Query string
+	Limit int
+}
+
+func newRequest(c *gin.Context) (*Request, error) {
+	query := c.Query("query")
+	if query == "" {
+		return nil, errors.New("query is required")
+	}
+
+	r := &Request{Query: query}
+
+	if limit := c.Query("limit"); limit != "" {
+		parsed, err := strconv.Atoi(limit)
+		if err != nil {
+			return nil, err
+		}
+		r.Limit = parsed
+	}
+
+	return r, nil
+}
+
+func newUser(account *models.UserAccount) User {
+	user := User{
+		ID:       uint64(account.ID),
+		Email:    account.Email,
+		Username: account.Nickname,
+		Provider: account.Provider,
+		Role:     string(account.Role),
+		JoinedAt: proto.MarshalTime(account.CreatedAt),
+	}
+
+	if account.BannedAt != nil {
+		bannedAt := proto.MarshalTime(*account.BannedAt)
+		user.BannedAt = &bannedAt
+	}
+
+	return user
+}
+
+func newResponseBody(accounts []*models.UserAccount) *ResponseBody {
+	users := make([]User, 0, len(accounts))
+	for _, account := range accounts {
+		users = append(users, newUser(account))
+	}
+
+	return &ResponseBody{Users: users}
+}
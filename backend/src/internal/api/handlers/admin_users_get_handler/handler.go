@@ -0,0 +1,84 @@
+package admin_users_get_handler
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/ruslanonly/blindtyping/src/internal"
+	"github.com/ruslanonly/blindtyping/src/internal/api/middleware"
+	"github.com/ruslanonly/blindtyping/src/internal/models"
+	"github.com/ruslanonly/blindtyping/src/internal/services/admin_service"
+	"github.com/ruslanonly/blindtyping/src/internal/shared/proto"
+)
+
+const handlerName = "admin_users_get_handler"
+
+type adminService interface {
+	Search(ctx context.Context, in *admin_service.SearchIn) ([]*models.UserAccount, error)
+}
+
+type Handler struct {
+	adminService adminService
+	logger       internal.Logger
+}
+
+// Handle godoc
+// @Summary     Поиск пользователей
+// @Description Ищет пользователей по части имени пользователя или email. Доступно модераторам и администраторам.
+// @Tags        Admin
+// @Accept      json
+// @Produce     json
+// @Security    ApiKeyAuth
+// @Param       query query string true "Часть имени пользователя или email" Example(ffh)
+// @Param       limit query int false "Максимальное кол-во пользователей (по умолчанию 20, не больше 100)"
+// @Success     200 {object} ResponseBody "Найденные пользователи"
+// @Failure     400 {object} proto.Error "Отсутствует запрос"
+// @Failure     401 {object} proto.Error "Пользователь не авторизован"
+// @Failure     403 {object} proto.Error "Недостаточно прав"
+// @Failure     500 {object} proto.Error "Внутренняя ошибка сервера (смотреть логи)"
+// @Router      /admin/users [get]
+func (h *Handler) Handle(c *gin.Context) {
+	ctx := h.logger.WithHandlerName(c.Request.Context(), handlerName)
+
+	req, err := newRequest(c)
+	if err != nil {
+		ctx = h.logger.WithStatusCode(ctx, http.StatusBadRequest)
+		h.logger.Warning(h.logger.WithError(ctx, err))
+		proto.WriteError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	accounts, err := h.adminService.Search(ctx, &admin_service.SearchIn{
+		Query: req.Query,
+		Limit: req.Limit,
+	})
+	if err != nil {
+		ctx = h.logger.WithStatusCode(ctx, http.StatusInternalServerError)
+		h.logger.Error(h.logger.WithError(ctx, err))
+		proto.WriteError(c, http.StatusInternalServerError, "something went wrong")
+		return
+	}
+
+	proto.WriteJSON(c, http.StatusOK, newResponseBody(accounts))
+}
+
+func (h *Handler) Method() string {
+	return http.MethodGet
+}
+
+func (h *Handler) Path() string {
+	return "/admin/users"
+}
+
+func (h *Handler) Middleware() []string {
+	return []string{middleware.Auth, middleware.AccessRevocation, middleware.Session, middleware.Moderator}
+}
+
+func New(adminService adminService, logger internal.Logger) *Handler {
+	return &Handler{
+		adminService: adminService,
+		logger:       logger,
+	}
+}
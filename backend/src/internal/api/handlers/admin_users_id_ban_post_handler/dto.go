@@ -0,0 +1,27 @@
+package admin_users_id_ban_post_handler
+
+import (
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/ruslanonly/blindtyping/src/internal/api"
+	"github.com/ruslanonly/blindtyping/src/internal/models"
+)
+
+type Request struct {
+	ActorID models.ID
+	UserID  models.ID
+}
+
+func newRequest(c *gin.Context) (*Request, error) {
+	userID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Request{
+		ActorID: models.ID(api.GetUserID(c)),
+		UserID:  models.ID(userID),
+	}, nil
+}
@@ -0,0 +1,55 @@
+package admin_users_id_get_handler
+
+import (
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/ruslanonly/blindtyping/src/internal/models"
+	"github.com/ruslanonly/blindtyping/src/internal/shared/proto"
+)
+
+type User struct {
+	ID       uint64  `json:"id" example:"1"`
+	Email    string  `json:"email" example:"ffh@example.com"`
+	Username string  `json:"username" example:"ffh255"`
+	Provider string  `json:"provider" example:"github"`
+	Role     string  `json:"role" example:"user"`
+	JoinedAt string  `json:"joinedAt" example:"2025-10-19T19:02:29+03:00"`
+	BannedAt *string `json:"bannedAt" example:"2025-10-20T19:02:29+03:00"`
+} //@name AdminUsersIDGetHandler.User
+
+type ResponseBody struct {
+	User User `json:"user"`
+} //@name AdminUsersIDGetHandler.ResponseBody
+
+type Request struct {
+	UserID models.ID
+}
+
+func newRequest(c *gin.Context) (*Request, error) {
+	userID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Request{UserID: models.ID(userID)}, nil
+}
+
+func newResponseBody(account *models.UserAccount) *ResponseBody {
+	user := User{
+		ID:       uint64(account.ID),
+		Email:    account.Email,
+		Username: account.Nickname,
+		Provider: account.Provider,
+		Role:     string(account.Role),
+		JoinedAt: proto.MarshalTime(account.CreatedAt),
+	}
+
+	if account.BannedAt != nil {
+		bannedAt := proto.MarshalTime(*account.BannedAt)
+		user.BannedAt = &bannedAt
+	}
+
+	return &ResponseBody{User: user}
+}
@@ -0,0 +1,103 @@
+package admin_users_id_get_handler
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/ruslanonly/blindtyping/src/internal"
+	"github.com/ruslanonly/blindtyping/src/internal/api/middleware"
+	"github.com/ruslanonly/blindtyping/src/internal/models"
+	"github.com/ruslanonly/blindtyping/src/internal/services/admin_service"
+	"github.com/ruslanonly/blindtyping/src/internal/shared/proto"
+)
+
+const handlerName = "admin_users_id_get_handler"
+
+type adminService interface {
+	GetUser(ctx context.Context, id models.ID) (*models.UserAccount, error)
+}
+
+type Handler struct {
+	adminService adminService
+	logger       internal.Logger
+}
+
+func (h *Handler) handleError(ctx context.Context, c *gin.Context, err error) {
+	var (
+		status  = http.StatusInternalServerError
+		message = "something went wrong serverside"
+	)
+
+	switch {
+	case admin_service.IsUserNotFoundError(err):
+		status = http.StatusNotFound
+		message = "user not found"
+	}
+
+	ctx = h.logger.WithError(h.logger.WithStatusCode(ctx, status), err)
+
+	switch status {
+	case http.StatusInternalServerError:
+		h.logger.Error(ctx)
+	default:
+		h.logger.Warning(ctx)
+	}
+
+	proto.WriteError(c, status, message)
+}
+
+// Handle godoc
+// @Summary     Получить пользователя
+// @Description Получить учетную запись пользователя по ID. Доступно модераторам и администраторам.
+// @Tags        Admin
+// @Accept      json
+// @Produce     json
+// @Security    ApiKeyAuth
+// @Param       id path int true "ID пользователя"
+// @Success     200 {object} ResponseBody "Пользователь"
+// @Failure     400 {object} proto.Error "Неверный ID пользователя"
+// @Failure     401 {object} proto.Error "Пользователь не авторизован"
+// @Failure     403 {object} proto.Error "Недостаточно прав"
+// @Failure     404 {object} proto.Error "Пользователь не найден"
+// @Failure     500 {object} proto.Error "Внутренняя ошибка сервера (смотреть логи)"
+// @Router      /admin/users/{id} [get]
+func (h *Handler) Handle(c *gin.Context) {
+	ctx := h.logger.WithHandlerName(c.Request.Context(), handlerName)
+
+	req, err := newRequest(c)
+	if err != nil {
+		ctx = h.logger.WithStatusCode(ctx, http.StatusBadRequest)
+		h.logger.Warning(h.logger.WithError(ctx, err))
+		proto.WriteError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	account, err := h.adminService.GetUser(ctx, req.UserID)
+	if err != nil {
+		h.handleError(ctx, c, err)
+		return
+	}
+
+	proto.WriteJSON(c, http.StatusOK, newResponseBody(account))
+}
+
+func (h *Handler) Method() string {
+	return http.MethodGet
+}
+
+func (h *Handler) Path() string {
+	return "/admin/users/:id"
+}
+
+func (h *Handler) Middleware() []string {
+	return []string{middleware.Auth, middleware.AccessRevocation, middleware.Session, middleware.Moderator}
+}
+
+func New(adminService adminService, logger internal.Logger) *Handler {
+	return &Handler{
+		adminService: adminService,
+		logger:       logger,
+	}
+}
@@ -0,0 +1,38 @@
+package admin_users_id_role_patch_handler
+
+import (
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/ruslanonly/blindtyping/src/internal/api"
+	"github.com/ruslanonly/blindtyping/src/internal/models"
+)
+
+type RequestBody struct {
+	Role string `json:"role" example:"moderator" description:"Новая роль: user, moderator или admin"`
+} //@name AdminUsersIDRolePatchHandler.RequestBody
+
+type Request struct {
+	ActorID models.ID
+	UserID  models.ID
+	Role    models.Role
+}
+
+func newRequest(c *gin.Context) (*Request, error) {
+	userID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return nil, err
+	}
+
+	var body RequestBody
+	if err = c.ShouldBindBodyWithJSON(&body); err != nil {
+		return nil, err
+	}
+
+	return &Request{
+		ActorID: models.ID(api.GetUserID(c)),
+		UserID:  models.ID(userID),
+		Role:    models.Role(body.Role),
+	}, nil
+}
@@ -0,0 +1,77 @@
+package admin_users_id_sanctions_get_handler
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/ruslanonly/blindtyping/src/internal/models"
+	"github.com/ruslanonly/blindtyping/src/internal/shared/proto"
+)
+
+type Sanction struct {
+	ID        uint64  `json:"id" example:"1"`
+	Kind      string  `json:"kind" example:"suspension"`
+	Reason    string  `json:"reason" example:"impossible keystroke timings"`
+	ActorID   *uint64 `json:"actorId" example:"2"`
+	IsActive  bool    `json:"isActive" example:"false"`
+	CreatedAt string  `json:"createdAt" example:"2025-10-19T19:02:29+03:00"`
+	ExpiresAt *string `json:"expiresAt" example:"2025-10-26T19:02:29+03:00"`
+	LiftedAt  *string `json:"liftedAt" example:"2025-10-20T10:00:00+03:00"`
+	LiftedBy  *uint64 `json:"liftedBy" example:"2"`
+} //@name AdminUsersIDSanctionsGetHandler.Sanction
+
+type ResponseBody struct {
+	Sanctions []Sanction `json:"sanctions"`
+} //@name AdminUsersIDSanctionsGetHandler.ResponseBody
+
+type Request struct {
+	UserID models.ID
+}
+
+func newRequest(c *gin.Context) (*Request, error) {
+	userID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Request{UserID: models.ID(userID)}, nil
+}
+
+func marshalOptionalID(id *models.ID) *uint64 {
+	if id == nil {
+		return nil
+	}
+	value := uint64(*id)
+	return &value
+}
+
+func marshalOptionalTime(t *time.Time) *string {
+	if t == nil {
+		return nil
+	}
+	formatted := proto.MarshalTime(*t)
+	return &formatted
+}
+
+func newResponseBody(sanctions []*models.Sanction) *ResponseBody {
+	now := time.Now()
+
+	body := make([]Sanction, 0, len(sanctions))
+	for _, sanction := range sanctions {
+		body = append(body, Sanction{
+			ID:        uint64(sanction.ID),
+			Kind:      string(sanction.Kind),
+			Reason:    sanction.Reason,
+			ActorID:   marshalOptionalID(sanction.ActorID),
+			IsActive:  sanction.IsActive(now),
+			CreatedAt: proto.MarshalTime(sanction.CreatedAt),
+			ExpiresAt: marshalOptionalTime(sanction.ExpiresAt),
+			LiftedAt:  marshalOptionalTime(sanction.LiftedAt),
+			LiftedBy:  marshalOptionalID(sanction.LiftedBy),
+		})
+	}
+
+	return &ResponseBody{Sanctions: body}
+}
@@ -0,0 +1,117 @@
+package admin_users_id_sanctions_id_delete_handler
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/ruslanonly/blindtyping/src/internal"
+	"github.com/ruslanonly/blindtyping/src/internal/api/middleware"
+	"github.com/ruslanonly/blindtyping/src/internal/services/admin_service"
+	"github.com/ruslanonly/blindtyping/src/internal/shared/proto"
+)
+
+const handlerName = "admin_users_id_sanctions_id_delete_handler"
+
+type adminService interface {
+	LiftSanction(ctx context.Context, in *admin_service.LiftSanctionIn) error
+}
+
+type Handler struct {
+	adminService adminService
+	logger       internal.Logger
+}
+
+func (h *Handler) handleError(ctx context.Context, c *gin.Context, err error) {
+	var (
+		status  = http.StatusInternalServerError
+		message = "something went wrong serverside"
+	)
+
+	switch {
+	case admin_service.IsUserNotFoundError(err):
+		status = http.StatusNotFound
+		message = "user not found"
+	case admin_service.IsSanctionNotFoundError(err):
+		status = http.StatusNotFound
+		message = "sanction not found"
+	case admin_service.IsActionOnSelfError(err), admin_service.IsInsufficientRoleError(err):
+		status = http.StatusForbidden
+		message = err.Error()
+	case admin_service.IsSanctionAlreadyLiftedError(err):
+		status = http.StatusConflict
+		message = err.Error()
+	}
+
+	ctx = h.logger.WithError(h.logger.WithStatusCode(ctx, status), err)
+
+	switch status {
+	case http.StatusInternalServerError:
+		h.logger.Error(ctx)
+	default:
+		h.logger.Warning(ctx)
+	}
+
+	proto.WriteError(c, status, message)
+}
+
+// Handle godoc
+// @Summary     Снять санкцию с пользователя
+// @Description Досрочно снимает активную санкцию. Запись о санкции остается в истории. Доступно модераторам и администраторам.
+// @Tags        Admin
+// @Accept      json
+// @Produce     json
+// @Security    ApiKeyAuth
+// @Param       id path int true "ID пользователя"
+// @Param       sanctionId path int true "ID санкции"
+// @Success     200 "Санкция снята"
+// @Failure     400 {object} proto.Error "Неверный ID пользователя или санкции"
+// @Failure     401 {object} proto.Error "Пользователь не авторизован"
+// @Failure     403 {object} proto.Error "Недостаточно прав"
+// @Failure     404 {object} proto.Error "Пользователь или санкция не найдены"
+// @Failure     409 {object} proto.Error "Санкция уже снята или истекла"
+// @Failure     500 {object} proto.Error "Внутренняя ошибка сервера (смотреть логи)"
+// @Router      /admin/users/{id}/sanctions/{sanctionId} [delete]
+func (h *Handler) Handle(c *gin.Context) {
+	ctx := h.logger.WithHandlerName(c.Request.Context(), handlerName)
+
+	req, err := newRequest(c)
+	if err != nil {
+		ctx = h.logger.WithStatusCode(ctx, http.StatusBadRequest)
+		h.logger.Warning(h.logger.WithError(ctx, err))
+		proto.WriteError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	err = h.adminService.LiftSanction(ctx, &admin_service.LiftSanctionIn{
+		ActionIn: admin_service.ActionIn{
+			ActorID: req.ActorID,
+			UserID:  req.UserID,
+		},
+		SanctionID: req.SanctionID,
+	})
+	if err != nil {
+		h.handleError(ctx, c, err)
+		return
+	}
+}
+
+func (h *Handler) Method() string {
+	return http.MethodDelete
+}
+
+func (h *Handler) Path() string {
+	return "/admin/users/:id/sanctions/:sanctionId"
+}
+
+func (h *Handler) Middleware() []string {
+	return []string{middleware.Auth, middleware.AccessRevocation, middleware.Session, middleware.Moderator}
+}
+
+func New(adminService adminService, logger internal.Logger) *Handler {
+	return &Handler{
+		adminService: adminService,
+		logger:       logger,
+	}
+}
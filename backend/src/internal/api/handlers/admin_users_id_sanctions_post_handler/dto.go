@@ -0,0 +1,93 @@
+package admin_users_id_sanctions_post_handler
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/ruslanonly/blindtyping/src/internal/api"
+	"github.com/ruslanonly/blindtyping/src/internal/models"
+	"github.com/ruslanonly/blindtyping/src/internal/shared/proto"
+)
+
+type RequestBody struct {
+	Kind      string  `json:"kind" example:"shadow_ban" description:"Тип санкции: suspension или shadow_ban"`
+	Reason    string  `json:"reason" example:"wpm is too high for the recorded keystrokes" description:"Причина санкции"`
+	ExpiresAt *string `json:"expiresAt" example:"2026-10-19T19:02:29+03:00" description:"Срок действия в RFC3339, без него санкция бессрочная"`
+} //@name AdminUsersIDSanctionsPostHandler.RequestBody
+
+type Sanction struct {
+	ID        uint64  `json:"id" example:"1"`
+	Kind      string  `json:"kind" example:"shadow_ban"`
+	Reason    string  `json:"reason" example:"wpm is too high for the recorded keystrokes"`
+	ActorID   *uint64 `json:"actorId" example:"2"`
+	CreatedAt string  `json:"createdAt" example:"2025-10-19T19:02:29+03:00"`
+	ExpiresAt *string `json:"expiresAt" example:"2026-10-19T19:02:29+03:00"`
+} //@name AdminUsersIDSanctionsPostHandler.Sanction
+
+type ResponseBody struct {
+	Sanction Sanction `json:"sanction"`
+} //@name AdminUsersIDSanctionsPostHandler.ResponseBody
+
+type Request struct {
+	ActorID   models.ID
+	UserID    models.ID
+	Kind      models.SanctionKind
+	Reason    string
+	ExpiresAt *time.Time
+}
+
+func newRequest(c *gin.Context) (*Request, error) {
+	userID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return nil, err
+	}
+
+	var body RequestBody
+	if err = c.ShouldBindBodyWithJSON(&body); err != nil {
+		return nil, err
+	}
+
+	r := &Request{
+		ActorID: models.ID(api.GetUserID(c)),
+		UserID:  models.ID(userID),
+		Kind:    models.SanctionKind(body.Kind),
+		Reason:  body.Reason,
+	}
+
+	if body.ExpiresAt != nil {
+		expiresAt, err := proto.UnmarshalTime(*body.ExpiresAt)
+		if err != nil {
+			return nil, err
+		}
+		r.ExpiresAt = &expiresAt
+	}
+
+	return r, nil
+}
+
+func newResponseBody(sanction *models.Sanction) *ResponseBody {
+	var actorID *uint64
+	if sanction.ActorID != nil {
+		id := uint64(*sanction.ActorID)
+		actorID = &id
+	}
+
+	var expiresAt *string
+	if sanction.ExpiresAt != nil {
+		formatted := proto.MarshalTime(*sanction.ExpiresAt)
+		expiresAt = &formatted
+	}
+
+	return &ResponseBody{
+		Sanction: Sanction{
+			ID:        uint64(sanction.ID),
+			Kind:      string(sanction.Kind),
+			Reason:    sanction.Reason,
+			ActorID:   actorID,
+			CreatedAt: proto.MarshalTime(sanction.CreatedAt),
+			ExpiresAt: expiresAt,
+		},
+	}
+}
@@ -0,0 +1,124 @@
+package admin_users_id_sanctions_post_handler
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/ruslanonly/blindtyping/src/internal"
+	"github.com/ruslanonly/blindtyping/src/internal/api/middleware"
+	"github.com/ruslanonly/blindtyping/src/internal/models"
+	"github.com/ruslanonly/blindtyping/src/internal/services/admin_service"
+	"github.com/ruslanonly/blindtyping/src/internal/shared/proto"
+)
+
+const handlerName = "admin_users_id_sanctions_post_handler"
+
+type adminService interface {
+	Sanction(ctx context.Context, in *admin_service.SanctionIn) (*models.Sanction, error)
+}
+
+type Handler struct {
+	adminService adminService
+	logger       internal.Logger
+}
+
+func (h *Handler) handleError(ctx context.Context, c *gin.Context, err error) {
+	var (
+		status  = http.StatusInternalServerError
+		message = "something went wrong serverside"
+	)
+
+	switch {
+	case admin_service.IsInvalidSanctionKindError(err),
+		admin_service.IsInvalidSanctionReasonError(err),
+		admin_service.IsInvalidSanctionExpiryError(err):
+		status = http.StatusBadRequest
+		message = err.Error()
+	case admin_service.IsUserNotFoundError(err):
+		status = http.StatusNotFound
+		message = "user not found"
+	case admin_service.IsActionOnSelfError(err), admin_service.IsInsufficientRoleError(err):
+		status = http.StatusForbidden
+		message = err.Error()
+	case admin_service.IsUserAlreadySanctionedError(err):
+		status = http.StatusConflict
+		message = err.Error()
+	}
+
+	ctx = h.logger.WithError(h.logger.WithStatusCode(ctx, status), err)
+
+	switch status {
+	case http.StatusInternalServerError:
+		h.logger.Error(ctx)
+	default:
+		h.logger.Warning(ctx)
+	}
+
+	proto.WriteError(c, status, message)
+}
+
+// Handle godoc
+// @Summary     Наложить санкцию на пользователя
+// @Description Блокирует пользователя (suspension) или скрывает его результаты из лидербордов и публичного профиля (shadow_ban). Блокировка запрещает вход и отзывает все сессии. Санкция без срока действия снимается только модератором. Доступно модераторам и администраторам.
+// @Tags        Admin
+// @Accept      json
+// @Produce     json
+// @Security    ApiKeyAuth
+// @Param       id path int true "ID пользователя"
+// @Param       body body RequestBody true "Санкция"
+// @Success     201 {object} ResponseBody "Санкция наложена"
+// @Failure     400 {object} proto.Error "Неверный тип, причина или срок действия санкции"
+// @Failure     401 {object} proto.Error "Пользователь не авторизован"
+// @Failure     403 {object} proto.Error "Недостаточно прав"
+// @Failure     404 {object} proto.Error "Пользователь не найден"
+// @Failure     409 {object} proto.Error "У пользователя уже есть активная санкция этого типа"
+// @Failure     500 {object} proto.Error "Внутренняя ошибка сервера (смотреть логи)"
+// @Router      /admin/users/{id}/sanctions [post]
+func (h *Handler) Handle(c *gin.Context) {
+	ctx := h.logger.WithHandlerName(c.Request.Context(), handlerName)
+
+	req, err := newRequest(c)
+	if err != nil {
+		ctx = h.logger.WithStatusCode(ctx, http.StatusBadRequest)
+		h.logger.Warning(h.logger.WithError(ctx, err))
+		proto.WriteError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	sanction, err := h.adminService.Sanction(ctx, &admin_service.SanctionIn{
+		ActionIn: admin_service.ActionIn{
+			ActorID: req.ActorID,
+			UserID:  req.UserID,
+		},
+		Kind:      req.Kind,
+		Reason:    req.Reason,
+		ExpiresAt: req.ExpiresAt,
+	})
+	if err != nil {
+		h.handleError(ctx, c, err)
+		return
+	}
+
+	proto.WriteJSON(c, http.StatusCreated, newResponseBody(sanction))
+}
+
+func (h *Handler) Method() string {
+	return http.MethodPost
+}
+
+func (h *Handler) Path() string {
+	return "/admin/users/:id/sanctions"
+}
+
+func (h *Handler) Middleware() []string {
+	return []string{middleware.Auth, middleware.AccessRevocation, middleware.Session, middleware.Moderator}
+}
+
+func New(adminService adminService, logger internal.Logger) *Handler {
+	return &Handler{
+		adminService: adminService,
+		logger:       logger,
+	}
+}
@@ -0,0 +1,81 @@
+package admin_users_id_statistics_get_handler
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/ruslanonly/blindtyping/src/internal/models"
+	"github.com/ruslanonly/blindtyping/src/internal/shared/proto"
+)
+
+type Stats struct {
+	ID                            uint    `json:"id" example:"1" format:"uint"`
+	WPM                           float64 `json:"wpm" example:"42.5" format:"float64"`
+	CPM                           float64 `json:"cpm" example:"210.3" format:"float64"`
+	Accuracy                      float64 `json:"accuracy" example:"98.7" format:"float64"`
+	Duration                      int64   `json:"duration" example:"120000" format:"int64" description:"Duration in microseconds"`
+	PlayedAt                      string  `json:"playedAt" example:"2023-12-25T15:04:05Z" format:"date-time"`
+	Language                      string  `json:"language" example:"english"`
+	Mode                          string  `json:"mode" example:"time"`
+	SubMode                       string  `json:"submode" example:"30s"`
+	IsPunctuation                 bool    `json:"isPunctuation" example:"true"`
+	UncompletedTestsCount         int64   `json:"uncompletedTestsCount" example:"0"`
+	UncompletedTestsTotalDuration int64   `json:"uncompletedTestsTotalDuration" example:"0"`
+} //@name AdminUsersIDStatisticsGetHandler.Stats
+
+type ResponseBody struct {
+	Statistics []Stats `json:"statistics"`
+} //@name AdminUsersIDStatisticsGetHandler.ResponseBody
+
+type Request struct {
+	UserID   models.ID
+	DateFrom *time.Time
+}
+
+func newRequest(c *gin.Context) (*Request, error) {
+	userID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &Request{UserID: models.ID(userID)}
+
+	dateFromString := c.Query("dateFrom")
+	if dateFromString == "" {
+		return r, nil
+	}
+
+	dateFrom, err := proto.UnmarshalTime(dateFromString)
+	if err != nil {
+		return nil, err
+	}
+
+	r.DateFrom = &dateFrom
+
+	return r, nil
+}
+
+func newResponseBody(stats []models.Statistics) *ResponseBody {
+	body := make([]Stats, len(stats))
+
+	for i, stat := range stats {
+		body[i] = Stats{
+			ID:                            uint(stat.ID),
+			WPM:                           float64(stat.WPM),
+			CPM:                           float64(stat.CPM),
+			Accuracy:                      float64(stat.Accuracy),
+			Duration:                      stat.Duration.Milliseconds(),
+			PlayedAt:                      proto.MarshalTime(stat.PlayedAt),
+			Language:                      string(stat.Language),
+			Mode:                          string(stat.Mode),
+			SubMode:                       string(stat.SubMode),
+			IsPunctuation:                 stat.IsPunctuation,
+			UncompletedTestsCount:         int64(stat.UncompletedTestsCount),
+			UncompletedTestsTotalDuration: stat.UncompletedTestsTotalDuration.Milliseconds(),
+		}
+	}
+
+	return &ResponseBody{Statistics: body}
+}
@@ -1,24 +1,7 @@
 package antifroad_key_get_handler
 
-import (
-	"fmt"
-
-	"github.com/gin-gonic/gin"
-)
-
 type ResponseBody struct {
-	Key string `json:"key" example:"12345" description:"Антифрод-ключ"`
+	KeyID     string `json:"keyId" example:"3f2a9c1e7b8d4e6f" description:"Идентификатор ключа, который клиент передает вместе с подписью"`
+	Key       string `json:"key" example:"12345" description:"Антифрод-ключ"`
+	NotBefore string `json:"notBefore" example:"2025-10-19T19:02:29+03:00" description:"Время, с которого ключ действителен"`
 } //@name AntifroadKeyGetHandler.ResponseBody
-
-type Request struct {
-	Password string
-}
-
-func newRequest(c *gin.Context) (*Request, error) {
-	password := c.Query("password")
-	if password == "" {
-		return nil, fmt.Errorf("password can not be empty")
-	}
-
-	return &Request{Password: password}, nil
-}
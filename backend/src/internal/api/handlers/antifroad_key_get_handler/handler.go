@@ -3,15 +3,13 @@ package antifroad_key_get_handler
 import (
 	"context"
 	"net/http"
-	"time"
 
 	"github.com/gin-gonic/gin"
-	"github.com/ulule/limiter/v3"
-	"github.com/ulule/limiter/v3/drivers/store/memory"
 
 	"github.com/ruslanonly/blindtyping/src/internal"
+	"github.com/ruslanonly/blindtyping/src/internal/api/middleware"
 	"github.com/ruslanonly/blindtyping/src/internal/models"
-	"github.com/ruslanonly/blindtyping/src/internal/services/antifroad_service"
+	"github.com/ruslanonly/blindtyping/src/internal/services/antifroad_key_service"
 	"github.com/ruslanonly/blindtyping/src/internal/shared/proto"
 )
 
@@ -19,61 +17,37 @@ const (
 	handlerName = "antifroad_key_get_handler"
 )
 
-type antifroadService interface {
-	GetKey(password string) (*models.AntifroadKey, error)
+type antifroadKeyService interface {
+	Current(ctx context.Context) (*models.AntifroadKeyVersion, error)
 }
 
 type Handler struct {
-	antifroadService antifroadService
-	logger           internal.Logger
-	limiter          *limiter.Limiter
+	antifroadKeyService antifroadKeyService
+	logger              internal.Logger
 }
 
 // Handle godoc
 // @Summary Получить актуальный антофрод-ключ
-// @Description Получить актуальный антофрод-ключ. Ручка используется только внутри контура blindtyping.
+// @Description Получить актуальный антофрод-ключ. Ручка используется только внутри контура blindtyping. Запрос подписывается секретом вызывающего сервиса.
 // @Tags Antifroad
 // @Accept  json
 // @Produce  json
-// @Param password query string true "Пароль для авторизации в модуле антифрода" Example(12345)
-// @Success 200 {object} ResponseBody "Антифрод-ключ"
-// @Failure 400 {object} proto.Error "Отсутствуем пароль"
-// @Failure 401 {object} proto.Error "Неправильный пароль"
+// @Param X-Service-Client header string true "Имя вызывающего сервиса"
+// @Param X-Service-Timestamp header string true "Время запроса в unix-секундах"
+// @Param X-Service-Signature header string true "HMAC-SHA256 подпись запроса"
+// @Success 200 {object} ResponseBody "Антифрод-ключ и его идентификатор"
+// @Failure 401 {object} proto.Error "Неверная подпись, запрос устарел или уже был выполнен"
 // @Failure 404 {object} proto.Error "Ключ не найден (вообще такого быть не должно)"
+// @Failure 429 {object} proto.Error "Превышен лимит запросов"
 // @Router /antifroad/key [get]
 func (h *Handler) Handle(c *gin.Context) {
 	ctx := h.logger.WithHandlerName(c.Request.Context(), handlerName)
 
-	limiterCtx, err := h.limiter.Get(ctx, handlerName)
-	if err != nil {
-		h.logger.Error(h.logger.WithError(ctx, err))
-		proto.WriteError(c, http.StatusBadRequest, err)
-		return
-	}
-
-	if limiterCtx.Reached {
-		h.logger.Warning(h.logger.WithStatusCode(ctx, http.StatusTooManyRequests))
-		proto.WriteError(c, http.StatusTooManyRequests, "too many requests")
-		return
-	}
-
-	req, err := newRequest(c)
-	if err != nil {
-		h.logger.Warning(h.logger.WithError(ctx, err))
-		proto.WriteError(c, http.StatusBadRequest, err)
-		return
-	}
-
-	key, err := h.antifroadService.GetKey(req.Password)
+	key, err := h.antifroadKeyService.Current(ctx)
 	if err != nil {
 		h.handleError(c, ctx, err)
 		return
 	}
-	if key == nil || key.Value == "" {
-		h.logger.Error(h.logger.WithMsg(ctx, "antifroad key not found"))
-		proto.WriteError(c, http.StatusNotFound, "key not found")
-		return
-	}
 
 	out := h.newResponseBody(key)
 
@@ -87,9 +61,9 @@ func (h *Handler) handleError(c *gin.Context, ctx context.Context, err error) {
 	)
 
 	switch {
-	case antifroad_service.IsWrongPasswordError(err):
-		message = err.Error()
-		status = http.StatusUnauthorized
+	case antifroad_key_service.IsNoActiveKeyError(err):
+		message = "key not found"
+		status = http.StatusNotFound
 	}
 
 	ctx = h.logger.WithStatusCode(ctx, status)
@@ -97,9 +71,11 @@ func (h *Handler) handleError(c *gin.Context, ctx context.Context, err error) {
 	proto.WriteError(c, status, message)
 }
 
-func (h *Handler) newResponseBody(key *models.AntifroadKey) *ResponseBody {
+func (h *Handler) newResponseBody(key *models.AntifroadKeyVersion) *ResponseBody {
 	return &ResponseBody{
-		Key: key.Value,
+		KeyID:     key.KID,
+		Key:       key.Value,
+		NotBefore: proto.MarshalTime(key.NotBefore),
 	}
 }
 
@@ -112,20 +88,12 @@ func (h *Handler) Path() string {
 }
 
 func (h *Handler) Middleware() []string {
-	return nil
+	return []string{middleware.Service}
 }
 
-func New(antifroadService antifroadService, logger internal.Logger) *Handler {
-	rate := limiter.Rate{
-		Period: 1 * time.Minute,
-		Limit:  1,
-	}
-	store := memory.NewStore()
-	limitInstance := limiter.New(store, rate)
-
+func New(antifroadKeyService antifroadKeyService, logger internal.Logger) *Handler {
 	return &Handler{
-		antifroadService: antifroadService,
-		logger:           logger,
-		limiter:          limitInstance,
+		antifroadKeyService: antifroadKeyService,
+		logger:              logger,
 	}
 }
@@ -3,14 +3,14 @@ package antifroad_rotate_keys_post_handler
 import (
 	"context"
 	"net/http"
-	"time"
 
 	"github.com/gin-gonic/gin"
-	"github.com/ulule/limiter/v3"
-	"github.com/ulule/limiter/v3/drivers/store/memory"
 
 	"github.com/ruslanonly/blindtyping/src/internal"
-	"github.com/ruslanonly/blindtyping/src/internal/services/antifroad_service"
+	"github.com/ruslanonly/blindtyping/src/internal/api"
+	"github.com/ruslanonly/blindtyping/src/internal/api/middleware"
+	"github.com/ruslanonly/blindtyping/src/internal/models"
+	"github.com/ruslanonly/blindtyping/src/internal/services/audit_service"
 	"github.com/ruslanonly/blindtyping/src/internal/shared/proto"
 )
 
@@ -18,52 +18,34 @@ const (
 	handlerName = "antifroad_rotate_keys_post_handler"
 )
 
-type antifroadService interface {
-	RotateKeys(ctx context.Context, password string) error
+type antifroadKeyService interface {
+	Rotate(ctx context.Context, trigger models.AntifroadRotationTrigger) error
 }
 
 type Handler struct {
-	antifroadService antifroadService
-	logger           internal.Logger
-	limiter          *limiter.Limiter
+	antifroadKeyService antifroadKeyService
+	logger              internal.Logger
 }
 
 // Handle godoc
 // @Summary Ротировать ключи (служебная ручка)
-// @Description Ротировать ключи. Ручка используется только внутри контура blindtyping.
+// @Description Ротировать ключи. Предыдущий ключ остается действительным в течение льготного периода. Ротация записывается в журнал. Ручка используется только внутри контура blindtyping. Запрос подписывается секретом вызывающего сервиса.
 // @Tags Antifroad
 // @Accept  json
 // @Produce  json
-// @Param password query string true "Пароль для авторизации в модуле антифрода" Example(12345)
+// @Param X-Service-Client header string true "Имя вызывающего сервиса"
+// @Param X-Service-Timestamp header string true "Время запроса в unix-секундах"
+// @Param X-Service-Signature header string true "HMAC-SHA256 подпись запроса"
 // @Success 200
-// @Failure 400 {object} proto.Error "Отсутствуем пароль"
-// @Failure 401 {object} proto.Error "Неправильный пароль"
+// @Failure 401 {object} proto.Error "Неверная подпись, запрос устарел или уже был выполнен"
+// @Failure 429 {object} proto.Error "Превышен лимит запросов"
 // @Failure 500 {object} proto.Error "Ошибка сервера"
 // @Router /antifroad/rotate-keys [post]
 func (h *Handler) Handle(c *gin.Context) {
 	ctx := h.logger.WithHandlerName(c.Request.Context(), handlerName)
+	ctx = audit_service.WithActorService(ctx, api.GetServiceClient(c))
 
-	limiterCtx, err := h.limiter.Get(ctx, handlerName)
-	if err != nil {
-		h.logger.Error(h.logger.WithError(ctx, err))
-		proto.WriteError(c, http.StatusBadRequest, err)
-		return
-	}
-
-	if limiterCtx.Reached {
-		h.logger.Warning(h.logger.WithStatusCode(ctx, http.StatusTooManyRequests))
-		proto.WriteError(c, http.StatusTooManyRequests, "too many requests")
-		return
-	}
-
-	req, err := newRequest(c)
-	if err != nil {
-		h.logger.Warning(h.logger.WithError(ctx, err))
-		proto.WriteError(c, http.StatusBadRequest, err)
-		return
-	}
-
-	err = h.antifroadService.RotateKeys(ctx, req.Password)
+	err := h.antifroadKeyService.Rotate(ctx, models.AntifroadRotationAPI)
 	if err != nil {
 		h.handleError(ctx, c, err)
 		return
@@ -76,12 +58,6 @@ func (h *Handler) handleError(ctx context.Context, c *gin.Context, err error) {
 		message = "something went wrong"
 	)
 
-	switch {
-	case antifroad_service.IsWrongPasswordError(err):
-		message = err.Error()
-		status = http.StatusUnauthorized
-	}
-
 	ctx = h.logger.WithStatusCode(ctx, status)
 	h.logger.Error(h.logger.WithError(ctx, err))
 	proto.WriteError(c, status, message)
@@ -96,20 +72,12 @@ func (h *Handler) Path() string {
 }
 
 func (h *Handler) Middleware() []string {
-	return nil
+	return []string{middleware.Service}
 }
 
-func New(service antifroadService, logger internal.Logger) *Handler {
-	rate := limiter.Rate{
-		Period: 1 * time.Minute,
-		Limit:  1,
-	}
-	store := memory.NewStore()
-	limitInstance := limiter.New(store, rate)
-
+func New(service antifroadKeyService, logger internal.Logger) *Handler {
 	return &Handler{
-		antifroadService: service,
-		logger:           logger,
-		limiter:          limitInstance,
+		antifroadKeyService: service,
+		logger:              logger,
 	}
 }
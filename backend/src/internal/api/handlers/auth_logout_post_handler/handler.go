@@ -9,6 +9,7 @@ import (
 	"github.com/ruslanonly/blindtyping/src/internal"
 	"github.com/ruslanonly/blindtyping/src/internal/api"
 	"github.com/ruslanonly/blindtyping/src/internal/api/middleware"
+	"github.com/ruslanonly/blindtyping/src/internal/models"
 	"github.com/ruslanonly/blindtyping/src/internal/services/auth_service"
 	"github.com/ruslanonly/blindtyping/src/internal/shared/proto"
 )
@@ -30,10 +31,15 @@ type cookieManager interface {
 	DeleteRefreshToken(ctx *gin.Context)
 }
 
+type auditRecorder interface {
+	RecordUserAction(ctx context.Context, action models.AuditAction, userID models.ID, details map[string]any)
+}
+
 type Handler struct {
 	authService   authService
 	cookieManager cookieManager
 	oauth         oauth
+	auditRecorder auditRecorder
 	logger        internal.Logger
 }
 
@@ -41,12 +47,14 @@ func New(
 	authService authService,
 	cookieManager cookieManager,
 	oauth oauth,
+	auditRecorder auditRecorder,
 	logger internal.Logger,
 ) *Handler {
 	return &Handler{
 		authService:   authService,
 		cookieManager: cookieManager,
 		oauth:         oauth,
+		auditRecorder: auditRecorder,
 		logger:        logger,
 	}
 }
@@ -80,6 +88,10 @@ func (h *Handler) Handle(c *gin.Context) {
 		return
 	}
 
+	h.auditRecorder.RecordUserAction(ctx, models.AuditSessionRevoked, models.ID(api.GetUserID(c)), map[string]any{
+		"reason": "logout",
+	})
+
 	h.cookieManager.DeleteAccessToken(c)
 	h.cookieManager.DeleteRefreshToken(c)
 }
@@ -126,5 +138,5 @@ func (h *Handler) Path() string {
 }
 
 func (h *Handler) Middleware() []string {
-	return []string{middleware.Auth}
+	return []string{middleware.Auth, middleware.AccessRevocation, middleware.Session}
 }
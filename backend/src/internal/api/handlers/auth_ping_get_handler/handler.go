@@ -40,5 +40,5 @@ func (h *Handler) Path() string {
 }
 
 func (h *Handler) Middleware() []string {
-	return []string{middleware.Auth}
+	return []string{middleware.Auth, middleware.AccessRevocation}
 }
@@ -5,6 +5,7 @@ import (
 	"net/http"
 	"net/url"
 	"strconv"
+	"sync/atomic"
 
 	"github.com/gin-gonic/gin"
 
@@ -16,7 +17,10 @@ import (
 type Status int
 
 const (
-	EmailIsAlreadyTaken Status = 1
+	EmailIsAlreadyTaken      Status = 1
+	AccountBanned            Status = 2
+	AccountSuspended         Status = 3
+	AccountDeletionScheduled Status = 4
 
 	statusParamName = "status"
 
@@ -27,12 +31,33 @@ type authService interface {
 	Callback(ctx context.Context, in *auth_service.CallbackIn) (*auth_service.CallbackOut, error)
 }
 
+type accountChecker interface {
+	IsBannedEmail(ctx context.Context, email string) (bool, error)
+	IsSuspendedEmail(ctx context.Context, email string) (bool, error)
+}
+
+type deletionChecker interface {
+	IsDeletionScheduledEmail(ctx context.Context, email string) (bool, error)
+}
+
 type cookieManager interface {
 	SetAccessToken(c *gin.Context, accessToken string)
 	SetRefreshToken(c *gin.Context, refreshToken string)
 	SetRegistrationToken(c *gin.Context, registrationToken string)
 }
 
+type tokenFamilies interface {
+	Start(ctx context.Context, refreshToken string) error
+}
+
+type auditRecorder interface {
+	RecordLogin(ctx context.Context, email, provider string)
+}
+
+type avatarRecorder interface {
+	SetProviderAvatar(ctx context.Context, email, avatarURL string) error
+}
+
 type oauthManager interface {
 	Complete(c *gin.Context, provider string) (*oauth.User, error)
 }
@@ -41,34 +66,59 @@ type Handler struct {
 	oauthManager    oauthManager
 	cookieManager   cookieManager
 	authService     authService
+	tokenFamilies   tokenFamilies
+	accountChecker  accountChecker
+	deletionChecker deletionChecker
+	auditRecorder   auditRecorder
+	avatarRecorder  avatarRecorder
 	logger          internal.Logger
-	loggedInURL     string
-	registrationURL string
-	errorURL        string
+	redirectURLs    atomic.Pointer[RedirectURLs]
+}
+
+// RedirectURLs are the frontend pages the callback sends the user to. They can
+// be replaced while the server is running.
+type RedirectURLs struct {
+	LoggedIn     string
+	Registration string
+	Error        string
 }
 
 func New(
 	authService authService,
+	tokenFamilies tokenFamilies,
+	accountChecker accountChecker,
+	deletionChecker deletionChecker,
 	oauthManager oauthManager,
 	cookieManager cookieManager,
+	auditRecorder auditRecorder,
+	avatarRecorder avatarRecorder,
 	logger internal.Logger,
 	loggedInURL string,
 	registrationURL string,
 	errorURL string,
 ) *Handler {
-	return &Handler{
+	h := &Handler{
 		authService:     authService,
+		tokenFamilies:   tokenFamilies,
+		accountChecker:  accountChecker,
+		deletionChecker: deletionChecker,
 		oauthManager:    oauthManager,
 		cookieManager:   cookieManager,
+		auditRecorder:   auditRecorder,
+		avatarRecorder:  avatarRecorder,
 		logger:          logger,
-		loggedInURL:     loggedInURL,
-		registrationURL: registrationURL,
-		errorURL:        errorURL,
 	}
+	h.SetRedirectURLs(&RedirectURLs{
+		LoggedIn:     loggedInURL,
+		Registration: registrationURL,
+		Error:        errorURL,
+	})
+
+	return h
 }
 
 func (h *Handler) buildErrorURL(status Status) string {
-	u, err := url.Parse(h.errorURL)
+	u, err := url.Parse(h.redirectURLs.Load().Error)
 	if err != nil {
 		panic(err)
 	}
@@ -81,12 +131,42 @@ func (h *Handler) buildErrorURL(status Status) string {
 	return u.String()
 }
 
+// checkAccount reports whether login is blocked for the account and the status
+// the user is redirected with.
+func (h *Handler) checkAccount(ctx context.Context, email string) (Status, bool, error) {
+	banned, err := h.accountChecker.IsBannedEmail(ctx, email)
+	if err != nil {
+		return 0, false, err
+	}
+	if banned {
+		return AccountBanned, true, nil
+	}
+
+	suspended, err := h.accountChecker.IsSuspendedEmail(ctx, email)
+	if err != nil {
+		return 0, false, err
+	}
+	if suspended {
+		return AccountSuspended, true, nil
+	}
+
+	deleted, err := h.deletionChecker.IsDeletionScheduledEmail(ctx, email)
+	if err != nil {
+		return 0, false, err
+	}
+	if deleted {
+		return AccountDeletionScheduled, true, nil
+	}
+
+	return 0, false, nil
+}
+
 func (h *Handler) handleError(c *gin.Context, err error) {
 	switch {
 	case auth_service.IsEmailAlreadyTakenError(err):
 		c.Redirect(http.StatusPermanentRedirect, h.buildErrorURL(EmailIsAlreadyTaken))
 	default:
-		c.Redirect(http.StatusPermanentRedirect, h.errorURL)
+		c.Redirect(http.StatusPermanentRedirect, h.redirectURLs.Load().Error)
 	}
 }
 
@@ -101,6 +181,9 @@ func (h *Handler) handleError(c *gin.Context, err error) {
 // @Description
 // @Description  **Коды ошибок:**
 // @Description  - `1` - Email уже занят другие аккаунтом
+// @Description  - `2` - Аккаунт заблокирован
+// @Description  - `3` - Аккаунт временно заблокирован модератором
+// @Description  - `4` - Аккаунт ждет удаления, войти в него больше нельзя
 // @Description
 // @Description  **Особенности:**
 // @Description  - Устанавливает authentication cookies при успешной аутентификации или registration_token при необходимости регистрации
@@ -118,7 +201,7 @@ func (h *Handler) Handle(c *gin.Context) {
 	in, err := newIn(c)
 	if err != nil {
 		h.logger.Error(h.logger.WithError(ctx, err))
-		c.Redirect(http.StatusPermanentRedirect, h.errorURL)
+		c.Redirect(http.StatusPermanentRedirect, h.redirectURLs.Load().Error)
 		return
 	}
 
@@ -127,17 +210,29 @@ func (h *Handler) Handle(c *gin.Context) {
 	user, err := h.oauthManager.Complete(c, in.Provider)
 	if err != nil {
 		h.logger.Error(h.logger.WithError(ctx, err))
-		c.Redirect(http.StatusPermanentRedirect, h.errorURL)
+		c.Redirect(http.StatusPermanentRedirect, h.redirectURLs.Load().Error)
 		return
 	}
 	if user == nil {
 		h.logger.Error(h.logger.WithField(ctx, "msg", "oauth user is nil"))
-		c.Redirect(http.StatusPermanentRedirect, h.errorURL)
+		c.Redirect(http.StatusPermanentRedirect, h.redirectURLs.Load().Error)
 		return
 	}
 
 	ctx = h.logger.WithField(ctx, "external_user_id", user.ID)
 
+	status, blocked, err := h.checkAccount(ctx, user.Email)
+	if err != nil {
+		h.logger.Error(h.logger.WithError(ctx, err))
+		c.Redirect(http.StatusPermanentRedirect, h.redirectURLs.Load().Error)
+		return
+	}
+	if blocked {
+		h.logger.Warning(h.logger.WithMsg(h.logger.WithField(ctx, statusParamName, int(status)), "blocked account tried to log in"))
+		c.Redirect(http.StatusPermanentRedirect, h.buildErrorURL(status))
+		return
+	}
+
 	out, err := h.authService.Callback(ctx, &auth_service.CallbackIn{
 		Email:    user.Email,
 		Provider: user.Provider,
@@ -149,25 +244,37 @@ func (h *Handler) Handle(c *gin.Context) {
 	}
 	if out == nil {
 		h.logger.Error(h.logger.WithField(ctx, "msg", "auth service response is nil"))
-		c.Redirect(http.StatusPermanentRedirect, h.errorURL)
+		c.Redirect(http.StatusPermanentRedirect, h.redirectURLs.Load().Error)
 		return
 	}
 
+	// The avatar is kept by email, so it is there once registration finishes.
+	if err = h.avatarRecorder.SetProviderAvatar(ctx, user.Email, user.AvatarURL); err != nil {
+		h.logger.Error(h.logger.WithError(ctx, err))
+	}
+
 	if out.IsRegistration() {
 		h.cookieManager.SetRegistrationToken(c, *out.RegistrationToken)
-		c.Redirect(http.StatusPermanentRedirect, h.registrationURL)
+		c.Redirect(http.StatusPermanentRedirect, h.redirectURLs.Load().Registration)
 		return
 	}
 
 	if out.IsLogin() {
+		// A token outside of any family would escape reuse detection.
+		if err = h.tokenFamilies.Start(ctx, *out.RefreshToken); err != nil {
+			h.logger.Error(h.logger.WithError(ctx, err))
+			c.Redirect(http.StatusPermanentRedirect, h.redirectURLs.Load().Error)
+			return
+		}
+		h.auditRecorder.RecordLogin(ctx, user.Email, in.Provider)
 		h.cookieManager.SetAccessToken(c, *out.AccessToken)
 		h.cookieManager.SetRefreshToken(c, *out.RefreshToken)
-		c.Redirect(http.StatusPermanentRedirect, h.loggedInURL)
+		c.Redirect(http.StatusPermanentRedirect, h.redirectURLs.Load().LoggedIn)
 		return
 	}
 
 	h.logger.Error(h.logger.WithField(ctx, "msg", "out is not registration and login"))
-	c.Redirect(http.StatusPermanentRedirect, h.errorURL)
+	c.Redirect(http.StatusPermanentRedirect, h.redirectURLs.Load().Error)
 }
 
 func (*Handler) Method() string {
@@ -181,3 +288,7 @@ func (*Handler) Path() string {
 func (h *Handler) Middleware() []string {
 	return nil
 }
+
+func (h *Handler) SetRedirectURLs(urls *RedirectURLs) {
+	h.redirectURLs.Store(urls)
+}
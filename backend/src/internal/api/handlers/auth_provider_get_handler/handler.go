@@ -6,6 +6,7 @@ import (
 	"github.com/gin-gonic/gin"
 
 	"github.com/ruslanonly/blindtyping/src/internal"
+	"github.com/ruslanonly/blindtyping/src/internal/api/middleware"
 	"github.com/ruslanonly/blindtyping/src/internal/shared/proto"
 )
 
@@ -39,6 +40,7 @@ func New(
 // @Param        provider path string true "OAuth provider name"
 // @Success      200 {object} nil "User already authenticated"
 // @Failure      400 {object} proto.Error "Missing provider or invalid input"
+// @Failure      429 {object} proto.Error "Too many requests"
 // @Router       /auth/{provider} [get]
 func (h *Handler) Handle(c *gin.Context) {
 	ctx := h.logger.WithHandlerName(c.Request.Context(), handlerName)
@@ -62,5 +64,5 @@ func (*Handler) Path() string {
 }
 
 func (h *Handler) Middleware() []string {
-	return nil
+	return []string{middleware.OAuthBeginRateLimit}
 }
@@ -9,7 +9,9 @@ import (
 	"github.com/ruslanonly/blindtyping/src/internal"
 	"github.com/ruslanonly/blindtyping/src/internal/api"
 	"github.com/ruslanonly/blindtyping/src/internal/api/middleware"
+	"github.com/ruslanonly/blindtyping/src/internal/models"
 	"github.com/ruslanonly/blindtyping/src/internal/services/auth_service"
+	"github.com/ruslanonly/blindtyping/src/internal/services/token_family_service"
 	"github.com/ruslanonly/blindtyping/src/internal/shared/proto"
 )
 
@@ -19,20 +21,36 @@ type refresher interface {
 	Refresh(ctx context.Context, in *auth_service.RefreshIn) (*auth_service.RefreshOut, error)
 }
 
+type tokenFamilies interface {
+	Claim(ctx context.Context, refreshToken string) (*models.SessionFamilyMember, error)
+	Complete(ctx context.Context, claimed *models.SessionFamilyMember, newRefreshToken string) error
+	Release(ctx context.Context, claimed *models.SessionFamilyMember) error
+	Start(ctx context.Context, refreshToken string) error
+}
+
 type cookieManager interface {
 	SetAccessToken(ctx *gin.Context, accessToken string)
 	SetRefreshToken(ctx *gin.Context, refreshToken string)
+	DeleteAccessToken(ctx *gin.Context)
+	DeleteRefreshToken(ctx *gin.Context)
 }
 
 type Handler struct {
 	refresher     refresher
+	tokenFamilies tokenFamilies
 	cookieManager cookieManager
 	logger        internal.Logger
 }
 
-func New(refresher refresher, cookieManager cookieManager, logger internal.Logger) *Handler {
+func New(
+	refresher refresher,
+	tokenFamilies tokenFamilies,
+	cookieManager cookieManager,
+	logger internal.Logger,
+) *Handler {
 	return &Handler{
 		refresher:     refresher,
+		tokenFamilies: tokenFamilies,
 		cookieManager: cookieManager,
 		logger:        logger,
 	}
@@ -46,14 +64,47 @@ func New(refresher refresher, cookieManager cookieManager, logger internal.Logge
 // @Produce      json
 // @Success      200 {object} nil "Success"
 // @Router       /auth/refresh [post]
+// @Failure      401 {object} proto.Error "Session expired or refresh token reused"
 // @Failure      403 {object} proto.Error "Unauthorized"
 // @Failure      404 {object} proto.Error "User session not found"
 // @Failure      500 {object} proto.Error "Something went wrong server side"
 // @Security     ApiKeyAuth
 func (h *Handler) Handle(c *gin.Context) {
 	ctx := h.logger.WithHandlerName(c.Request.Context(), handlerName)
+	in := h.makeRefreshIn(c)
+
+	claimed, err := h.tokenFamilies.Claim(ctx, in.RefreshToken)
+	if err != nil {
+		if token_family_service.IsRefreshTokenReusedError(err) {
+			h.cookieManager.DeleteAccessToken(c)
+			h.cookieManager.DeleteRefreshToken(c)
+		}
+		h.handleError(ctx, c, err)
+		return
+	}
+
+	out, err := h.refresher.Refresh(ctx, in)
+	if err != nil {
+		if claimed != nil {
+			if releaseErr := h.tokenFamilies.Release(ctx, claimed); releaseErr != nil {
+				h.logger.Error(h.logger.WithError(ctx, releaseErr))
+			}
+		}
+		h.handleError(ctx, c, err)
+		return
+	}
+	if out == nil {
+		h.makeOut(c, out)
+		return
+	}
 
-	out, err := h.refresher.Refresh(ctx, h.makeRefreshIn(c))
+	// A token outside of any family would escape reuse detection, so the
+	// request fails instead of handing it out.
+	if claimed != nil {
+		err = h.tokenFamilies.Complete(ctx, claimed, out.RefreshToken)
+	} else {
+		err = h.tokenFamilies.Start(ctx, out.RefreshToken)
+	}
 	if err != nil {
 		h.handleError(ctx, c, err)
 		return
@@ -91,6 +142,9 @@ func (h *Handler) handleError(ctx context.Context, c *gin.Context, err error) {
 	case auth_service.IsSessionExpiredError(err):
 		status = http.StatusUnauthorized
 		message = "session expired"
+	case token_family_service.IsRefreshTokenReusedError(err):
+		status = http.StatusUnauthorized
+		message = "session revoked"
 	}
 
 	ctx = h.logger.WithError(h.logger.WithStatusCode(ctx, status), err)
@@ -13,6 +13,7 @@ import (
 	"github.com/ruslanonly/blindtyping/src/internal/models"
 	"github.com/ruslanonly/blindtyping/src/internal/services/auth_service"
 	"github.com/ruslanonly/blindtyping/src/internal/services/user_service"
+	"github.com/ruslanonly/blindtyping/src/internal/services/username_service"
 	"github.com/ruslanonly/blindtyping/src/internal/shared/proto"
 )
 
@@ -22,6 +23,11 @@ type RequestBody struct {
 	Nickname string `json:"nickname" example:"ffh255"`
 } //@name AuthRegisterPostHandler.RequestBody
 
+type RejectedBody struct {
+	Error       string   `json:"error" example:"username is already taken"`
+	Suggestions []string `json:"suggestions" example:"ffh42"`
+} //@name AuthRegisterPostHandler.RejectedBody
+
 type Request struct {
 	Nickname string
 	Token    string
@@ -31,23 +37,62 @@ type authService interface {
 	Register(ctx context.Context, in *auth_service.RegisterIn) (*auth_service.RegisterOut, error)
 }
 
+type usernameChecker interface {
+	Check(ctx context.Context, username string, userID *models.ID) error
+}
+
+type tokenFamilies interface {
+	Start(ctx context.Context, refreshToken string) error
+}
+
 type cookieManager interface {
 	DeleteRegistrationToken(c *gin.Context)
 	SetAccessToken(c *gin.Context, token string)
 	SetRefreshToken(c *gin.Context, token string)
 }
 
+type registrationMetrics interface {
+	UserRegistered()
+}
+
+type analyticsTracker interface {
+	TrackRegistration(ctx context.Context, nickname string)
+}
+
+type auditRecorder interface {
+	RecordRegistration(ctx context.Context, nickname string)
+}
+
 type Handler struct {
-	authService   authService
-	cookieManager cookieManager
-	logger        internal.Logger
+	authService         authService
+	usernameChecker     usernameChecker
+	tokenFamilies       tokenFamilies
+	cookieManager       cookieManager
+	registrationMetrics registrationMetrics
+	analyticsTracker    analyticsTracker
+	auditRecorder       auditRecorder
+	logger              internal.Logger
 }
 
-func New(authService authService, cookieManager cookieManager, logger internal.Logger) *Handler {
+func New(
+	authService authService,
+	usernameChecker usernameChecker,
+	tokenFamilies tokenFamilies,
+	cookieManager cookieManager,
+	registrationMetrics registrationMetrics,
+	analyticsTracker analyticsTracker,
+	auditRecorder auditRecorder,
+	logger internal.Logger,
+) *Handler {
 	return &Handler{
-		authService:   authService,
-		cookieManager: cookieManager,
-		logger:        logger,
+		authService:         authService,
+		usernameChecker:     usernameChecker,
+		tokenFamilies:       tokenFamilies,
+		cookieManager:       cookieManager,
+		registrationMetrics: registrationMetrics,
+		analyticsTracker:    analyticsTracker,
+		auditRecorder:       auditRecorder,
+		logger:              logger,
 	}
 }
 
@@ -76,12 +121,15 @@ func (h *Handler) handleError(ctx context.Context, c *gin.Context, err error) {
 	)
 
 	switch {
-	case models.IsValidationError(err):
+	case models.IsValidationError(err), username_service.IsUsernamePolicyError(err):
 		status = http.StatusBadRequest
 		message = err.Error()
 	case user_service.IsUserAlreadyExistsError(err):
 		status = http.StatusConflict
 		message = "user already exists"
+	case username_service.IsUsernameConflictError(err):
+		status = http.StatusConflict
+		message = err.Error()
 	}
 
 	ctx = h.logger.WithError(h.logger.WithStatusCode(ctx, status), err)
@@ -93,6 +141,15 @@ func (h *Handler) handleError(ctx context.Context, c *gin.Context, err error) {
 		h.logger.Error(ctx)
 	}
 
+	if rejected, ok := username_service.AsRejectedError(err); ok {
+		suggestions := rejected.Suggestions
+		if suggestions == nil {
+			suggestions = []string{}
+		}
+		proto.WriteJSON(c, status, &RejectedBody{Error: message, Suggestions: suggestions})
+		return
+	}
+
 	proto.WriteError(c, status, message)
 }
 
@@ -104,8 +161,8 @@ func (h *Handler) handleError(ctx context.Context, c *gin.Context, err error) {
 // @Produce      json
 // @Param        body  body      RequestBody true "User nickname"
 // @Success      200 "Access and refresh tokens are set as cookies"
-// @Failure      400   {object}  proto.Error  "Invalid input or missing registration token"
-// @Failure      409   {object}  proto.Error  "User already exists"
+// @Failure      400   {object}  RejectedBody "Invalid input, nickname breaks the username policy or missing registration token"
+// @Failure      409   {object}  RejectedBody "User already exists, nickname or a look-alike is taken or reserved"
 // @Failure      500   {object}  proto.Error  "Internal server error during registration"
 // @Router       /auth/register [post]
 func (h *Handler) Handle(c *gin.Context) {
@@ -118,6 +175,11 @@ func (h *Handler) Handle(c *gin.Context) {
 		return
 	}
 
+	if err = h.usernameChecker.Check(ctx, r.Nickname, nil); err != nil {
+		h.handleError(ctx, c, err)
+		return
+	}
+
 	out, err := h.authService.Register(ctx, &auth_service.RegisterIn{
 		Nickname: r.Nickname,
 		Token:    r.Token,
@@ -127,6 +189,16 @@ func (h *Handler) Handle(c *gin.Context) {
 		return
 	}
 
+	h.registrationMetrics.UserRegistered()
+	h.analyticsTracker.TrackRegistration(ctx, r.Nickname)
+	h.auditRecorder.RecordRegistration(ctx, r.Nickname)
+
+	// A token outside of any family would escape reuse detection.
+	if err = h.tokenFamilies.Start(ctx, out.RefreshToken); err != nil {
+		h.handleError(ctx, c, err)
+		return
+	}
+
 	h.writeResponse(c, out)
 }
 
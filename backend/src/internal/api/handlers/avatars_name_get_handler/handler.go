@@ -0,0 +1,94 @@
+package avatars_name_get_handler
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/ruslanonly/blindtyping/src/internal"
+	"github.com/ruslanonly/blindtyping/src/internal/services/profile_details_service"
+	"github.com/ruslanonly/blindtyping/src/internal/shared/proto"
+)
+
+const (
+	handlerName = "avatars_name_get_handler"
+
+	// Every upload gets a new name, so an avatar never changes under its URL.
+	cacheControl = "public, max-age=31536000, immutable"
+)
+
+type avatarGetter interface {
+	GetAvatar(ctx context.Context, name string) ([]byte, string, error)
+}
+
+type Handler struct {
+	avatarGetter avatarGetter
+	logger       internal.Logger
+}
+
+func (h *Handler) handleError(ctx context.Context, c *gin.Context, err error) {
+	var (
+		status  = http.StatusInternalServerError
+		message = "something went wrong serverside"
+	)
+
+	switch {
+	case profile_details_service.IsAvatarNotFoundError(err):
+		status = http.StatusNotFound
+		message = err.Error()
+	}
+
+	ctx = h.logger.WithError(h.logger.WithStatusCode(ctx, status), err)
+
+	switch status {
+	case http.StatusInternalServerError:
+		h.logger.Error(ctx)
+	default:
+		h.logger.Warning(ctx)
+	}
+
+	proto.WriteError(c, status, message)
+}
+
+// Handle godoc
+// @Summary     Получить аватарку
+// @Description Отдает загруженную пользователем аватарку. Ссылки на аватарки приходят в профиле, а не собираются вручную
+// @Tags        Profile
+// @Produce     jpeg
+// @Param       name path string true "Имя файла аватарки"
+// @Success     200 "Изображение"
+// @Failure     404 {object} proto.Error "Аватарка не найдена"
+// @Failure     500 {object} proto.Error "Внутренняя ошибка сервера (смотреть логи)"
+// @Router      /avatars/{name} [get]
+func (h *Handler) Handle(c *gin.Context) {
+	ctx := h.logger.WithHandlerName(c.Request.Context(), handlerName)
+
+	data, contentType, err := h.avatarGetter.GetAvatar(ctx, c.Param("name"))
+	if err != nil {
+		h.handleError(ctx, c, err)
+		return
+	}
+
+	c.Header("Cache-Control", cacheControl)
+	c.Data(http.StatusOK, contentType, data)
+}
+
+func (h *Handler) Method() string {
+	return http.MethodGet
+}
+
+func (h *Handler) Path() string {
+	return "/avatars/:name"
+}
+
+func (h *Handler) Middleware() []string {
+	return nil
+}
+
+func New(avatarGetter avatarGetter, logger internal.Logger) *Handler {
+	return &Handler{
+		avatarGetter: avatarGetter,
+		logger:       logger,
+	}
+}
@@ -0,0 +1,42 @@
+package healthz_get_handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/ruslanonly/blindtyping/src/internal/shared/proto"
+)
+
+type ResponseBody struct {
+	Status string `json:"status" example:"ok"`
+} //@name HealthzGetHandler.ResponseBody
+
+type Handler struct{}
+
+func New() *Handler {
+	return &Handler{}
+}
+
+// Handle godoc
+// @Summary     Проверка жизнеспособности
+// @Description Отвечает, пока процесс обрабатывает запросы. Зависимости не проверяются, для этого есть /readyz
+// @Tags        Health
+// @Produce     json
+// @Success     200 {object} ResponseBody "Процесс жив"
+// @Router      /healthz [get]
+func (h *Handler) Handle(c *gin.Context) {
+	proto.WriteJSON(c, http.StatusOK, &ResponseBody{Status: "ok"})
+}
+
+func (h *Handler) Method() string {
+	return http.MethodGet
+}
+
+func (h *Handler) Path() string {
+	return "/healthz"
+}
+
+func (h *Handler) Middleware() []string {
+	return nil
+}
@@ -0,0 +1,56 @@
+package readyz_get_handler
+
+import (
+	"github.com/ruslanonly/blindtyping/src/internal/services/health_service"
+)
+
+const (
+	statusOK       = "ok"
+	statusFail     = "fail"
+	statusDraining = "draining"
+)
+
+type Check struct {
+	Status string `json:"status" example:"fail"`
+	Error  string `json:"error,omitempty" example:"database is not migrated to the latest version: applied 16, latest is 17"`
+} //@name ReadyzGetHandler.Check
+
+type ResponseBody struct {
+	Status string           `json:"status" example:"ok"`
+	Checks map[string]Check `json:"checks"`
+} //@name ReadyzGetHandler.ResponseBody
+
+// checkError tells why a dependency is unavailable. Connection errors are only
+// logged, since they may contain addresses of the dependencies.
+func checkError(err error) string {
+	switch {
+	case health_service.IsMigrationsPendingError(err), health_service.IsMigrationDirtyError(err):
+		return err.Error()
+	default:
+		return "unavailable"
+	}
+}
+
+func newResponseBody(out *health_service.ReadinessOut) *ResponseBody {
+	body := &ResponseBody{
+		Status: statusOK,
+		Checks: make(map[string]Check, len(out.Checks)),
+	}
+
+	if out.IsDraining {
+		body.Status = statusDraining
+		return body
+	}
+
+	for _, check := range out.Checks {
+		if check.Err != nil {
+			body.Status = statusFail
+			body.Checks[check.Name] = Check{Status: statusFail, Error: checkError(check.Err)}
+			continue
+		}
+
+		body.Checks[check.Name] = Check{Status: statusOK}
+	}
+
+	return body
+}
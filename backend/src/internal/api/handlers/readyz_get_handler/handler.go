@@ -0,0 +1,70 @@
+package readyz_get_handler
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/ruslanonly/blindtyping/src/internal"
+	"github.com/ruslanonly/blindtyping/src/internal/services/health_service"
+	"github.com/ruslanonly/blindtyping/src/internal/shared/proto"
+)
+
+const handlerName = "readyz_get_handler"
+
+type healthService interface {
+	Readiness(ctx context.Context) *health_service.ReadinessOut
+}
+
+type Handler struct {
+	healthService healthService
+	logger        internal.Logger
+}
+
+// Handle godoc
+// @Summary     Проверка готовности
+// @Description Проверяет Postgres, Redis, версию миграций и наличие текущего ключа antifroad (если он включен). После сигнала остановки отвечает 503 со статусом draining; сервер продолжает принимать запросы еще server.readiness_delay и только затем останавливается
+// @Tags        Health
+// @Produce     json
+// @Success     200 {object} ResponseBody "Сервис готов принимать запросы"
+// @Failure     503 {object} ResponseBody "Зависимость недоступна или сервер останавливается"
+// @Router      /readyz [get]
+func (h *Handler) Handle(c *gin.Context) {
+	ctx := h.logger.WithHandlerName(c.Request.Context(), handlerName)
+
+	out := h.healthService.Readiness(ctx)
+
+	status := http.StatusOK
+	if !out.IsReady() {
+		status = http.StatusServiceUnavailable
+	}
+
+	for _, check := range out.Checks {
+		if check.Err != nil {
+			checkCtx := h.logger.WithField(ctx, "check", check.Name)
+			h.logger.Warning(h.logger.WithError(h.logger.WithStatusCode(checkCtx, status), check.Err))
+		}
+	}
+
+	proto.WriteJSON(c, status, newResponseBody(out))
+}
+
+func (h *Handler) Method() string {
+	return http.MethodGet
+}
+
+func (h *Handler) Path() string {
+	return "/readyz"
+}
+
+func (h *Handler) Middleware() []string {
+	return nil
+}
+
+func New(healthService healthService, logger internal.Logger) *Handler {
+	return &Handler{
+		healthService: healthService,
+		logger:        logger,
+	}
+}
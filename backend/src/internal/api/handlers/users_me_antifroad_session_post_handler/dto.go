@@ -0,0 +1,32 @@
+package users_me_antifroad_session_post_handler
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/ruslanonly/blindtyping/src/internal/api"
+	"github.com/ruslanonly/blindtyping/src/internal/models"
+	"github.com/ruslanonly/blindtyping/src/internal/services/antifroad_key_service"
+	"github.com/ruslanonly/blindtyping/src/internal/shared/proto"
+)
+
+type ResponseBody struct {
+	SessionID string `json:"sessionId" example:"9b1c0f4e2a7d4c3b8e5f6a7b8c9d0e1f" description:"Идентификатор сессии, передается вместе с подписью результата"`
+	Secret    string `json:"secret" example:"5f2b..." description:"Секрет для подписи результатов в hex"`
+	ExpiresAt string `json:"expiresAt" example:"2025-10-20T19:02:29+03:00" description:"Время окончания сессии"`
+} //@name UsersMeAntifroadSessionPostHandler.ResponseBody
+
+type Request struct {
+	UserID models.ID
+}
+
+func newRequest(c *gin.Context) *Request {
+	return &Request{UserID: models.ID(api.GetUserID(c))}
+}
+
+func newResponseBody(out *antifroad_key_service.StartSessionOut) *ResponseBody {
+	return &ResponseBody{
+		SessionID: out.Session.ID,
+		Secret:    out.Secret,
+		ExpiresAt: proto.MarshalTime(out.Session.ExpiresAt),
+	}
+}
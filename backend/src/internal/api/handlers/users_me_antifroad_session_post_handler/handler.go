@@ -0,0 +1,71 @@
+package users_me_antifroad_session_post_handler
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/ruslanonly/blindtyping/src/internal"
+	"github.com/ruslanonly/blindtyping/src/internal/api/middleware"
+	"github.com/ruslanonly/blindtyping/src/internal/models"
+	"github.com/ruslanonly/blindtyping/src/internal/services/antifroad_key_service"
+	"github.com/ruslanonly/blindtyping/src/internal/shared/proto"
+)
+
+const handlerName = "users_me_antifroad_session_post_handler"
+
+type antifroadKeyService interface {
+	StartSession(ctx context.Context, userID models.ID) (*antifroad_key_service.StartSessionOut, error)
+}
+
+type Handler struct {
+	antifroadKeyService antifroadKeyService
+	logger              internal.Logger
+}
+
+// Handle godoc
+// @Summary     Начать сессию подписи результатов
+// @Description Выдает секрет для подписи результатов текущего пользователя. Секрет выводится из антифрод-ключа, идентификатора сессии и ID пользователя, поэтому не подходит для подписи результатов других пользователей. Подпись (sessionSign) - HMAC-SHA256 секретом от полей результата, соединенных через "\n": uid, wpm, cpm, accuracy, durationMs, language, mode, submode, isPunctuation, uncompletedTestsCount, uncompletedTestsDurationMs, createdAt, startedAt, finishedAt. Если сохранение результата вернуло ошибку об истекшем ключе или неизвестной сессии, нужно начать новую сессию.
+// @Tags        User Statistics
+// @Accept      json
+// @Produce     json
+// @Security    ApiKeyAuth
+// @Success     201 {object} ResponseBody "Сессия создана"
+// @Failure     401 {object} proto.Error "Пользователь не авторизован"
+// @Failure     403 {object} proto.Error "У персонального токена нет права statistics:write"
+// @Failure     500 {object} proto.Error "Внутренняя ошибка сервера (смотреть логи)"
+// @Router      /users/me/antifroad/session [post]
+func (h *Handler) Handle(c *gin.Context) {
+	ctx := h.logger.WithHandlerName(c.Request.Context(), handlerName)
+	req := newRequest(c)
+
+	out, err := h.antifroadKeyService.StartSession(ctx, req.UserID)
+	if err != nil {
+		ctx = h.logger.WithStatusCode(ctx, http.StatusInternalServerError)
+		h.logger.Error(h.logger.WithError(ctx, err))
+		proto.WriteError(c, http.StatusInternalServerError, "something went wrong")
+		return
+	}
+
+	proto.WriteJSON(c, http.StatusCreated, newResponseBody(out))
+}
+
+func (h *Handler) Method() string {
+	return http.MethodPost
+}
+
+func (h *Handler) Path() string {
+	return "/users/me/antifroad/session"
+}
+
+func (h *Handler) Middleware() []string {
+	return []string{middleware.Auth, middleware.AccessRevocation, middleware.StatisticsWrite}
+}
+
+func New(antifroadKeyService antifroadKeyService, logger internal.Logger) *Handler {
+	return &Handler{
+		antifroadKeyService: antifroadKeyService,
+		logger:              logger,
+	}
+}
@@ -0,0 +1,88 @@
+package users_me_audit_get_handler
+
+import (
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/ruslanonly/blindtyping/src/internal/api"
+	"github.com/ruslanonly/blindtyping/src/internal/models"
+	"github.com/ruslanonly/blindtyping/src/internal/shared/proto"
+)
+
+type Event struct {
+	ID           uint64         `json:"id" example:"1024"`
+	Action       string         `json:"action" example:"login"`
+	ActorID      *uint64        `json:"actorId" example:"42"`
+	ActorService *string        `json:"actorService" example:"scheduler"`
+	IP           string         `json:"ip" example:"203.0.113.7"`
+	UserAgent    string         `json:"userAgent" example:"Mozilla/5.0"`
+	RequestID    string         `json:"requestId" example:"4bf92f3577b34da6a3ce929d0e0e4736"`
+	Details      map[string]any `json:"details" swaggertype:"object"`
+	CreatedAt    string         `json:"createdAt" example:"2025-10-19T19:02:29+03:00"`
+} //@name UsersMeAuditGetHandler.Event
+
+type ResponseBody struct {
+	Events []Event `json:"events"`
+} //@name UsersMeAuditGetHandler.ResponseBody
+
+type Request struct {
+	UserID   models.ID
+	BeforeID *models.ID
+	Limit    int
+}
+
+func newRequest(c *gin.Context) (*Request, error) {
+	r := &Request{
+		UserID: models.ID(api.GetUserID(c)),
+	}
+
+	if before := c.Query("before"); before != "" {
+		parsed, err := strconv.ParseUint(before, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		id := models.ID(parsed)
+		r.BeforeID = &id
+	}
+
+	if limit := c.Query("limit"); limit != "" {
+		parsed, err := strconv.Atoi(limit)
+		if err != nil {
+			return nil, err
+		}
+		r.Limit = parsed
+	}
+
+	return r, nil
+}
+
+func newResponseBody(events []*models.AuditEvent) *ResponseBody {
+	body := make([]Event, 0, len(events))
+	for _, event := range events {
+		var actorID *uint64
+		if event.ActorID != nil {
+			id := uint64(*event.ActorID)
+			actorID = &id
+		}
+
+		var actorService *string
+		if event.ActorService != "" {
+			actorService = &event.ActorService
+		}
+
+		body = append(body, Event{
+			ID:           uint64(event.ID),
+			Action:       string(event.Action),
+			ActorID:      actorID,
+			ActorService: actorService,
+			IP:           event.IP,
+			UserAgent:    event.UserAgent,
+			RequestID:    event.RequestID,
+			Details:      event.Details,
+			CreatedAt:    proto.MarshalTime(event.CreatedAt),
+		})
+	}
+
+	return &ResponseBody{Events: body}
+}
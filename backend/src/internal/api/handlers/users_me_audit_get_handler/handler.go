@@ -0,0 +1,93 @@
+package users_me_audit_get_handler
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/ruslanonly/blindtyping/src/internal"
+	"github.com/ruslanonly/blindtyping/src/internal/api/middleware"
+	"github.com/ruslanonly/blindtyping/src/internal/models"
+	"github.com/ruslanonly/blindtyping/src/internal/shared/proto"
+)
+
+const handlerName = "users_me_audit_get_handler"
+
+type auditService interface {
+	Search(ctx context.Context, filter *models.AuditFilter) ([]*models.AuditEvent, error)
+}
+
+type Handler struct {
+	auditService auditService
+	logger       internal.Logger
+}
+
+func (h *Handler) handleError(ctx context.Context, c *gin.Context, err error) {
+	var (
+		status  = http.StatusInternalServerError
+		message = "something went wrong serverside"
+	)
+
+	ctx = h.logger.WithError(h.logger.WithStatusCode(ctx, status), err)
+	h.logger.Error(ctx)
+
+	proto.WriteError(c, status, message)
+}
+
+// Handle godoc
+// @Summary     Журнал действий с аккаунтом
+// @Description Получить входы, регистрацию, смены имени, удаления статистики и отзывы сессий текущего пользователя, начиная с новых. Для следующей страницы нужно передать id последней записи в before.
+// @Tags        Users
+// @Accept      json
+// @Produce     json
+// @Security    ApiKeyAuth
+// @Param       before query int false "Вернуть записи старше записи с этим id"
+// @Param       limit query int false "Максимальное кол-во записей (по умолчанию 50, не больше 200)"
+// @Success     200 {object} ResponseBody "Записи журнала"
+// @Failure     400 {object} proto.Error "Неверный before или лимит"
+// @Failure     401 {object} proto.Error "Пользователь не авторизован"
+// @Failure     500 {object} proto.Error "Внутренняя ошибка сервера (смотреть логи)"
+// @Router      /users/me/audit [get]
+func (h *Handler) Handle(c *gin.Context) {
+	ctx := h.logger.WithHandlerName(c.Request.Context(), handlerName)
+
+	req, err := newRequest(c)
+	if err != nil {
+		ctx = h.logger.WithStatusCode(ctx, http.StatusBadRequest)
+		h.logger.Warning(h.logger.WithError(ctx, err))
+		proto.WriteError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	events, err := h.auditService.Search(ctx, &models.AuditFilter{
+		UserID:   &req.UserID,
+		BeforeID: req.BeforeID,
+		Limit:    req.Limit,
+	})
+	if err != nil {
+		h.handleError(ctx, c, err)
+		return
+	}
+
+	proto.WriteJSON(c, http.StatusOK, newResponseBody(events))
+}
+
+func (h *Handler) Method() string {
+	return http.MethodGet
+}
+
+func (h *Handler) Path() string {
+	return "/users/me/audit"
+}
+
+func (h *Handler) Middleware() []string {
+	return []string{middleware.Auth, middleware.AccessRevocation, middleware.Session}
+}
+
+func New(auditService auditService, logger internal.Logger) *Handler {
+	return &Handler{
+		auditService: auditService,
+		logger:       logger,
+	}
+}
@@ -0,0 +1,62 @@
+package users_me_avatar_put_handler
+
+import (
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/ruslanonly/blindtyping/src/internal/api"
+	"github.com/ruslanonly/blindtyping/src/internal/models"
+)
+
+const formField = "avatar"
+
+var errAvatarTooLarge = errors.New("avatar file is too large")
+
+type ResponseBody struct {
+	AvatarURL string `json:"avatarUrl" example:"http://localhost:5001/avatars/0b7a8a1e-3f9c-4b8f-9a52-6d2b3c1e2f40.jpg"`
+} //@name UsersMeAvatarPutHandler.ResponseBody
+
+type Request struct {
+	UserID models.ID
+	Image  []byte
+}
+
+func newRequest(c *gin.Context, maxSize int64) (*Request, error) {
+	header, err := c.FormFile(formField)
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			return nil, errAvatarTooLarge
+		}
+		return nil, errors.New("avatar file is required in the avatar form field")
+	}
+	if header.Size > maxSize {
+		return nil, errAvatarTooLarge
+	}
+
+	file, err := header.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	image, err := io.ReadAll(io.LimitReader(file, maxSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(image)) > maxSize {
+		return nil, errAvatarTooLarge
+	}
+
+	return &Request{
+		UserID: models.ID(api.GetUserID(c)),
+		Image:  image,
+	}, nil
+}
+
+func newResponseBody(details *models.ProfileDetails) *ResponseBody {
+	return &ResponseBody{AvatarURL: details.AvatarURL}
+}
@@ -0,0 +1,122 @@
+package users_me_avatar_put_handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/ruslanonly/blindtyping/src/internal"
+	"github.com/ruslanonly/blindtyping/src/internal/api/middleware"
+	"github.com/ruslanonly/blindtyping/src/internal/models"
+	"github.com/ruslanonly/blindtyping/src/internal/services/profile_details_service"
+	"github.com/ruslanonly/blindtyping/src/internal/shared/proto"
+)
+
+const (
+	handlerName = "users_me_avatar_put_handler"
+
+	// multipartOverhead is room for the multipart headers around the file.
+	multipartOverhead = 64 << 10
+)
+
+type profileDetailsService interface {
+	UploadAvatar(ctx context.Context, userID models.ID, image []byte) (*models.ProfileDetails, error)
+}
+
+type Handler struct {
+	profileDetailsService profileDetailsService
+	logger                internal.Logger
+	maxSize               int64
+}
+
+func (h *Handler) handleError(ctx context.Context, c *gin.Context, err error) {
+	var (
+		status  = http.StatusInternalServerError
+		message = "something went wrong serverside"
+	)
+
+	switch {
+	case profile_details_service.IsInvalidAvatarError(err):
+		status = http.StatusBadRequest
+		message = err.Error()
+	case profile_details_service.IsUserNotFoundError(err):
+		status = http.StatusNotFound
+		message = err.Error()
+	}
+
+	ctx = h.logger.WithError(h.logger.WithStatusCode(ctx, status), err)
+
+	switch status {
+	case http.StatusInternalServerError:
+		h.logger.Error(ctx)
+	default:
+		h.logger.Warning(ctx)
+	}
+
+	proto.WriteError(c, status, message)
+}
+
+// Handle godoc
+// @Summary     Загрузить аватарку
+// @Description Заменить аватарку пользователя загруженным изображением (JPEG, PNG или GIF). Изображение обрезается до квадрата
+// @Description и уменьшается на сервере. Загруженная аватарка показывается вместо аватарки от OAuth провайдера
+// @Tags        Profile
+// @Accept      multipart/form-data
+// @Produce     json
+// @Security    ApiKeyAuth
+// @Param       avatar formData file true "Изображение"
+// @Success     200 {object} ResponseBody "Аватарка обновлена"
+// @Failure     400 {object} proto.Error "Файл отсутствует или не является изображением"
+// @Failure     401 {object} proto.Error "Пользователь не авторизован"
+// @Failure     413 {object} proto.Error "Файл слишком большой"
+// @Failure     429 {object} proto.Error "Слишком много загрузок, нужно подождать"
+// @Failure     500 {object} proto.Error "Внутренняя ошибка сервера (смотреть логи)"
+// @Router      /users/me/avatar [put]
+func (h *Handler) Handle(c *gin.Context) {
+	ctx := h.logger.WithHandlerName(c.Request.Context(), handlerName)
+
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, h.maxSize+multipartOverhead)
+
+	req, err := newRequest(c, h.maxSize)
+	if err != nil {
+		status := http.StatusBadRequest
+		if errors.Is(err, errAvatarTooLarge) {
+			status = http.StatusRequestEntityTooLarge
+		}
+
+		ctx = h.logger.WithStatusCode(ctx, status)
+		h.logger.Warning(h.logger.WithError(ctx, err))
+		proto.WriteError(c, status, err)
+		return
+	}
+
+	details, err := h.profileDetailsService.UploadAvatar(ctx, req.UserID, req.Image)
+	if err != nil {
+		h.handleError(ctx, c, err)
+		return
+	}
+
+	proto.WriteJSON(c, http.StatusOK, newResponseBody(details))
+}
+
+func (h *Handler) Method() string {
+	return http.MethodPut
+}
+
+func (h *Handler) Path() string {
+	return "/users/me/avatar"
+}
+
+func (h *Handler) Middleware() []string {
+	return []string{middleware.Auth, middleware.AccessRevocation, middleware.Session, middleware.AvatarUploadRateLimit}
+}
+
+func New(profileDetailsService profileDetailsService, logger internal.Logger, maxSize int64) *Handler {
+	return &Handler{
+		profileDetailsService: profileDetailsService,
+		logger:                logger,
+		maxSize:               maxSize,
+	}
+}
@@ -0,0 +1,184 @@
+package users_me_data_get_handler
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/ruslanonly/blindtyping/src/internal/api"
+	"github.com/ruslanonly/blindtyping/src/internal/models"
+	"github.com/ruslanonly/blindtyping/src/internal/shared/proto"
+)
+
+type Profile struct {
+	ID                  uint64  `json:"id"`
+	Email               string  `json:"email"`
+	Username            string  `json:"username"`
+	Provider            string  `json:"provider"`
+	Role                string  `json:"role"`
+	JoinedAt            string  `json:"joinedAt"`
+	BannedAt            *string `json:"bannedAt"`
+	DeletionScheduledAt *string `json:"deletionScheduledAt"`
+	AvatarURL           string  `json:"avatarUrl"`
+	ProviderAvatarURL   string  `json:"providerAvatarUrl"`
+	Bio                 string  `json:"bio"`
+	Country             string  `json:"country"`
+	Keyboard            string  `json:"keyboard"`
+	Layout              string  `json:"layout"`
+}
+
+type Session struct {
+	ExpiresAt string `json:"expiresAt"`
+}
+
+type PersonalToken struct {
+	Name       string   `json:"name"`
+	Scopes     []string `json:"scopes"`
+	CreatedAt  string   `json:"createdAt"`
+	ExpiresAt  *string  `json:"expiresAt"`
+	LastUsedAt *string  `json:"lastUsedAt"`
+}
+
+type Result struct {
+	ID                            uint64  `json:"id"`
+	WPM                           float64 `json:"wpm"`
+	CPM                           float64 `json:"cpm"`
+	Accuracy                      float64 `json:"accuracy"`
+	Duration                      int64   `json:"duration"`
+	PlayedAt                      string  `json:"playedAt"`
+	Language                      string  `json:"language"`
+	Mode                          string  `json:"mode"`
+	SubMode                       string  `json:"submode"`
+	IsPunctuation                 bool    `json:"isPunctuation"`
+	UncompletedTestsCount         int64   `json:"uncompletedTestsCount"`
+	UncompletedTestsTotalDuration int64   `json:"uncompletedTestsTotalDuration"`
+	IsDeleted                     bool    `json:"isDeleted"`
+}
+
+type Request struct {
+	UserID models.ID
+}
+
+func newRequest(c *gin.Context) *Request {
+	return &Request{
+		UserID: models.ID(api.GetUserID(c)),
+	}
+}
+
+func marshalOptionalTime(t *time.Time) *string {
+	if t == nil {
+		return nil
+	}
+	formatted := proto.MarshalTime(*t)
+	return &formatted
+}
+
+func newProfile(account *models.UserAccount, details *models.ProfileDetails) *Profile {
+	return &Profile{
+		ID:                  uint64(account.ID),
+		Email:               account.Email,
+		Username:            account.Nickname,
+		Provider:            account.Provider,
+		Role:                string(account.Role),
+		JoinedAt:            proto.MarshalTime(account.CreatedAt),
+		BannedAt:            marshalOptionalTime(account.BannedAt),
+		DeletionScheduledAt: marshalOptionalTime(account.DeletionScheduledAt),
+		AvatarURL:           details.AvatarURL,
+		ProviderAvatarURL:   details.ProviderAvatarURL,
+		Bio:                 details.Bio,
+		Country:             details.Country,
+		Keyboard:            details.Keyboard,
+		Layout:              details.Layout,
+	}
+}
+
+func newSessions(sessions []*models.AccountSession) []Session {
+	body := make([]Session, 0, len(sessions))
+	for _, session := range sessions {
+		body = append(body, Session{ExpiresAt: proto.MarshalTime(session.ExpiresAt)})
+	}
+	return body
+}
+
+func newPersonalTokens(tokens []*models.PersonalToken) []PersonalToken {
+	body := make([]PersonalToken, 0, len(tokens))
+	for _, token := range tokens {
+		scopes := make([]string, 0, len(token.Scopes))
+		for _, scope := range token.Scopes {
+			scopes = append(scopes, string(scope))
+		}
+
+		body = append(body, PersonalToken{
+			Name:       token.Name,
+			Scopes:     scopes,
+			CreatedAt:  proto.MarshalTime(token.CreatedAt),
+			ExpiresAt:  marshalOptionalTime(token.ExpiresAt),
+			LastUsedAt: marshalOptionalTime(token.LastUsedAt),
+		})
+	}
+	return body
+}
+
+func newResults(results []*models.ExportedResult) []Result {
+	body := make([]Result, 0, len(results))
+	for _, result := range results {
+		body = append(body, Result{
+			ID:                            uint64(result.ID),
+			WPM:                           result.WPM,
+			CPM:                           result.CPM,
+			Accuracy:                      result.Accuracy,
+			Duration:                      result.Duration.Milliseconds(),
+			PlayedAt:                      proto.MarshalTime(result.PlayedAt),
+			Language:                      result.Language,
+			Mode:                          result.Mode,
+			SubMode:                       result.SubMode,
+			IsPunctuation:                 result.IsPunctuation,
+			UncompletedTestsCount:         result.UncompletedTestsCount,
+			UncompletedTestsTotalDuration: result.UncompletedTestsTotalDuration.Milliseconds(),
+			IsDeleted:                     result.IsDeleted,
+		})
+	}
+	return body
+}
+
+// newArchive packs every part of the export into its own JSON file.
+func newArchive(export *models.AccountExport) ([]byte, error) {
+	files := []struct {
+		name    string
+		content any
+	}{
+		{name: "profile.json", content: newProfile(export.Account, export.Details)},
+		{name: "sessions.json", content: newSessions(export.Sessions)},
+		{name: "personal_tokens.json", content: newPersonalTokens(export.PersonalTokens)},
+		{name: "statistics.json", content: newResults(export.Statistics)},
+	}
+
+	var buf bytes.Buffer
+	archive := zip.NewWriter(&buf)
+
+	for _, file := range files {
+		w, err := archive.CreateHeader(&zip.FileHeader{
+			Name:     file.name,
+			Method:   zip.Deflate,
+			Modified: export.ExportedAt,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		if err = encoder.Encode(file.content); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := archive.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,104 @@
+package users_me_data_get_handler
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/ruslanonly/blindtyping/src/internal"
+	"github.com/ruslanonly/blindtyping/src/internal/api/middleware"
+	"github.com/ruslanonly/blindtyping/src/internal/models"
+	"github.com/ruslanonly/blindtyping/src/internal/services/account_service"
+	"github.com/ruslanonly/blindtyping/src/internal/shared/proto"
+)
+
+const (
+	handlerName = "users_me_data_get_handler"
+
+	archiveContentType = "application/zip"
+	archiveDisposition = `attachment; filename="blindtyping-data.zip"`
+)
+
+type accountService interface {
+	Export(ctx context.Context, userID models.ID) (*models.AccountExport, error)
+}
+
+type Handler struct {
+	accountService accountService
+	logger         internal.Logger
+}
+
+func (h *Handler) handleError(ctx context.Context, c *gin.Context, err error) {
+	var (
+		status  = http.StatusInternalServerError
+		message = "something went wrong serverside"
+	)
+
+	switch {
+	case account_service.IsUserNotFoundError(err):
+		status = http.StatusNotFound
+		message = "user not found"
+	}
+
+	ctx = h.logger.WithError(h.logger.WithStatusCode(ctx, status), err)
+
+	switch status {
+	case http.StatusInternalServerError:
+		h.logger.Error(ctx)
+	default:
+		h.logger.Warning(ctx)
+	}
+
+	proto.WriteError(c, status, message)
+}
+
+// Handle godoc
+// @Summary     Выгрузить свои данные
+// @Description Получить zip архив со всеми данными пользователя: профиль (profile.json), сессии (sessions.json), персональные токены (personal_tokens.json) и статистика, включая удаленную, но еще хранящуюся (statistics.json). Значения токенов в архив не попадают.
+// @Tags        Users
+// @Produce     application/zip
+// @Security    ApiKeyAuth
+// @Success     200 {file} file "Архив с данными пользователя"
+// @Failure     401 {object} proto.Error "Пользователь не авторизован"
+// @Failure     404 {object} proto.Error "Пользователь не найден"
+// @Failure     500 {object} proto.Error "Внутренняя ошибка сервера (смотреть логи)"
+// @Router      /users/me/data [get]
+func (h *Handler) Handle(c *gin.Context) {
+	ctx := h.logger.WithHandlerName(c.Request.Context(), handlerName)
+	req := newRequest(c)
+
+	export, err := h.accountService.Export(ctx, req.UserID)
+	if err != nil {
+		h.handleError(ctx, c, err)
+		return
+	}
+
+	archive, err := newArchive(export)
+	if err != nil {
+		h.handleError(ctx, c, err)
+		return
+	}
+
+	c.Header("Content-Disposition", archiveDisposition)
+	c.Data(http.StatusOK, archiveContentType, archive)
+}
+
+func (h *Handler) Method() string {
+	return http.MethodGet
+}
+
+func (h *Handler) Path() string {
+	return "/users/me/data"
+}
+
+func (h *Handler) Middleware() []string {
+	return []string{middleware.Auth, middleware.AccessRevocation, middleware.Session}
+}
+
+func New(accountService accountService, logger internal.Logger) *Handler {
+	return &Handler{
+		accountService: accountService,
+		logger:         logger,
+	}
+}
@@ -0,0 +1,29 @@
+package users_me_delete_handler
+
+import (
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/ruslanonly/blindtyping/src/internal/api"
+	"github.com/ruslanonly/blindtyping/src/internal/models"
+	"github.com/ruslanonly/blindtyping/src/internal/shared/proto"
+)
+
+type ResponseBody struct {
+	DeleteAt string `json:"deleteAt" example:"2025-11-19T19:02:29+03:00"`
+} //@name UsersMeDeleteHandler.ResponseBody
+
+type Request struct {
+	UserID models.ID
+}
+
+func newRequest(c *gin.Context) *Request {
+	return &Request{
+		UserID: models.ID(api.GetUserID(c)),
+	}
+}
+
+func newResponseBody(deleteAt time.Time) *ResponseBody {
+	return &ResponseBody{DeleteAt: proto.MarshalTime(deleteAt)}
+}
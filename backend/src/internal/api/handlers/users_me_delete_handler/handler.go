@@ -0,0 +1,108 @@
+package users_me_delete_handler
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/ruslanonly/blindtyping/src/internal"
+	"github.com/ruslanonly/blindtyping/src/internal/api/middleware"
+	"github.com/ruslanonly/blindtyping/src/internal/models"
+	"github.com/ruslanonly/blindtyping/src/internal/services/account_service"
+	"github.com/ruslanonly/blindtyping/src/internal/shared/proto"
+)
+
+const handlerName = "users_me_delete_handler"
+
+type accountService interface {
+	ScheduleDeletion(ctx context.Context, userID models.ID) (time.Time, error)
+}
+
+type cookieManager interface {
+	DeleteAccessToken(ctx *gin.Context)
+	DeleteRefreshToken(ctx *gin.Context)
+}
+
+type Handler struct {
+	accountService accountService
+	cookieManager  cookieManager
+	logger         internal.Logger
+}
+
+func (h *Handler) handleError(ctx context.Context, c *gin.Context, err error) {
+	var (
+		status  = http.StatusInternalServerError
+		message = "something went wrong serverside"
+	)
+
+	switch {
+	case account_service.IsUserNotFoundError(err):
+		status = http.StatusNotFound
+		message = "user not found"
+	case account_service.IsDeletionAlreadyScheduledError(err):
+		status = http.StatusConflict
+		message = err.Error()
+	}
+
+	ctx = h.logger.WithError(h.logger.WithStatusCode(ctx, status), err)
+
+	switch status {
+	case http.StatusInternalServerError:
+		h.logger.Error(ctx)
+	default:
+		h.logger.Warning(ctx)
+	}
+
+	proto.WriteError(c, status, message)
+}
+
+// Handle godoc
+// @Summary     Удалить аккаунт
+// @Description Запланировать удаление аккаунта. Все сессии пользователя сразу завершаются, а по истечении льготного периода аккаунт удаляется вместе со статистикой и остальными данными.
+// @Tags        Users
+// @Accept      json
+// @Produce     json
+// @Security    ApiKeyAuth
+// @Success     202 {object} ResponseBody "Удаление запланировано"
+// @Failure     401 {object} proto.Error "Пользователь не авторизован"
+// @Failure     404 {object} proto.Error "Пользователь не найден"
+// @Failure     409 {object} proto.Error "Удаление уже запланировано"
+// @Failure     500 {object} proto.Error "Внутренняя ошибка сервера (смотреть логи)"
+// @Router      /users/me [delete]
+func (h *Handler) Handle(c *gin.Context) {
+	ctx := h.logger.WithHandlerName(c.Request.Context(), handlerName)
+	req := newRequest(c)
+
+	deleteAt, err := h.accountService.ScheduleDeletion(ctx, req.UserID)
+	if err != nil {
+		h.handleError(ctx, c, err)
+		return
+	}
+
+	h.cookieManager.DeleteAccessToken(c)
+	h.cookieManager.DeleteRefreshToken(c)
+
+	proto.WriteJSON(c, http.StatusAccepted, newResponseBody(deleteAt))
+}
+
+func (h *Handler) Method() string {
+	return http.MethodDelete
+}
+
+func (h *Handler) Path() string {
+	return "/users/me"
+}
+
+func (h *Handler) Middleware() []string {
+	return []string{middleware.Auth, middleware.AccessRevocation, middleware.Session}
+}
+
+func New(accountService accountService, cookieManager cookieManager, logger internal.Logger) *Handler {
+	return &Handler{
+		accountService: accountService,
+		cookieManager:  cookieManager,
+		logger:         logger,
+	}
+}
@@ -99,7 +99,7 @@ func (h *Handler) Path() string {
 }
 
 func (h *Handler) Middleware() []string {
-	return []string{middleware.Auth}
+	return []string{middleware.Auth, middleware.AccessRevocation, middleware.ProfileRead}
 }
 
 func New(userService userService, logger internal.Logger) *Handler {
@@ -0,0 +1,36 @@
+package users_me_privacy_get_handler
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/ruslanonly/blindtyping/src/internal/api"
+	"github.com/ruslanonly/blindtyping/src/internal/models"
+)
+
+type Request struct {
+	UserID models.ID
+}
+
+type ResponseBody struct {
+	Visibility        string `json:"visibility" example:"public" enums:"public,unlisted,private"`
+	HidePersonalBests bool   `json:"hidePersonalBests" example:"false"`
+	HideTimePlayed    bool   `json:"hideTimePlayed" example:"true"`
+	HideLanguageStats bool   `json:"hideLanguageStats" example:"false"`
+	HideActivity      bool   `json:"hideActivity" example:"false"`
+} //@name UsersMePrivacyGetHandler.ResponseBody
+
+func newRequest(c *gin.Context) *Request {
+	return &Request{
+		UserID: models.ID(api.GetUserID(c)),
+	}
+}
+
+func newResponseBody(privacy *models.ProfilePrivacy) *ResponseBody {
+	return &ResponseBody{
+		Visibility:        string(privacy.Visibility),
+		HidePersonalBests: privacy.HidePersonalBests,
+		HideTimePlayed:    privacy.HideTimePlayed,
+		HideLanguageStats: privacy.HideLanguageStats,
+		HideActivity:      privacy.HideActivity,
+	}
+}
@@ -0,0 +1,92 @@
+package users_me_privacy_get_handler
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/ruslanonly/blindtyping/src/internal"
+	"github.com/ruslanonly/blindtyping/src/internal/api/middleware"
+	"github.com/ruslanonly/blindtyping/src/internal/models"
+	"github.com/ruslanonly/blindtyping/src/internal/services/profile_privacy_service"
+	"github.com/ruslanonly/blindtyping/src/internal/shared/proto"
+)
+
+const handlerName = "users_me_privacy_get_handler"
+
+type profilePrivacyService interface {
+	Get(ctx context.Context, userID models.ID) (*models.ProfilePrivacy, error)
+}
+
+type Handler struct {
+	profilePrivacyService profilePrivacyService
+	logger                internal.Logger
+}
+
+func (h *Handler) handleError(ctx context.Context, c *gin.Context, err error) {
+	var (
+		status  = http.StatusInternalServerError
+		message = "something went wrong serverside"
+	)
+
+	switch {
+	case profile_privacy_service.IsUserNotFoundError(err):
+		status = http.StatusNotFound
+		message = err.Error()
+	}
+
+	ctx = h.logger.WithError(h.logger.WithStatusCode(ctx, status), err)
+
+	switch status {
+	case http.StatusInternalServerError:
+		h.logger.Error(ctx)
+	default:
+		h.logger.Warning(ctx)
+	}
+
+	proto.WriteError(c, status, message)
+}
+
+// Handle godoc
+// @Summary     Настройки приватности профиля
+// @Description Возвращает видимость профиля текущего пользователя и скрытые разделы
+// @Tags        Profile
+// @Accept      json
+// @Produce     json
+// @Security    ApiKeyAuth
+// @Success     200 {object} ResponseBody "Настройки приватности"
+// @Failure     401 {object} proto.Error "Пользователь не авторизован"
+// @Failure     500 {object} proto.Error "Внутренняя ошибка сервера (смотреть логи)"
+// @Router      /users/me/privacy [get]
+func (h *Handler) Handle(c *gin.Context) {
+	ctx := h.logger.WithHandlerName(c.Request.Context(), handlerName)
+	req := newRequest(c)
+
+	privacy, err := h.profilePrivacyService.Get(ctx, req.UserID)
+	if err != nil {
+		h.handleError(ctx, c, err)
+		return
+	}
+
+	proto.WriteJSON(c, http.StatusOK, newResponseBody(privacy))
+}
+
+func (h *Handler) Method() string {
+	return http.MethodGet
+}
+
+func (h *Handler) Path() string {
+	return "/users/me/privacy"
+}
+
+func (h *Handler) Middleware() []string {
+	return []string{middleware.Auth, middleware.AccessRevocation, middleware.Session}
+}
+
+func New(profilePrivacyService profilePrivacyService, logger internal.Logger) *Handler {
+	return &Handler{
+		profilePrivacyService: profilePrivacyService,
+		logger:                logger,
+	}
+}
@@ -0,0 +1,65 @@
+package users_me_privacy_patch_handler
+
+import (
+	"errors"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/ruslanonly/blindtyping/src/internal/api"
+	"github.com/ruslanonly/blindtyping/src/internal/models"
+)
+
+// RequestBody changes only the fields that are present.
+type RequestBody struct {
+	Visibility        *string `json:"visibility" example:"unlisted" enums:"public,unlisted,private"`
+	HidePersonalBests *bool   `json:"hidePersonalBests" example:"false"`
+	HideTimePlayed    *bool   `json:"hideTimePlayed" example:"true"`
+	HideLanguageStats *bool   `json:"hideLanguageStats" example:"false"`
+	HideActivity      *bool   `json:"hideActivity" example:"false"`
+} //@name UsersMePrivacyPatchHandler.RequestBody
+
+type ResponseBody struct {
+	Visibility        string `json:"visibility" example:"unlisted"`
+	HidePersonalBests bool   `json:"hidePersonalBests" example:"false"`
+	HideTimePlayed    bool   `json:"hideTimePlayed" example:"true"`
+	HideLanguageStats bool   `json:"hideLanguageStats" example:"false"`
+	HideActivity      bool   `json:"hideActivity" example:"false"`
+} //@name UsersMePrivacyPatchHandler.ResponseBody
+
+type Request struct {
+	UserID models.ID
+	Update *models.ProfilePrivacyUpdate
+}
+
+func newRequest(c *gin.Context) (*Request, error) {
+	var body RequestBody
+	if err := c.ShouldBindBodyWithJSON(&body); err != nil {
+		return nil, errors.New("failed to parse json body")
+	}
+
+	update := &models.ProfilePrivacyUpdate{
+		HidePersonalBests: body.HidePersonalBests,
+		HideTimePlayed:    body.HideTimePlayed,
+		HideLanguageStats: body.HideLanguageStats,
+		HideActivity:      body.HideActivity,
+	}
+	if body.Visibility != nil {
+		visibility := models.ProfileVisibility(*body.Visibility)
+		update.Visibility = &visibility
+	}
+
+	return &Request{
+		UserID: models.ID(api.GetUserID(c)),
+		Update: update,
+	}, nil
+}
+
+func newResponseBody(privacy *models.ProfilePrivacy) *ResponseBody {
+	return &ResponseBody{
+		Visibility:        string(privacy.Visibility),
+		HidePersonalBests: privacy.HidePersonalBests,
+		HideTimePlayed:    privacy.HideTimePlayed,
+		HideLanguageStats: privacy.HideLanguageStats,
+		HideActivity:      privacy.HideActivity,
+	}
+}
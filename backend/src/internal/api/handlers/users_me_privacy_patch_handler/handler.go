@@ -0,0 +1,105 @@
+package users_me_privacy_patch_handler
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/ruslanonly/blindtyping/src/internal"
+	"github.com/ruslanonly/blindtyping/src/internal/api/middleware"
+	"github.com/ruslanonly/blindtyping/src/internal/models"
+	"github.com/ruslanonly/blindtyping/src/internal/services/profile_privacy_service"
+	"github.com/ruslanonly/blindtyping/src/internal/shared/proto"
+)
+
+const handlerName = "users_me_privacy_patch_handler"
+
+type profilePrivacyService interface {
+	Update(ctx context.Context, userID models.ID, update *models.ProfilePrivacyUpdate) (*models.ProfilePrivacy, error)
+}
+
+type Handler struct {
+	profilePrivacyService profilePrivacyService
+	logger                internal.Logger
+}
+
+func (h *Handler) handleError(ctx context.Context, c *gin.Context, err error) {
+	var (
+		status  = http.StatusInternalServerError
+		message = "something went wrong serverside"
+	)
+
+	switch {
+	case models.IsProfilePrivacyValidationError(err):
+		status = http.StatusBadRequest
+		message = err.Error()
+	case profile_privacy_service.IsUserNotFoundError(err):
+		status = http.StatusNotFound
+		message = err.Error()
+	}
+
+	ctx = h.logger.WithError(h.logger.WithStatusCode(ctx, status), err)
+
+	switch status {
+	case http.StatusInternalServerError:
+		h.logger.Error(ctx)
+	default:
+		h.logger.Warning(ctx)
+	}
+
+	proto.WriteError(c, status, message)
+}
+
+// Handle godoc
+// @Summary     Изменить настройки приватности профиля
+// @Description Изменить видимость профиля: public — виден всем, unlisted — виден по ссылке, но не индексируется, private — виден только владельцу.
+// @Description Скрытые разделы (рекорды, время в игре, статистика по языкам, активность) видны только владельцу. Меняются только переданные поля
+// @Tags        Profile
+// @Accept      json
+// @Produce     json
+// @Security    ApiKeyAuth
+// @Param       body body RequestBody true "Изменяемые настройки"
+// @Success     200 {object} ResponseBody "Настройки после изменения"
+// @Failure     400 {object} proto.Error "Неизвестная видимость профиля"
+// @Failure     401 {object} proto.Error "Пользователь не авторизован"
+// @Failure     500 {object} proto.Error "Внутренняя ошибка сервера (смотреть логи)"
+// @Router      /users/me/privacy [patch]
+func (h *Handler) Handle(c *gin.Context) {
+	ctx := h.logger.WithHandlerName(c.Request.Context(), handlerName)
+
+	req, err := newRequest(c)
+	if err != nil {
+		ctx = h.logger.WithStatusCode(ctx, http.StatusBadRequest)
+		h.logger.Warning(h.logger.WithError(ctx, err))
+		proto.WriteError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	privacy, err := h.profilePrivacyService.Update(ctx, req.UserID, req.Update)
+	if err != nil {
+		h.handleError(ctx, c, err)
+		return
+	}
+
+	proto.WriteJSON(c, http.StatusOK, newResponseBody(privacy))
+}
+
+func (h *Handler) Method() string {
+	return http.MethodPatch
+}
+
+func (h *Handler) Path() string {
+	return "/users/me/privacy"
+}
+
+func (h *Handler) Middleware() []string {
+	return []string{middleware.Auth, middleware.AccessRevocation, middleware.Session}
+}
+
+func New(profilePrivacyService profilePrivacyService, logger internal.Logger) *Handler {
+	return &Handler{
+		profilePrivacyService: profilePrivacyService,
+		logger:                logger,
+	}
+}
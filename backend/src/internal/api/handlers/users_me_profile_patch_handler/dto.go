@@ -0,0 +1,59 @@
+package users_me_profile_patch_handler
+
+import (
+	"errors"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/ruslanonly/blindtyping/src/internal/api"
+	"github.com/ruslanonly/blindtyping/src/internal/models"
+)
+
+// RequestBody changes only the fields that are present, an empty string
+// clears a field.
+type RequestBody struct {
+	Bio      *string `json:"bio" example:"Печатаю вслепую с 2020 года"`
+	Country  *string `json:"country" example:"RU"`
+	Keyboard *string `json:"keyboard" example:"Keychron K2"`
+	Layout   *string `json:"layout" example:"ЙЦУКЕН"`
+} //@name UsersMeProfilePatchHandler.RequestBody
+
+type ResponseBody struct {
+	AvatarURL string `json:"avatarUrl" example:"http://localhost:5001/avatars/0b7a8a1e-3f9c-4b8f-9a52-6d2b3c1e2f40.jpg"`
+	Bio       string `json:"bio" example:"Печатаю вслепую с 2020 года"`
+	Country   string `json:"country" example:"RU"`
+	Keyboard  string `json:"keyboard" example:"Keychron K2"`
+	Layout    string `json:"layout" example:"ЙЦУКЕН"`
+} //@name UsersMeProfilePatchHandler.ResponseBody
+
+type Request struct {
+	UserID models.ID
+	Update *models.ProfileDetailsUpdate
+}
+
+func newRequest(c *gin.Context) (*Request, error) {
+	var body RequestBody
+	if err := c.ShouldBindBodyWithJSON(&body); err != nil {
+		return nil, errors.New("failed to parse json body")
+	}
+
+	return &Request{
+		UserID: models.ID(api.GetUserID(c)),
+		Update: &models.ProfileDetailsUpdate{
+			Bio:      body.Bio,
+			Country:  body.Country,
+			Keyboard: body.Keyboard,
+			Layout:   body.Layout,
+		},
+	}, nil
+}
+
+func newResponseBody(details *models.ProfileDetails) *ResponseBody {
+	return &ResponseBody{
+		AvatarURL: details.AvatarURL,
+		Bio:       details.Bio,
+		Country:   details.Country,
+		Keyboard:  details.Keyboard,
+		Layout:    details.Layout,
+	}
+}
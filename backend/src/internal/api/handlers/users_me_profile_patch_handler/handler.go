@@ -0,0 +1,105 @@
+package users_me_profile_patch_handler
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/ruslanonly/blindtyping/src/internal"
+	"github.com/ruslanonly/blindtyping/src/internal/api/middleware"
+	"github.com/ruslanonly/blindtyping/src/internal/models"
+	"github.com/ruslanonly/blindtyping/src/internal/services/profile_details_service"
+	"github.com/ruslanonly/blindtyping/src/internal/shared/proto"
+)
+
+const handlerName = "users_me_profile_patch_handler"
+
+type profileDetailsService interface {
+	Update(ctx context.Context, userID models.ID, update *models.ProfileDetailsUpdate) (*models.ProfileDetails, error)
+}
+
+type Handler struct {
+	profileDetailsService profileDetailsService
+	logger                internal.Logger
+}
+
+func (h *Handler) handleError(ctx context.Context, c *gin.Context, err error) {
+	var (
+		status  = http.StatusInternalServerError
+		message = "something went wrong serverside"
+	)
+
+	switch {
+	case models.IsProfileDetailsValidationError(err):
+		status = http.StatusBadRequest
+		message = err.Error()
+	case profile_details_service.IsUserNotFoundError(err):
+		status = http.StatusNotFound
+		message = err.Error()
+	}
+
+	ctx = h.logger.WithError(h.logger.WithStatusCode(ctx, status), err)
+
+	switch status {
+	case http.StatusInternalServerError:
+		h.logger.Error(ctx)
+	default:
+		h.logger.Warning(ctx)
+	}
+
+	proto.WriteError(c, status, message)
+}
+
+// Handle godoc
+// @Summary     Изменить профиль
+// @Description Изменить поля публичного профиля: о себе (до 300 символов), страну (код ISO 3166-1 alpha-2), клавиатуру и раскладку.
+// @Description Меняются только переданные поля, пустая строка очищает поле
+// @Tags        Profile
+// @Accept      json
+// @Produce     json
+// @Security    ApiKeyAuth
+// @Param       body body RequestBody true "Изменяемые поля профиля"
+// @Success     200 {object} ResponseBody "Профиль после изменения"
+// @Failure     400 {object} proto.Error "Поле не подходит под условия"
+// @Failure     401 {object} proto.Error "Пользователь не авторизован"
+// @Failure     500 {object} proto.Error "Внутренняя ошибка сервера (смотреть логи)"
+// @Router      /users/me/profile [patch]
+func (h *Handler) Handle(c *gin.Context) {
+	ctx := h.logger.WithHandlerName(c.Request.Context(), handlerName)
+
+	req, err := newRequest(c)
+	if err != nil {
+		ctx = h.logger.WithStatusCode(ctx, http.StatusBadRequest)
+		h.logger.Warning(h.logger.WithError(ctx, err))
+		proto.WriteError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	details, err := h.profileDetailsService.Update(ctx, req.UserID, req.Update)
+	if err != nil {
+		h.handleError(ctx, c, err)
+		return
+	}
+
+	proto.WriteJSON(c, http.StatusOK, newResponseBody(details))
+}
+
+func (h *Handler) Method() string {
+	return http.MethodPatch
+}
+
+func (h *Handler) Path() string {
+	return "/users/me/profile"
+}
+
+func (h *Handler) Middleware() []string {
+	return []string{middleware.Auth, middleware.AccessRevocation, middleware.Session}
+}
+
+func New(profileDetailsService profileDetailsService, logger internal.Logger) *Handler {
+	return &Handler{
+		profileDetailsService: profileDetailsService,
+		logger:                logger,
+	}
+}
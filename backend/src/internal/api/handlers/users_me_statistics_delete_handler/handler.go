@@ -15,7 +15,7 @@ import (
 const handlerName = "users_me_statistics_delete_handler"
 
 type statisticsService interface {
-	DeleteAllForUser(ctx context.Context, userID uint64) error
+	Wipe(ctx context.Context, userID models.ID) error
 }
 
 type Handler struct {
@@ -49,7 +49,7 @@ func (h *Handler) handleError(ctx context.Context, c *gin.Context, err error) {
 
 // Handle godoc
 // @Summary Отчистить всю статистику пользователя
-// @Description Делает soft delete для всей статистики пользователя
+// @Description Делает soft delete для всей статистики пользователя. Удаление можно отменить через POST /users/me/statistics/restore в течение restore_window
 // @Tags User Statistics
 // @Accept json
 // @Produce json
@@ -63,7 +63,7 @@ func (h *Handler) Handle(c *gin.Context) {
 	ctx := h.logger.WithHandlerName(c.Request.Context(), handlerName)
 	req := newRequest(c)
 
-	err := h.statisticsService.DeleteAllForUser(ctx, req.userID)
+	err := h.statisticsService.Wipe(ctx, models.ID(req.userID))
 	if err != nil {
 		h.handleError(ctx, c, err)
 		return
@@ -79,7 +79,7 @@ func (h *Handler) Path() string {
 }
 
 func (h *Handler) Middleware() []string {
-	return []string{middleware.Auth}
+	return []string{middleware.Auth, middleware.AccessRevocation, middleware.Session}
 }
 
 func New(logger internal.Logger, statisticsService statisticsService) *Handler {
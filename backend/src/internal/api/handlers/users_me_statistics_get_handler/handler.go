@@ -170,7 +170,7 @@ func (h *Handler) Path() string {
 }
 
 func (h *Handler) Middleware() []string {
-	return []string{middleware.Auth}
+	return []string{middleware.Auth, middleware.AccessRevocation, middleware.StatisticsRead}
 }
 
 func New(statisticsGetter statisticsGetter, logger internal.Logger) *Handler {
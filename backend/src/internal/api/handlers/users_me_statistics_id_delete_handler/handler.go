@@ -0,0 +1,99 @@
+package users_me_statistics_id_delete_handler
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/ruslanonly/blindtyping/src/internal"
+	"github.com/ruslanonly/blindtyping/src/internal/api/middleware"
+	"github.com/ruslanonly/blindtyping/src/internal/models"
+	"github.com/ruslanonly/blindtyping/src/internal/services/statistics_deletion_service"
+	"github.com/ruslanonly/blindtyping/src/internal/shared/proto"
+)
+
+const handlerName = "users_me_statistics_id_delete_handler"
+
+type statisticsService interface {
+	Delete(ctx context.Context, userID, id models.ID) error
+}
+
+type Handler struct {
+	statisticsService statisticsService
+	logger            internal.Logger
+}
+
+func (h *Handler) handleError(ctx context.Context, c *gin.Context, err error) {
+	var (
+		status  = http.StatusInternalServerError
+		message = "something went wrong serverside"
+	)
+
+	switch {
+	case statistics_deletion_service.IsResultNotFoundError(err):
+		status = http.StatusNotFound
+		message = err.Error()
+	}
+
+	ctx = h.logger.WithError(h.logger.WithStatusCode(ctx, status), err)
+
+	switch status {
+	case http.StatusInternalServerError:
+		h.logger.Error(ctx)
+	default:
+		h.logger.Warning(ctx)
+	}
+
+	proto.WriteError(c, status, message)
+}
+
+// Handle godoc
+// @Summary     Удалить результат
+// @Description Делает soft delete для одного результата пользователя
+// @Tags        User Statistics
+// @Accept      json
+// @Produce     json
+// @Security    ApiKeyAuth
+// @Param       id path int true "ID результата"
+// @Success     200 "Результат удален"
+// @Failure     400 {object} proto.Error "Неверный ID результата"
+// @Failure     401 {object} proto.Error "Пользователь не авторизован"
+// @Failure     404 {object} proto.Error "Результат не найден или уже удален"
+// @Failure     500 {object} proto.Error "Внутренняя ошибка сервера (смотреть логи)"
+// @Router      /users/me/statistics/{id} [delete]
+func (h *Handler) Handle(c *gin.Context) {
+	ctx := h.logger.WithHandlerName(c.Request.Context(), handlerName)
+
+	req, err := newRequest(c)
+	if err != nil {
+		ctx = h.logger.WithStatusCode(ctx, http.StatusBadRequest)
+		h.logger.Warning(h.logger.WithError(ctx, err))
+		proto.WriteError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	if err = h.statisticsService.Delete(ctx, req.UserID, req.ResultID); err != nil {
+		h.handleError(ctx, c, err)
+		return
+	}
+}
+
+func (h *Handler) Method() string {
+	return http.MethodDelete
+}
+
+func (h *Handler) Path() string {
+	return "/users/me/statistics/:id"
+}
+
+func (h *Handler) Middleware() []string {
+	return []string{middleware.Auth, middleware.AccessRevocation, middleware.Session}
+}
+
+func New(statisticsService statisticsService, logger internal.Logger) *Handler {
+	return &Handler{
+		statisticsService: statisticsService,
+		logger:            logger,
+	}
+}
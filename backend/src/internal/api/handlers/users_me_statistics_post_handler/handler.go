@@ -2,7 +2,10 @@ package users_me_statistics_post_handler
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"net/http"
+	"strconv"
 
 	"github.com/AlekSi/pointer"
 	"github.com/gin-gonic/gin"
@@ -11,32 +14,76 @@ import (
 	"github.com/ruslanonly/blindtyping/src/internal/api"
 	"github.com/ruslanonly/blindtyping/src/internal/api/middleware"
 	"github.com/ruslanonly/blindtyping/src/internal/models"
+	"github.com/ruslanonly/blindtyping/src/internal/services/antifroad_key_service"
+	"github.com/ruslanonly/blindtyping/src/internal/services/statistics_review_service"
 	"github.com/ruslanonly/blindtyping/src/internal/services/statistics_service"
+	"github.com/ruslanonly/blindtyping/src/internal/shared/metrics"
 	"github.com/ruslanonly/blindtyping/src/internal/shared/proto"
 )
 
-const handlerName = "users_me_statistics_post_handler"
+const (
+	handlerName = "users_me_statistics_post_handler"
+
+	// keystrokeSize is room for a single keystroke in the body.
+	keystrokeSize = 128
+	// bodyOverhead is room for the result fields around the keystrokes.
+	bodyOverhead = 16 << 10
+)
+
+var errTooManyKeystrokes = errors.New("too many keystrokes")
 
 type statisticsSaver interface {
 	Save(ctx context.Context, in *statistics_service.SaveIn) (*statistics_service.SaveOut, error)
 }
 
+type reviewQueue interface {
+	Check(ctx context.Context, in *statistics_review_service.CheckIn) (*models.StatisticsReview, error)
+	Learn(ctx context.Context, userID models.ID, keystrokes []models.Keystroke) error
+}
+
+type antifroadKeyVerifier interface {
+	Verify(ctx context.Context, in *antifroad_key_service.VerifyIn) error
+	VerifyResult(ctx context.Context, in *antifroad_key_service.VerifyResultIn) error
+}
+
+type statisticsMetrics interface {
+	ResultSaved()
+	PersonalBestSet()
+	FraudRejected(reason metrics.FraudReason)
+}
+
+type analyticsTracker interface {
+	Track(ctx context.Context, event *models.AnalyticsEvent)
+	FinishTest(ctx context.Context, userID models.ID, id string) error
+}
+
+type Keystroke struct {
+	Key        string `json:"key" example:"a" description:"Pressed key"`
+	PressedAt  uint64 `json:"pressedAtMs" example:"1200" description:"Press time since the test start in milliseconds"`
+	ReleasedAt uint64 `json:"releasedAtMs" example:"1290" description:"Release time since the test start in milliseconds"`
+} //@name UsersMeStatisticsPostHandler.Keystroke
+
 type RequestBody struct {
-	WPM                        float64 `json:"wpm" example:"42.5" description:"Words per minute"`
-	CPM                        float64 `json:"cpm" example:"210.3" description:"Characters per minute"`
-	Accuracy                   float64 `json:"accuracy" example:"98.7" description:"Accuracy percentage"`
-	DurationMs                 uint64  `json:"durationMs" example:"60000" description:"Duration in milliseconds"`
-	Language                   string  `json:"language" example:"english" description:"Language used for typing"`
-	Mode                       string  `json:"mode" example:"time" description:"Typing mode"`
-	SubMode                    string  `json:"submode" example:"1m" description:"Mode-specific parameters"`
-	IsPunctuation              bool    `json:"isPunctuation" example:"true" description:"Whether punctuation was enabled"`
-	UncompletedTestsCount      *uint64 `json:"uncompletedTestsCount" example:"0" description:"Uncompleted test count"`
-	UncompletedTestsDurationMs *uint64 `json:"uncompletedTestsDurationMs" example:"0" description:"Total duration of uncompleted tests"`
-	UID                        string  `json:"uid" example:"0" description:"Unique request ID"`
-	Sign                       string  `json:"sign" example:"12345" description:"Signature"`
-	CreatedAt                  string  `json:"createdAt" example:"2025-10-19T19:02:29+03:00" description:"Creation time in RFC3339"`
-	StartedAt                  string  `json:"startedAt" example:"2025-10-19T19:02:29+03:00" description:"Start time in RFC3339"`
-	FinishedAt                 string  `json:"finishedAt" example:"2025-10-19T19:02:29+03:00" description:"Finish time in RFC3339"`
+	WPM                        float64     `json:"wpm" example:"42.5" description:"Words per minute"`
+	CPM                        float64     `json:"cpm" example:"210.3" description:"Characters per minute"`
+	Accuracy                   float64     `json:"accuracy" example:"98.7" description:"Accuracy percentage"`
+	DurationMs                 uint64      `json:"durationMs" example:"60000" description:"Duration in milliseconds"`
+	Language                   string      `json:"language" example:"english" description:"Language used for typing"`
+	Mode                       string      `json:"mode" example:"time" description:"Typing mode"`
+	SubMode                    string      `json:"submode" example:"1m" description:"Mode-specific parameters"`
+	IsPunctuation              bool        `json:"isPunctuation" example:"true" description:"Whether punctuation was enabled"`
+	UncompletedTestsCount      *uint64     `json:"uncompletedTestsCount" example:"0" description:"Uncompleted test count"`
+	UncompletedTestsDurationMs *uint64     `json:"uncompletedTestsDurationMs" example:"0" description:"Total duration of uncompleted tests"`
+	UID                        string      `json:"uid" example:"0" description:"Unique request ID"`
+	Sign                       string      `json:"sign" example:"12345" description:"HMAC-SHA256 with the key named by keyId of the fields covered by sessionSign"`
+	KeyID                      string      `json:"keyId" example:"3f2a9c1e7b8d4e6f" description:"ID of the antifroad key the result was signed with, required"`
+	SessionID                  string      `json:"sessionId" example:"9b1c0f4e2a7d4c3b8e5f6a7b8c9d0e1f" description:"Antifroad session the result was signed in"`
+	SessionSign                string      `json:"sessionSign" example:"a1b2c3" description:"Signature made with the antifroad session secret"`
+	TestID                     string      `json:"testId" example:"4e2a7d4c3b8e5f6a9b1c0f7b8c9d0e1f" description:"Test returned by POST /users/me/tests, so it is not reported as abandoned"`
+	CreatedAt                  string      `json:"createdAt" example:"2025-10-19T19:02:29+03:00" description:"Creation time in RFC3339"`
+	StartedAt                  string      `json:"startedAt" example:"2025-10-19T19:02:29+03:00" description:"Start time in RFC3339"`
+	FinishedAt                 string      `json:"finishedAt" example:"2025-10-19T19:02:29+03:00" description:"Finish time in RFC3339"`
+	Keystrokes                 []Keystroke `json:"keystrokes" description:"Keystrokes in the order they were pressed, at most antifroad.biometrics.max_keystrokes"`
 } //@name UsersMeStatisticsPostHandler.RequestBody
 
 type Request struct {
@@ -47,18 +94,31 @@ type Request struct {
 type ResponseBody struct {
 	IsPersonalBest bool    `json:"isPersonalBest"`
 	WPMShift       float64 `json:"wpmShift"`
+	IsUnderReview  bool    `json:"isUnderReview"`
 } //@name UsersMyStatisticsPostHandler.ResponseBody
 
 type Handler struct {
-	statisticsSaver statisticsSaver
-	logger          internal.Logger
+	statisticsSaver      statisticsSaver
+	reviewQueue          reviewQueue
+	antifroadKeyVerifier antifroadKeyVerifier
+	statisticsMetrics    statisticsMetrics
+	analyticsTracker     analyticsTracker
+	logger               internal.Logger
+	maxKeystrokes        int
 }
 
 func (h *Handler) newRequest(c *gin.Context) (*Request, error) {
 	body := new(RequestBody)
 	if err := c.ShouldBindBodyWithJSON(body); err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			return nil, errTooManyKeystrokes
+		}
 		return nil, err
 	}
+	if len(body.Keystrokes) > h.maxKeystrokes {
+		return nil, fmt.Errorf("%w: at most %d are accepted", errTooManyKeystrokes, h.maxKeystrokes)
+	}
 
 	return &Request{
 		body:   body,
@@ -102,6 +162,97 @@ func (h *Handler) newSaveIn(r *Request) (*statistics_service.SaveIn, error) {
 	}, nil
 }
 
+// signedFields lists the signed fields in the order documented for
+// POST /users/me/antifroad/session. Both sign and sessionSign cover them. Times
+// are signed as sent.
+func (h *Handler) signedFields(r *Request) []string {
+	formatFloat := func(v float64) string {
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	}
+
+	return []string{
+		r.body.UID,
+		formatFloat(r.body.WPM),
+		formatFloat(r.body.CPM),
+		formatFloat(r.body.Accuracy),
+		strconv.FormatUint(r.body.DurationMs, 10),
+		r.body.Language,
+		r.body.Mode,
+		r.body.SubMode,
+		strconv.FormatBool(r.body.IsPunctuation),
+		strconv.FormatUint(pointer.GetUint64(r.body.UncompletedTestsCount), 10),
+		strconv.FormatUint(pointer.GetUint64(r.body.UncompletedTestsDurationMs), 10),
+		r.body.CreatedAt,
+		r.body.StartedAt,
+		r.body.FinishedAt,
+	}
+}
+
+func (h *Handler) newVerifyIn(r *Request) *antifroad_key_service.VerifyIn {
+	return &antifroad_key_service.VerifyIn{
+		KID:    r.body.KeyID,
+		Sign:   r.body.Sign,
+		Fields: h.signedFields(r),
+	}
+}
+
+func (h *Handler) newVerifyResultIn(r *Request) *antifroad_key_service.VerifyResultIn {
+	return &antifroad_key_service.VerifyResultIn{
+		UserID:    r.userID,
+		SessionID: r.body.SessionID,
+		Sign:      r.body.SessionSign,
+		Fields:    h.signedFields(r),
+	}
+}
+
+func (h *Handler) newKeystrokes(r *Request) []models.Keystroke {
+	keystrokes := make([]models.Keystroke, 0, len(r.body.Keystrokes))
+	for _, keystroke := range r.body.Keystrokes {
+		keystrokes = append(keystrokes, models.Keystroke{
+			Key:        keystroke.Key,
+			PressedAt:  proto.ParseMilliseconds(&keystroke.PressedAt),
+			ReleasedAt: proto.ParseMilliseconds(&keystroke.ReleasedAt),
+		})
+	}
+
+	return keystrokes
+}
+
+// finishTest closes the test the result was submitted for. Its start and
+// abandonment are tracked by POST /users/me/tests and stale_tests_handler.
+func (h *Handler) finishTest(ctx context.Context, r *Request) {
+	if err := h.analyticsTracker.FinishTest(ctx, r.userID, r.body.TestID); err != nil {
+		h.logger.Error(h.logger.WithError(ctx, err))
+	}
+}
+
+// trackResult emits analytics events of a saved result.
+func (h *Handler) trackResult(ctx context.Context, in *statistics_service.SaveIn, out *statistics_service.SaveOut) {
+	userID := in.UserID
+
+	h.analyticsTracker.Track(ctx, &models.AnalyticsEvent{
+		Name:   models.AnalyticsTestCompleted,
+		UserID: &userID,
+		Properties: map[string]any{
+			"mode":     in.Mode,
+			"submode":  in.SubMode,
+			"language": in.Language,
+			"wpm":      in.WPM,
+			"accuracy": in.Accuracy,
+		},
+		OccurredAt: in.FinishedAt,
+	})
+
+	if out.IsPB {
+		h.analyticsTracker.Track(ctx, &models.AnalyticsEvent{
+			Name:       models.AnalyticsPersonalBest,
+			UserID:     &userID,
+			Properties: map[string]any{"mode": in.Mode, "submode": in.SubMode, "language": in.Language, "wpm": in.WPM},
+			OccurredAt: in.FinishedAt,
+		})
+	}
+}
+
 func (h *Handler) newResponseBody(out *statistics_service.SaveOut) *ResponseBody {
 	return &ResponseBody{
 		IsPersonalBest: out.IsPB,
@@ -122,10 +273,21 @@ func (h *Handler) handleError(ctx context.Context, c *gin.Context, err error) {
 	case models.IsValidationError(err):
 		status = http.StatusBadRequest
 		message = err.Error()
-	case statistics_service.IsFroadError(err):
+	case antifroad_key_service.IsUnknownKeyError(err),
+		antifroad_key_service.IsKeyRequiredError(err),
+		antifroad_key_service.IsKeyExpiredError(err),
+		antifroad_key_service.IsUnknownSessionError(err),
+		antifroad_key_service.IsSessionRequiredError(err),
+		statistics_review_service.IsUnverifiedResultError(err):
+		status = http.StatusBadRequest
+		message = err.Error()
+	case antifroad_key_service.IsInvalidSignatureError(err),
+		statistics_service.IsFroadError(err),
+		statistics_review_service.IsMachineInputError(err):
 		status = http.StatusBadRequest
 		message = "froad detected"
-	case statistics_service.IsAlreadyHandledError(err):
+	case statistics_service.IsAlreadyHandledError(err),
+		statistics_review_service.IsResultAlreadyQueuedError(err):
 		status = http.StatusConflict
 		message = "stats already handled"
 	}
@@ -151,18 +313,28 @@ func (h *Handler) handleError(ctx context.Context, c *gin.Context, err error) {
 // @Security ApiKeyAuth
 // @Param request body RequestBody true "Statistics data to save"
 // @Success 201 {object} ResponseBody "Statistics successfully created"
-// @Failure 400 {object} proto.Error "Invalid request body"
+// @Success 202 {object} ResponseBody "Statistics look anomalous and are held for moderator review"
+// @Failure 400 {object} proto.Error "Invalid request body, missing, unknown or expired antifroad key, unknown session, invalid signature"
 // @Failure 401 {object} proto.Error "Unauthorized"
+// @Failure 413 {object} proto.Error "Too many keystrokes"
+// @Failure 429 {object} proto.Error "Too many requests"
 // @Failure 500 {object} proto.Error "Internal server error"
 // @Router /users/me/statistics [post]
 func (h *Handler) Handle(c *gin.Context) {
 	ctx := h.logger.WithHandlerName(c.Request.Context(), handlerName)
 
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, bodyOverhead+int64(h.maxKeystrokes)*keystrokeSize)
+
 	r, err := h.newRequest(c)
 	if err != nil {
-		ctx = h.logger.WithStatusCode(ctx, http.StatusBadRequest)
+		status := http.StatusBadRequest
+		if errors.Is(err, errTooManyKeystrokes) {
+			status = http.StatusRequestEntityTooLarge
+		}
+
+		ctx = h.logger.WithStatusCode(ctx, status)
 		h.logger.Warning(h.logger.WithError(ctx, err))
-		proto.WriteError(c, http.StatusBadRequest, err)
+		proto.WriteError(c, status, err)
 		return
 	}
 
@@ -174,12 +346,55 @@ func (h *Handler) Handle(c *gin.Context) {
 		return
 	}
 
+	if err = h.antifroadKeyVerifier.Verify(ctx, h.newVerifyIn(r)); err != nil {
+		h.handleError(ctx, c, err)
+		return
+	}
+
+	if err = h.antifroadKeyVerifier.VerifyResult(ctx, h.newVerifyResultIn(r)); err != nil {
+		h.handleError(ctx, c, err)
+		return
+	}
+
+	keystrokes := h.newKeystrokes(r)
+
+	// VerifyResult has passed, so a result with a session has a verified signature
+	review, err := h.reviewQueue.Check(ctx, &statistics_review_service.CheckIn{
+		Result:     in,
+		Keystrokes: keystrokes,
+		Verified:   r.body.SessionID != "",
+	})
+	if err != nil {
+		h.handleError(ctx, c, err)
+		return
+	}
+	if review != nil {
+		h.finishTest(ctx, r)
+		proto.WriteJSON(c, http.StatusAccepted, &ResponseBody{IsUnderReview: true})
+		return
+	}
+
 	saveOut, err := h.statisticsSaver.Save(ctx, in)
 	if err != nil {
+		// statistics_service has fraud checks of its own and does not count rejections
+		if statistics_service.IsFroadError(err) {
+			h.statisticsMetrics.FraudRejected(metrics.FraudSignature)
+		}
 		h.handleError(ctx, c, err)
 		return
 	}
 
+	h.statisticsMetrics.ResultSaved()
+	if saveOut.IsPB {
+		h.statisticsMetrics.PersonalBestSet()
+	}
+	h.finishTest(ctx, r)
+	h.trackResult(ctx, in, saveOut)
+
+	if err = h.reviewQueue.Learn(ctx, in.UserID, keystrokes); err != nil {
+		h.logger.Error(h.logger.WithError(ctx, err))
+	}
+
 	responseBody := h.newResponseBody(saveOut)
 	proto.WriteJSON(c, http.StatusCreated, responseBody)
 }
@@ -193,12 +408,30 @@ func (h *Handler) Path() string {
 }
 
 func (h *Handler) Middleware() []string {
-	return []string{middleware.Auth}
+	return []string{
+		middleware.Auth,
+		middleware.AccessRevocation,
+		middleware.StatisticsWrite,
+		middleware.StatisticsWriteRateLimit,
+	}
 }
 
-func New(statisticsSaver statisticsSaver, logger internal.Logger) *Handler {
+func New(
+	statisticsSaver statisticsSaver,
+	reviewQueue reviewQueue,
+	antifroadKeyVerifier antifroadKeyVerifier,
+	statisticsMetrics statisticsMetrics,
+	analyticsTracker analyticsTracker,
+	logger internal.Logger,
+	maxKeystrokes int,
+) *Handler {
 	return &Handler{
-		statisticsSaver: statisticsSaver,
-		logger:          logger,
+		statisticsSaver:      statisticsSaver,
+		reviewQueue:          reviewQueue,
+		antifroadKeyVerifier: antifroadKeyVerifier,
+		statisticsMetrics:    statisticsMetrics,
+		analyticsTracker:     analyticsTracker,
+		logger:               logger,
+		maxKeystrokes:        maxKeystrokes,
 	}
 }
@@ -0,0 +1,26 @@
+package users_me_statistics_restore_post_handler
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/ruslanonly/blindtyping/src/internal/api"
+	"github.com/ruslanonly/blindtyping/src/internal/models"
+)
+
+type ResponseBody struct {
+	Restored int64 `json:"restored" example:"120"`
+} //@name UsersMeStatisticsRestorePostHandler.ResponseBody
+
+type Request struct {
+	UserID models.ID
+}
+
+func newRequest(c *gin.Context) *Request {
+	return &Request{
+		UserID: models.ID(api.GetUserID(c)),
+	}
+}
+
+func newResponseBody(restored int64) *ResponseBody {
+	return &ResponseBody{Restored: restored}
+}
@@ -0,0 +1,93 @@
+package users_me_statistics_restore_post_handler
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/ruslanonly/blindtyping/src/internal"
+	"github.com/ruslanonly/blindtyping/src/internal/api/middleware"
+	"github.com/ruslanonly/blindtyping/src/internal/models"
+	"github.com/ruslanonly/blindtyping/src/internal/services/statistics_deletion_service"
+	"github.com/ruslanonly/blindtyping/src/internal/shared/proto"
+)
+
+const handlerName = "users_me_statistics_restore_post_handler"
+
+type statisticsService interface {
+	Restore(ctx context.Context, userID models.ID) (int64, error)
+}
+
+type Handler struct {
+	statisticsService statisticsService
+	logger            internal.Logger
+}
+
+func (h *Handler) handleError(ctx context.Context, c *gin.Context, err error) {
+	var (
+		status  = http.StatusInternalServerError
+		message = "something went wrong serverside"
+	)
+
+	switch {
+	case statistics_deletion_service.IsNothingToRestoreError(err):
+		status = http.StatusNotFound
+		message = err.Error()
+	}
+
+	ctx = h.logger.WithError(h.logger.WithStatusCode(ctx, status), err)
+
+	switch status {
+	case http.StatusInternalServerError:
+		h.logger.Error(ctx)
+	default:
+		h.logger.Warning(ctx)
+	}
+
+	proto.WriteError(c, status, message)
+}
+
+// Handle godoc
+// @Summary     Восстановить статистику
+// @Description Отменить последнее удаление всей статистики, если оно было не раньше restore_window назад. Отдельно удаленные результаты не восстанавливаются
+// @Tags        User Statistics
+// @Accept      json
+// @Produce     json
+// @Security    ApiKeyAuth
+// @Success     200 {object} ResponseBody "Статистика восстановлена"
+// @Failure     401 {object} proto.Error "Пользователь не авторизован"
+// @Failure     404 {object} proto.Error "Нет удаления, которое можно отменить"
+// @Failure     500 {object} proto.Error "Внутренняя ошибка сервера (смотреть логи)"
+// @Router      /users/me/statistics/restore [post]
+func (h *Handler) Handle(c *gin.Context) {
+	ctx := h.logger.WithHandlerName(c.Request.Context(), handlerName)
+	req := newRequest(c)
+
+	restored, err := h.statisticsService.Restore(ctx, req.UserID)
+	if err != nil {
+		h.handleError(ctx, c, err)
+		return
+	}
+
+	proto.WriteJSON(c, http.StatusOK, newResponseBody(restored))
+}
+
+func (h *Handler) Method() string {
+	return http.MethodPost
+}
+
+func (h *Handler) Path() string {
+	return "/users/me/statistics/restore"
+}
+
+func (h *Handler) Middleware() []string {
+	return []string{middleware.Auth, middleware.AccessRevocation, middleware.Session}
+}
+
+func New(statisticsService statisticsService, logger internal.Logger) *Handler {
+	return &Handler{
+		statisticsService: statisticsService,
+		logger:            logger,
+	}
+}
@@ -0,0 +1,48 @@
+package users_me_tests_post_handler
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/ruslanonly/blindtyping/src/internal/api"
+	"github.com/ruslanonly/blindtyping/src/internal/models"
+	"github.com/ruslanonly/blindtyping/src/internal/shared/proto"
+)
+
+type RequestBody struct {
+	Mode     string `json:"mode" example:"time" description:"Режим теста"`
+	SubMode  string `json:"submode" example:"1m" description:"Параметры режима"`
+	Language string `json:"language" example:"english" description:"Язык теста"`
+} //@name UsersMeTestsPostHandler.RequestBody
+
+type ResponseBody struct {
+	TestID    string `json:"testId" example:"4e2a7d4c3b8e5f6a9b1c0f7b8c9d0e1f" description:"Идентификатор теста, передается вместе с результатом"`
+	StartedAt string `json:"startedAt" example:"2025-10-19T19:02:29+03:00" description:"Время начала теста"`
+} //@name UsersMeTestsPostHandler.ResponseBody
+
+type Request struct {
+	UserID   models.ID
+	Mode     string
+	SubMode  string
+	Language string
+}
+
+func newRequest(c *gin.Context) (*Request, error) {
+	var body RequestBody
+	if err := c.ShouldBindBodyWithJSON(&body); err != nil {
+		return nil, err
+	}
+
+	return &Request{
+		UserID:   models.ID(api.GetUserID(c)),
+		Mode:     body.Mode,
+		SubMode:  body.SubMode,
+		Language: body.Language,
+	}, nil
+}
+
+func newResponseBody(run *models.TestRun) *ResponseBody {
+	return &ResponseBody{
+		TestID:    run.ID,
+		StartedAt: proto.MarshalTime(run.StartedAt),
+	}
+}
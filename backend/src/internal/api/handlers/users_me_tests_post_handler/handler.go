@@ -0,0 +1,106 @@
+package users_me_tests_post_handler
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/ruslanonly/blindtyping/src/internal"
+	"github.com/ruslanonly/blindtyping/src/internal/api/middleware"
+	"github.com/ruslanonly/blindtyping/src/internal/models"
+	"github.com/ruslanonly/blindtyping/src/internal/services/analytics_service"
+	"github.com/ruslanonly/blindtyping/src/internal/shared/proto"
+)
+
+const handlerName = "users_me_tests_post_handler"
+
+type analyticsService interface {
+	StartTest(ctx context.Context, in *analytics_service.StartTestIn) (*models.TestRun, error)
+}
+
+type Handler struct {
+	analyticsService analyticsService
+	logger           internal.Logger
+}
+
+func (h *Handler) handleError(ctx context.Context, c *gin.Context, err error) {
+	var (
+		status  = http.StatusInternalServerError
+		message = "something went wrong serverside"
+	)
+
+	if analytics_service.IsInvalidTestError(err) {
+		status = http.StatusBadRequest
+		message = err.Error()
+	}
+
+	ctx = h.logger.WithError(h.logger.WithStatusCode(ctx, status), err)
+
+	switch status {
+	case http.StatusInternalServerError:
+		h.logger.Error(ctx)
+	default:
+		h.logger.Warning(ctx)
+	}
+
+	proto.WriteError(c, status, message)
+}
+
+// Handle godoc
+// @Summary     Начать тест
+// @Description Отмечает начало теста. Идентификатор теста передается в testId при сохранении результата. Незавершенный тест считается брошенным, когда пользователь начинает следующий или когда истекает analytics.test_timeout.
+// @Tags        User Statistics
+// @Accept      json
+// @Produce     json
+// @Security    ApiKeyAuth
+// @Param       request body RequestBody true "Параметры теста"
+// @Success     201 {object} ResponseBody "Тест начат"
+// @Failure     400 {object} proto.Error "Неверное тело запроса"
+// @Failure     401 {object} proto.Error "Пользователь не авторизован"
+// @Failure     403 {object} proto.Error "У персонального токена нет права statistics:write"
+// @Failure     500 {object} proto.Error "Внутренняя ошибка сервера (смотреть логи)"
+// @Router      /users/me/tests [post]
+func (h *Handler) Handle(c *gin.Context) {
+	ctx := h.logger.WithHandlerName(c.Request.Context(), handlerName)
+
+	req, err := newRequest(c)
+	if err != nil {
+		ctx = h.logger.WithStatusCode(ctx, http.StatusBadRequest)
+		h.logger.Warning(h.logger.WithError(ctx, err))
+		proto.WriteError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	run, err := h.analyticsService.StartTest(ctx, &analytics_service.StartTestIn{
+		UserID:   req.UserID,
+		Mode:     req.Mode,
+		SubMode:  req.SubMode,
+		Language: req.Language,
+	})
+	if err != nil {
+		h.handleError(ctx, c, err)
+		return
+	}
+
+	proto.WriteJSON(c, http.StatusCreated, newResponseBody(run))
+}
+
+func (h *Handler) Method() string {
+	return http.MethodPost
+}
+
+func (h *Handler) Path() string {
+	return "/users/me/tests"
+}
+
+func (h *Handler) Middleware() []string {
+	return []string{middleware.Auth, middleware.AccessRevocation, middleware.StatisticsWrite}
+}
+
+func New(analyticsService analyticsService, logger internal.Logger) *Handler {
+	return &Handler{
+		analyticsService: analyticsService,
+		logger:           logger,
+	}
+}
@@ -0,0 +1,67 @@
+package users_me_tokens_get_handler
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/ruslanonly/blindtyping/src/internal/api"
+	"github.com/ruslanonly/blindtyping/src/internal/models"
+	"github.com/ruslanonly/blindtyping/src/internal/shared/proto"
+)
+
+type Token struct {
+	ID         uint64   `json:"id" example:"1"`
+	Name       string   `json:"name" example:"vscode plugin"`
+	Scopes     []string `json:"scopes" example:"statistics:write"`
+	CreatedAt  string   `json:"createdAt" example:"2025-10-19T19:02:29+03:00"`
+	ExpiresAt  *string  `json:"expiresAt" example:"2026-10-19T19:02:29+03:00"`
+	LastUsedAt *string  `json:"lastUsedAt" example:"2025-10-20T19:02:29+03:00"`
+} //@name UsersMeTokensGetHandler.Token
+
+type ResponseBody struct {
+	Tokens []Token `json:"tokens"`
+} //@name UsersMeTokensGetHandler.ResponseBody
+
+type Request struct {
+	UserID models.ID
+}
+
+func newRequest(c *gin.Context) *Request {
+	return &Request{
+		UserID: models.ID(api.GetUserID(c)),
+	}
+}
+
+func newToken(token *models.PersonalToken) Token {
+	scopes := make([]string, 0, len(token.Scopes))
+	for _, scope := range token.Scopes {
+		scopes = append(scopes, string(scope))
+	}
+
+	out := Token{
+		ID:        uint64(token.ID),
+		Name:      token.Name,
+		Scopes:    scopes,
+		CreatedAt: proto.MarshalTime(token.CreatedAt),
+	}
+
+	if token.ExpiresAt != nil {
+		expiresAt := proto.MarshalTime(*token.ExpiresAt)
+		out.ExpiresAt = &expiresAt
+	}
+
+	if token.LastUsedAt != nil {
+		lastUsedAt := proto.MarshalTime(*token.LastUsedAt)
+		out.LastUsedAt = &lastUsedAt
+	}
+
+	return out
+}
+
+func newResponseBody(tokens []*models.PersonalToken) *ResponseBody {
+	out := make([]Token, 0, len(tokens))
+	for _, token := range tokens {
+		out = append(out, newToken(token))
+	}
+
+	return &ResponseBody{Tokens: out}
+}
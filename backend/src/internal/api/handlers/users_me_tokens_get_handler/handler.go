@@ -0,0 +1,70 @@
+package users_me_tokens_get_handler
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/ruslanonly/blindtyping/src/internal"
+	"github.com/ruslanonly/blindtyping/src/internal/api/middleware"
+	"github.com/ruslanonly/blindtyping/src/internal/models"
+	"github.com/ruslanonly/blindtyping/src/internal/shared/proto"
+)
+
+const handlerName = "users_me_tokens_get_handler"
+
+type personalTokenService interface {
+	List(ctx context.Context, userID models.ID) ([]*models.PersonalToken, error)
+}
+
+type Handler struct {
+	personalTokenService personalTokenService
+	logger               internal.Logger
+}
+
+// Handle godoc
+// @Summary     Список персональных токенов
+// @Description Возвращает персональные токены текущего пользователя без их значений
+// @Tags        Tokens
+// @Accept      json
+// @Produce     json
+// @Security    ApiKeyAuth
+// @Success     200 {object} ResponseBody "Токены пользователя"
+// @Failure     401 {object} proto.Error "Пользователь не авторизован"
+// @Failure     403 {object} proto.Error "Запрос выполнен персональным токеном"
+// @Failure     500 {object} proto.Error "Внутренняя ошибка сервера (смотреть логи)"
+// @Router      /users/me/tokens [get]
+func (h *Handler) Handle(c *gin.Context) {
+	ctx := h.logger.WithHandlerName(c.Request.Context(), handlerName)
+	req := newRequest(c)
+
+	tokens, err := h.personalTokenService.List(ctx, req.UserID)
+	if err != nil {
+		ctx = h.logger.WithStatusCode(ctx, http.StatusInternalServerError)
+		h.logger.Error(h.logger.WithError(ctx, err))
+		proto.WriteError(c, http.StatusInternalServerError, "something went wrong")
+		return
+	}
+
+	proto.WriteJSON(c, http.StatusOK, newResponseBody(tokens))
+}
+
+func (h *Handler) Method() string {
+	return http.MethodGet
+}
+
+func (h *Handler) Path() string {
+	return "/users/me/tokens"
+}
+
+func (h *Handler) Middleware() []string {
+	return []string{middleware.Auth, middleware.AccessRevocation, middleware.Session}
+}
+
+func New(personalTokenService personalTokenService, logger internal.Logger) *Handler {
+	return &Handler{
+		personalTokenService: personalTokenService,
+		logger:               logger,
+	}
+}
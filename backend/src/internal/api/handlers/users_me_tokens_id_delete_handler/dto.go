@@ -0,0 +1,27 @@
+package users_me_tokens_id_delete_handler
+
+import (
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/ruslanonly/blindtyping/src/internal/api"
+	"github.com/ruslanonly/blindtyping/src/internal/models"
+)
+
+type Request struct {
+	UserID  models.ID
+	TokenID models.ID
+}
+
+func newRequest(c *gin.Context) (*Request, error) {
+	tokenID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Request{
+		UserID:  models.ID(api.GetUserID(c)),
+		TokenID: models.ID(tokenID),
+	}, nil
+}
@@ -0,0 +1,100 @@
+package users_me_tokens_id_delete_handler
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/ruslanonly/blindtyping/src/internal"
+	"github.com/ruslanonly/blindtyping/src/internal/api/middleware"
+	"github.com/ruslanonly/blindtyping/src/internal/models"
+	"github.com/ruslanonly/blindtyping/src/internal/services/personal_token_service"
+	"github.com/ruslanonly/blindtyping/src/internal/shared/proto"
+)
+
+const handlerName = "users_me_tokens_id_delete_handler"
+
+type personalTokenService interface {
+	Delete(ctx context.Context, userID, id models.ID) error
+}
+
+type Handler struct {
+	personalTokenService personalTokenService
+	logger               internal.Logger
+}
+
+func (h *Handler) handleError(ctx context.Context, c *gin.Context, err error) {
+	var (
+		status  = http.StatusInternalServerError
+		message = "something went wrong serverside"
+	)
+
+	switch {
+	case personal_token_service.IsTokenNotFoundError(err):
+		status = http.StatusNotFound
+		message = err.Error()
+	}
+
+	ctx = h.logger.WithError(h.logger.WithStatusCode(ctx, status), err)
+
+	switch status {
+	case http.StatusInternalServerError:
+		h.logger.Error(ctx)
+	default:
+		h.logger.Warning(ctx)
+	}
+
+	proto.WriteError(c, status, message)
+}
+
+// Handle godoc
+// @Summary     Отозвать персональный токен
+// @Description Удаляет персональный токен, после чего им нельзя авторизоваться
+// @Tags        Tokens
+// @Accept      json
+// @Produce     json
+// @Security    ApiKeyAuth
+// @Param       id path int true "ID токена"
+// @Success     200 "Токен отозван"
+// @Failure     400 {object} proto.Error "Неверный ID токена"
+// @Failure     401 {object} proto.Error "Пользователь не авторизован"
+// @Failure     403 {object} proto.Error "Запрос выполнен персональным токеном"
+// @Failure     404 {object} proto.Error "Токен не найден"
+// @Failure     500 {object} proto.Error "Внутренняя ошибка сервера (смотреть логи)"
+// @Router      /users/me/tokens/{id} [delete]
+func (h *Handler) Handle(c *gin.Context) {
+	ctx := h.logger.WithHandlerName(c.Request.Context(), handlerName)
+
+	req, err := newRequest(c)
+	if err != nil {
+		ctx = h.logger.WithStatusCode(ctx, http.StatusBadRequest)
+		h.logger.Warning(h.logger.WithError(ctx, err))
+		proto.WriteError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	if err = h.personalTokenService.Delete(ctx, req.UserID, req.TokenID); err != nil {
+		h.handleError(ctx, c, err)
+		return
+	}
+}
+
+func (h *Handler) Method() string {
+	return http.MethodDelete
+}
+
+func (h *Handler) Path() string {
+	return "/users/me/tokens/:id"
+}
+
+func (h *Handler) Middleware() []string {
+	return []string{middleware.Auth, middleware.AccessRevocation, middleware.Session}
+}
+
+func New(personalTokenService personalTokenService, logger internal.Logger) *Handler {
+	return &Handler{
+		personalTokenService: personalTokenService,
+		logger:               logger,
+	}
+}
@@ -0,0 +1,78 @@
+package users_me_tokens_post_handler
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/ruslanonly/blindtyping/src/internal/api"
+	"github.com/ruslanonly/blindtyping/src/internal/models"
+	"github.com/ruslanonly/blindtyping/src/internal/shared/proto"
+)
+
+type RequestBody struct {
+	Name      string   `json:"name" example:"vscode plugin" description:"Название токена"`
+	Scopes    []string `json:"scopes" example:"statistics:write" description:"Права токена: statistics:read, statistics:write, profile:read"`
+	ExpiresAt *string  `json:"expiresAt" example:"2026-10-19T19:02:29+03:00" description:"Срок действия в RFC3339, без него токен бессрочный"`
+} //@name UsersMeTokensPostHandler.RequestBody
+
+type Token struct {
+	ID        uint64   `json:"id" example:"1"`
+	Name      string   `json:"name" example:"vscode plugin"`
+	Scopes    []string `json:"scopes" example:"statistics:write"`
+	CreatedAt string   `json:"createdAt" example:"2025-10-19T19:02:29+03:00"`
+	ExpiresAt *string  `json:"expiresAt" example:"2026-10-19T19:02:29+03:00"`
+} //@name UsersMeTokensPostHandler.Token
+
+type ResponseBody struct {
+	Token Token  `json:"token"`
+	Value string `json:"value" example:"bt_pat_2Jx0..." description:"Значение токена, показывается только один раз"`
+} //@name UsersMeTokensPostHandler.ResponseBody
+
+type Request struct {
+	UserID    models.ID
+	Name      string
+	Scopes    []models.Scope
+	ExpiresAt *string
+}
+
+func newRequest(c *gin.Context) (*Request, error) {
+	var body RequestBody
+	if err := c.ShouldBindBodyWithJSON(&body); err != nil {
+		return nil, err
+	}
+
+	scopes := make([]models.Scope, 0, len(body.Scopes))
+	for _, scope := range body.Scopes {
+		scopes = append(scopes, models.Scope(scope))
+	}
+
+	return &Request{
+		UserID:    models.ID(api.GetUserID(c)),
+		Name:      body.Name,
+		Scopes:    scopes,
+		ExpiresAt: body.ExpiresAt,
+	}, nil
+}
+
+func newResponseBody(token *models.PersonalToken, value string) *ResponseBody {
+	scopes := make([]string, 0, len(token.Scopes))
+	for _, scope := range token.Scopes {
+		scopes = append(scopes, string(scope))
+	}
+
+	var expiresAt *string
+	if token.ExpiresAt != nil {
+		formatted := proto.MarshalTime(*token.ExpiresAt)
+		expiresAt = &formatted
+	}
+
+	return &ResponseBody{
+		Token: Token{
+			ID:        uint64(token.ID),
+			Name:      token.Name,
+			Scopes:    scopes,
+			CreatedAt: proto.MarshalTime(token.CreatedAt),
+			ExpiresAt: expiresAt,
+		},
+		Value: value,
+	}
+}
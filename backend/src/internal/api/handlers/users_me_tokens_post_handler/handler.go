@@ -0,0 +1,135 @@
+package users_me_tokens_post_handler
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/ruslanonly/blindtyping/src/internal"
+	"github.com/ruslanonly/blindtyping/src/internal/api/middleware"
+	"github.com/ruslanonly/blindtyping/src/internal/services/personal_token_service"
+	"github.com/ruslanonly/blindtyping/src/internal/shared/proto"
+)
+
+const handlerName = "users_me_tokens_post_handler"
+
+type personalTokenService interface {
+	Create(ctx context.Context, in *personal_token_service.CreateIn) (*personal_token_service.CreateOut, error)
+}
+
+type Handler struct {
+	personalTokenService personalTokenService
+	logger               internal.Logger
+}
+
+func (h *Handler) newCreateIn(r *Request) (*personal_token_service.CreateIn, error) {
+	var expiresAt *time.Time
+	if r.ExpiresAt != nil {
+		parsed, err := proto.UnmarshalTime(*r.ExpiresAt)
+		if err != nil {
+			return nil, err
+		}
+		expiresAt = &parsed
+	}
+
+	return &personal_token_service.CreateIn{
+		UserID:    r.UserID,
+		Name:      r.Name,
+		Scopes:    r.Scopes,
+		ExpiresAt: expiresAt,
+	}, nil
+}
+
+func (h *Handler) handleError(ctx context.Context, c *gin.Context, err error) {
+	var (
+		status  = http.StatusInternalServerError
+		message = "something went wrong serverside"
+	)
+
+	switch {
+	case personal_token_service.IsInvalidScopeError(err),
+		personal_token_service.IsEmptyScopesError(err),
+		personal_token_service.IsInvalidNameError(err),
+		personal_token_service.IsInvalidExpiryError(err):
+		status = http.StatusBadRequest
+		message = err.Error()
+	case personal_token_service.IsTooManyTokensError(err):
+		status = http.StatusConflict
+		message = err.Error()
+	}
+
+	ctx = h.logger.WithError(h.logger.WithStatusCode(ctx, status), err)
+
+	switch status {
+	case http.StatusInternalServerError:
+		h.logger.Error(ctx)
+	default:
+		h.logger.Warning(ctx)
+	}
+
+	proto.WriteError(c, status, message)
+}
+
+// Handle godoc
+// @Summary     Создать персональный токен
+// @Description Создает токен для сторонних клиентов (CLI, плагины редакторов). Токен передается в заголовке Authorization: Bearer. Значение токена возвращается только один раз.
+// @Tags        Tokens
+// @Accept      json
+// @Produce     json
+// @Security    ApiKeyAuth
+// @Param       body body RequestBody true "Название, права и срок действия токена"
+// @Success     201 {object} ResponseBody "Токен создан"
+// @Failure     400 {object} proto.Error "Неверное название, права или срок действия"
+// @Failure     401 {object} proto.Error "Пользователь не авторизован"
+// @Failure     403 {object} proto.Error "Запрос выполнен персональным токеном"
+// @Failure     409 {object} proto.Error "Превышено количество токенов"
+// @Failure     500 {object} proto.Error "Внутренняя ошибка сервера (смотреть логи)"
+// @Router      /users/me/tokens [post]
+func (h *Handler) Handle(c *gin.Context) {
+	ctx := h.logger.WithHandlerName(c.Request.Context(), handlerName)
+
+	req, err := newRequest(c)
+	if err != nil {
+		ctx = h.logger.WithStatusCode(ctx, http.StatusBadRequest)
+		h.logger.Warning(h.logger.WithError(ctx, err))
+		proto.WriteError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	in, err := h.newCreateIn(req)
+	if err != nil {
+		ctx = h.logger.WithStatusCode(ctx, http.StatusBadRequest)
+		h.logger.Warning(h.logger.WithError(ctx, err))
+		proto.WriteError(c, http.StatusBadRequest, err)
+		return
+	}
+
+	out, err := h.personalTokenService.Create(ctx, in)
+	if err != nil {
+		h.handleError(ctx, c, err)
+		return
+	}
+
+	proto.WriteJSON(c, http.StatusCreated, newResponseBody(out.Token, out.Value))
+}
+
+func (h *Handler) Method() string {
+	return http.MethodPost
+}
+
+func (h *Handler) Path() string {
+	return "/users/me/tokens"
+}
+
+func (h *Handler) Middleware() []string {
+	return []string{middleware.Auth, middleware.AccessRevocation, middleware.Session}
+}
+
+func New(personalTokenService personalTokenService, logger internal.Logger) *Handler {
+	return &Handler{
+		personalTokenService: personalTokenService,
+		logger:               logger,
+	}
+}
@@ -4,12 +4,18 @@ import (
 	"github.com/gin-gonic/gin"
 
 	"github.com/ruslanonly/blindtyping/src/internal/api"
+	"github.com/ruslanonly/blindtyping/src/internal/services/username_service"
 )
 
 type RequestBody struct {
 	Username string `json:"username"`
 } //@name UserUsernamePatchHandler.RequestBody
 
+type RejectedBody struct {
+	Error       string   `json:"error" example:"username is already taken"`
+	Suggestions []string `json:"suggestions" example:"ffh42"`
+} //@name UserUsernamePatchHandler.RejectedBody
+
 type Request struct {
 	Username string
 	UserID   uint64
@@ -28,3 +34,15 @@ func newRequest(c *gin.Context) (*Request, error) {
 		UserID:   userID,
 	}, nil
 }
+
+func newRejectedBody(rejected *username_service.RejectedError) *RejectedBody {
+	suggestions := rejected.Suggestions
+	if suggestions == nil {
+		suggestions = []string{}
+	}
+
+	return &RejectedBody{
+		Error:       rejected.Error(),
+		Suggestions: suggestions,
+	}
+}
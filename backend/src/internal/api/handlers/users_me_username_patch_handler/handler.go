@@ -8,6 +8,7 @@ import (
 	"github.com/ruslanonly/blindtyping/src/internal"
 	"github.com/ruslanonly/blindtyping/src/internal/api/middleware"
 	"github.com/ruslanonly/blindtyping/src/internal/models"
+	"github.com/ruslanonly/blindtyping/src/internal/services/username_service"
 	"github.com/ruslanonly/blindtyping/src/internal/shared/proto"
 )
 
@@ -17,9 +18,14 @@ type userService interface {
 	ChangeUsername(ctx context.Context, userID uint64, username string) error
 }
 
+type analyticsTracker interface {
+	Track(ctx context.Context, event *models.AnalyticsEvent)
+}
+
 type Handler struct {
-	logger      internal.Logger
-	userService userService
+	logger           internal.Logger
+	userService      userService
+	analyticsTracker analyticsTracker
 }
 
 func (h *Handler) handleError(ctx context.Context, c *gin.Context, err error) {
@@ -29,13 +35,13 @@ func (h *Handler) handleError(ctx context.Context, c *gin.Context, err error) {
 	)
 
 	switch {
-	case models.IsValidationError(err), models.IsSameUsernameError(err):
+	case models.IsValidationError(err), username_service.IsSameUsernameError(err), username_service.IsUsernamePolicyError(err):
 		status = http.StatusBadRequest
 		message = err.Error()
-	case models.IsUsernameAlreadyTakenError(err):
+	case username_service.IsUsernameConflictError(err):
 		status = http.StatusConflict
 		message = err.Error()
-	case models.IsTooMuchUsernameChangesError(err):
+	case username_service.IsTooMuchUsernameChangesError(err):
 		status = http.StatusTooManyRequests
 		message = err.Error()
 	}
@@ -49,19 +55,25 @@ func (h *Handler) handleError(ctx context.Context, c *gin.Context, err error) {
 		h.logger.Error(ctx)
 	}
 
+	if rejected, ok := username_service.AsRejectedError(err); ok {
+		proto.WriteJSON(c, status, newRejectedBody(rejected))
+		return
+	}
+
 	proto.WriteError(c, status, message)
 }
 
 // Handle godoc
 // @Summary     Изменить имя пользователя
-// @Description Изменить имя пользователя, но не чаще чем 1 раз в 15 дней
+// @Description Изменить имя пользователя, но не чаще чем 1 раз в 15 дней. Прежнее имя какое-то время
+// @Description остается закрепленным за пользователем, и его профиль по прежнему имени указывает на новое
 // @Tags        Users
 // @Accept      json
 // @Produce     json
 // @Param       body body RequestBody true "Содержит новое имя пользователя"
 // @Success     200                      "Имя пользователя обновлено"
-// @Failure     400 {object} proto.Error "Имя пользователя отсутствует или не подходит под условия"
-// @Failure     409 {object} proto.Error "Имя пользователя занято"
+// @Failure     400 {object} RejectedBody "Имя пользователя отсутствует или не подходит под условия, в suggestions подходящие варианты"
+// @Failure     409 {object} RejectedBody "Имя пользователя или похожее на него занято или закреплено за другим пользователем"
 // @Failure     429 {object} proto.Error "Нельзя так часто изменять имя пользователя"
 // @Failure     500 {object} proto.Error "Внутренняя ошибка сервера (смотреть логи)"
 // @Router      /users/me/username [patch]
@@ -80,6 +92,12 @@ func (h *Handler) Handle(c *gin.Context) {
 		h.handleError(ctx, c, err)
 		return
 	}
+
+	userID := models.ID(req.UserID)
+	h.analyticsTracker.Track(ctx, &models.AnalyticsEvent{
+		Name:   models.AnalyticsUsernameChanged,
+		UserID: &userID,
+	})
 }
 
 func (h *Handler) Method() string {
@@ -91,12 +109,17 @@ func (h *Handler) Path() string {
 }
 
 func (h *Handler) Middleware() []string {
-	return []string{middleware.Auth}
+	return []string{middleware.Auth, middleware.AccessRevocation, middleware.Session}
 }
 
-func New(logger internal.Logger, userService userService) *Handler {
+func New(
+	logger internal.Logger,
+	userService userService,
+	analyticsTracker analyticsTracker,
+) *Handler {
 	return &Handler{
-		logger:      logger,
-		userService: userService,
+		logger:           logger,
+		userService:      userService,
+		analyticsTracker: analyticsTracker,
 	}
 }
@@ -7,6 +7,9 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/ruslanonly/blindtyping/src/internal"
+	"github.com/ruslanonly/blindtyping/src/internal/api/middleware"
+	"github.com/ruslanonly/blindtyping/src/internal/models"
+	"github.com/ruslanonly/blindtyping/src/internal/services/username_service"
 	"github.com/ruslanonly/blindtyping/src/internal/shared/proto"
 )
 
@@ -18,12 +21,14 @@ type (
 	}
 
 	ResponseBody struct {
-		Available bool `json:"available"`
+		Available   bool     `json:"available"`
+		Reason      string   `json:"reason,omitempty" example:"username is already taken"`
+		Suggestions []string `json:"suggestions,omitempty" example:"ffh42"`
 	} //@name UsersUsernameAvailabilityGetHandler.ResponseBody
 )
 
 type availabilityService interface {
-	IsUsernameAvailable(ctx context.Context, username string) (bool, error)
+	Check(ctx context.Context, username string, userID *models.ID) error
 }
 
 type Handler struct {
@@ -40,13 +45,21 @@ func (h *Handler) newRequest(c *gin.Context) (*Request, error) {
 	return &Request{Username: username}, nil
 }
 
-func (h *Handler) newResponseBody(isAvailable bool) *ResponseBody {
-	return &ResponseBody{Available: isAvailable}
+func (h *Handler) newResponseBody(rejected *username_service.RejectedError) *ResponseBody {
+	if rejected == nil {
+		return &ResponseBody{Available: true}
+	}
+
+	return &ResponseBody{
+		Available:   false,
+		Reason:      rejected.Error(),
+		Suggestions: rejected.Suggestions,
+	}
 }
 
 // Handle godoc
 // @Summary      Проверяет username на доступность для регистрации
-// @Description  Если username свободен и соответствует требованиям, то в теле ответа будет available: true, иначе available: false. В cookie должен быть живой токен регистрации (registration_token), иначе 401
+// @Description  Если username свободен и соответствует требованиям, то в теле ответа будет available: true, иначе available: false, причина в reason и свободные варианты в suggestions. Похожие на занятые имена (ffh255 и ffh2SS) тоже считаются занятыми. В cookie должен быть живой токен регистрации (registration_token), иначе 401
 // @Tags         Users
 // @Accept       json
 // @Produce      json
@@ -54,6 +67,7 @@ func (h *Handler) newResponseBody(isAvailable bool) *ResponseBody {
 // @Success      200 {object} ResponseBody
 // @Failure      400 {object} proto.Error
 // @Failure      500 {object} proto.Error
+// @Failure      429 {object} proto.Error "Too many requests"
 // @Router       /users/username-availability [get]
 func (h *Handler) Handle(c *gin.Context) {
 	ctx := h.logger.WithHandlerName(c.Request.Context(), handlerName)
@@ -66,15 +80,16 @@ func (h *Handler) Handle(c *gin.Context) {
 		return
 	}
 
-	isAvailable, err := h.availabilityService.IsUsernameAvailable(ctx, req.Username)
-	if err != nil {
+	err = h.availabilityService.Check(ctx, req.Username, nil)
+	rejected, isRejected := username_service.AsRejectedError(err)
+	if err != nil && !isRejected {
 		ctx = h.logger.WithStatusCode(ctx, http.StatusInternalServerError)
 		h.logger.Error(h.logger.WithError(ctx, err))
 		proto.WriteError(c, http.StatusInternalServerError, err)
 		return
 	}
 
-	body := h.newResponseBody(isAvailable)
+	body := h.newResponseBody(rejected)
 	c.JSON(http.StatusOK, body)
 }
 
@@ -87,7 +102,7 @@ func (h *Handler) Path() string {
 }
 
 func (h *Handler) Middleware() []string {
-	return nil
+	return []string{middleware.UsernameCheckRateLimit}
 }
 
 func New(availabilityService availabilityService, logger internal.Logger) *Handler {
@@ -5,12 +5,21 @@ import (
 
 	"github.com/gin-gonic/gin"
 
+	"github.com/ruslanonly/blindtyping/src/internal/api"
 	"github.com/ruslanonly/blindtyping/src/internal/models"
 	"github.com/ruslanonly/blindtyping/src/internal/shared/proto"
 )
 
+const (
+	sectionPersonalBests = "personalBests"
+	sectionTimePlayed    = "timePlayed"
+	sectionLanguageStats = "languageStats"
+	sectionActivity      = "activity"
+)
+
 type Request struct {
 	Username string
+	ViewerID *models.ID // nil for anonymous viewers
 }
 
 type PersonalBest struct {
@@ -31,30 +40,65 @@ type LanguageStats struct {
 
 type Profile struct {
 	Username       string          `json:"username" example:"ffh"`
+	AvatarURL      string          `json:"avatarUrl" example:"http://localhost:5001/avatars/0b7a8a1e-3f9c-4b8f-9a52-6d2b3c1e2f40.jpg"`
+	Bio            string          `json:"bio" example:"Печатаю вслепую с 2020 года"`
+	Country        string          `json:"country" example:"RU"`
+	Keyboard       string          `json:"keyboard" example:"Keychron K2"`
+	Layout         string          `json:"layout" example:"ЙЦУКЕН"`
 	JoinedAt       string          `json:"joinedAt" example:"2020-01-01T00:00:00Z"`
 	CompletedTests uint64          `json:"completedTests" example:"1"`
 	StartedTests   uint64          `json:"startedTests" example:"1"`
 	TimePlayed     int64           `json:"timePlayed" example:"1000"`
 	PersonalBests  []PersonalBest  `json:"personalBests"`
 	LanguageStats  []LanguageStats `json:"languageStats"`
+	HiddenSections []string        `json:"hiddenSections" example:"personalBests,activity"`
 } //@name UsersUsernameProfileGetHandler.Profile
 
 type ResponseBody struct {
 	Profile Profile `json:"profile"`
 } //@name UsersUsernameProfileGetHandler.ResponseBody
 
+// RedirectBody is returned with 307 when the requested username is a previous
+// username of the user.
+type RedirectBody struct {
+	Username string `json:"username" example:"ffh255"`
+} //@name UsersUsernameProfileGetHandler.RedirectBody
+
 func newRequest(c *gin.Context) (*Request, error) {
 	username := c.Param("username")
 	if username == "" {
 		return nil, errors.New("username is empty")
 	}
 
-	return &Request{
+	request := &Request{
 		Username: username,
-	}, nil
+	}
+	if api.IsAuthenticated(c) {
+		viewerID := models.ID(api.GetUserID(c))
+		request.ViewerID = &viewerID
+	}
+
+	return request, nil
 }
 
-func newProfile(profile *models.Profile) *Profile {
+// newProfile lists the sections hidden from the viewer in HiddenSections, the
+// profile comes without them already.
+func newProfile(profile *models.Profile, details *models.ProfileDetails, privacy *models.ProfilePrivacy) *Profile {
+	hiddenSections := make([]string, 0, 4)
+
+	if privacy.HidePersonalBests {
+		hiddenSections = append(hiddenSections, sectionPersonalBests)
+	}
+	if privacy.HideLanguageStats {
+		hiddenSections = append(hiddenSections, sectionLanguageStats)
+	}
+	if privacy.HideTimePlayed {
+		hiddenSections = append(hiddenSections, sectionTimePlayed)
+	}
+	if privacy.HideActivity {
+		hiddenSections = append(hiddenSections, sectionActivity)
+	}
+
 	personalBests := make([]PersonalBest, 0, len(profile.PersonalBests))
 	for _, personalBest := range profile.PersonalBests {
 		personalBests = append(personalBests, newPersonalBest(&personalBest))
@@ -67,12 +111,18 @@ func newProfile(profile *models.Profile) *Profile {
 
 	return &Profile{
 		Username:       profile.Username,
+		AvatarURL:      details.AvatarURL,
+		Bio:            details.Bio,
+		Country:        details.Country,
+		Keyboard:       details.Keyboard,
+		Layout:         details.Layout,
 		JoinedAt:       proto.MarshalTime(profile.JoinedAt),
 		StartedTests:   profile.StartedTests,
 		CompletedTests: profile.CompletedTests,
 		TimePlayed:     profile.TimePlayed.Milliseconds(),
 		PersonalBests:  personalBests,
 		LanguageStats:  languageStats,
+		HiddenSections: hiddenSections,
 	}
 }
 
@@ -96,8 +146,8 @@ func newPersonalBest(personalBest *models.PersonalBest) PersonalBest {
 	}
 }
 
-func newResponseBody(profile *models.Profile) *ResponseBody {
+func newResponseBody(profile *models.Profile, details *models.ProfileDetails, privacy *models.ProfilePrivacy) *ResponseBody {
 	return &ResponseBody{
-		Profile: *newProfile(profile),
+		Profile: *newProfile(profile, details, privacy),
 	}
 }
@@ -3,30 +3,38 @@ package users_username_profile_get_handler
 import (
 	"context"
 	"net/http"
+	"net/url"
 
 	"github.com/gin-gonic/gin"
 
 	"github.com/ruslanonly/blindtyping/src/internal"
+	"github.com/ruslanonly/blindtyping/src/internal/api/middleware"
 	"github.com/ruslanonly/blindtyping/src/internal/models"
+	"github.com/ruslanonly/blindtyping/src/internal/services/profile_details_service"
+	"github.com/ruslanonly/blindtyping/src/internal/services/profile_privacy_service"
 	"github.com/ruslanonly/blindtyping/src/internal/services/profile_service"
 	"github.com/ruslanonly/blindtyping/src/internal/shared/proto"
 )
 
 const handlerName = "users_username_profile_get_handler"
 
-type profileGetter interface {
-	Get(ctx context.Context, in *profile_service.GetIn) (*models.Profile, error)
+type profileViewer interface {
+	GetProfile(ctx context.Context, username string, viewerID *models.ID) (*models.Profile, *models.ProfilePrivacy, error)
 }
 
-type Handler struct {
-	profileGetter profileGetter
-	logger        internal.Logger
+type renameResolver interface {
+	ResolveRenamed(ctx context.Context, username string) (string, error)
 }
 
-func (h *Handler) newProfileServiceGetIn(request *Request) *profile_service.GetIn {
-	return &profile_service.GetIn{
-		Username: request.Username,
-	}
+type detailsGetter interface {
+	GetByUsername(ctx context.Context, username string) (*models.ProfileDetails, error)
+}
+
+type Handler struct {
+	profileViewer  profileViewer
+	detailsGetter  detailsGetter
+	renameResolver renameResolver
+	logger         internal.Logger
 }
 
 func (h *Handler) handleError(ctx context.Context, c *gin.Context, err error) {
@@ -36,9 +44,14 @@ func (h *Handler) handleError(ctx context.Context, c *gin.Context, err error) {
 	)
 
 	switch {
-	case profile_service.IsUserNotFoundError(err):
+	case profile_service.IsUserNotFoundError(err),
+		profile_details_service.IsUserNotFoundError(err),
+		profile_privacy_service.IsUserNotFoundError(err):
 		status = http.StatusNotFound
 		message = "user not found"
+	case profile_privacy_service.IsProfileHiddenError(err):
+		status = http.StatusForbidden
+		message = err.Error()
 	}
 
 	ctx = h.logger.WithError(h.logger.WithStatusCode(ctx, status), err)
@@ -55,14 +68,16 @@ func (h *Handler) handleError(ctx context.Context, c *gin.Context, err error) {
 
 // Handle godoc
 // @Summary Gets user's profile
-// @Description Gets profile of any user by username
+// @Description Gets profile of any user by username. Private profiles are shown to their owner only,
+// @Description sections hidden by the owner are empty and listed in hiddenSections
 // @Tags Profile
 // @Accept json
 // @Produce json
 // @Param username path string true "Username of user for profile fetching"
 // @Success 200 {object} ResponseBody "User's profile"
+// @Success 307 {object} RedirectBody "The username was renamed, Location points to the current profile"
 // @Failure 400 {object} proto.Error "Invalid request body"
-// @Failure 401 {object} proto.Error "Unauthorized"
+// @Failure 403 {object} proto.Error "Profile is private"
 // @Failure 404 {object} proto.Error "User not found"
 // @Failure 500 {object} proto.Error "Internal server error"
 // @Router /users/{username}/profile [get]
@@ -77,13 +92,41 @@ func (h *Handler) Handle(c *gin.Context) {
 		return
 	}
 
-	profile, err := h.profileGetter.Get(ctx, h.newProfileServiceGetIn(request))
+	// A previous username is resolved before the profile cache is read, so
+	// the old name never serves a stale cached profile.
+	current, err := h.renameResolver.ResolveRenamed(ctx, request.Username)
+	if err != nil {
+		h.handleError(ctx, c, err)
+		return
+	}
+
+	if current != "" {
+		c.Header("Location", "/users/"+url.PathEscape(current)+"/profile")
+		c.JSON(http.StatusTemporaryRedirect, RedirectBody{Username: current})
+		return
+	}
+
+	// The profile comes already cut down to what the viewer may see
+	profile, privacy, err := h.profileViewer.GetProfile(ctx, request.Username, request.ViewerID)
+	if err != nil {
+		h.handleError(ctx, c, err)
+		return
+	}
+
+	// Details are not cached with the rest of the profile, so edits show up at once.
+	details, err := h.detailsGetter.GetByUsername(ctx, request.Username)
 	if err != nil {
 		h.handleError(ctx, c, err)
 		return
 	}
 
-	body := newResponseBody(profile)
+	// The response depends on the viewer, so shared caches must not keep it.
+	c.Header("Cache-Control", "private, no-store")
+	if privacy.Visibility == models.ProfileUnlisted {
+		c.Header("X-Robots-Tag", "noindex")
+	}
+
+	body := newResponseBody(profile, details, privacy)
 	c.JSON(http.StatusOK, body)
 }
 
@@ -96,12 +139,19 @@ func (h *Handler) Path() string {
 }
 
 func (h *Handler) Middleware() []string {
-	return nil
+	return []string{middleware.OptionalAuth}
 }
 
-func New(profileGetter profileGetter, logger internal.Logger) *Handler {
+func New(
+	profileViewer profileViewer,
+	detailsGetter detailsGetter,
+	renameResolver renameResolver,
+	logger internal.Logger,
+) *Handler {
 	return &Handler{
-		profileGetter: profileGetter,
-		logger:        logger,
+		profileViewer:  profileViewer,
+		detailsGetter:  detailsGetter,
+		renameResolver: renameResolver,
+		logger:         logger,
 	}
 }
@@ -0,0 +1,3 @@
+package middleware
+
+const AccessRevocation = "access_revocation"
@@ -0,0 +1,38 @@
+package audit_middleware
+
+import (
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/ruslanonly/blindtyping/src/internal/api"
+	"github.com/ruslanonly/blindtyping/src/internal/services/audit_service"
+	"github.com/ruslanonly/blindtyping/src/internal/shared/logger"
+)
+
+const userAgentHeader = "User-Agent"
+
+// New puts the client IP, user agent and request id into the request context,
+// so audit events recorded while handling the request tell where it came from.
+// It has to run after the request id and tracing middleware. Requests without a
+// request id are identified by their trace id.
+func New() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := c.Request.Context()
+
+		requestID := logger.RequestID(ctx)
+		if requestID == "" {
+			if spanContext := trace.SpanContextFromContext(ctx); spanContext.IsValid() {
+				requestID = spanContext.TraceID().String()
+			}
+		}
+
+		ctx = audit_service.WithRequest(ctx, &audit_service.Request{
+			IP:        api.GetClientIP(c),
+			UserAgent: c.GetHeader(userAgentHeader),
+			RequestID: requestID,
+		})
+		c.Request = c.Request.WithContext(ctx)
+
+		c.Next()
+	}
+}
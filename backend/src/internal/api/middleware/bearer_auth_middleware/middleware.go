@@ -0,0 +1,97 @@
+package bearer_auth_middleware
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/ruslanonly/blindtyping/src/internal"
+	"github.com/ruslanonly/blindtyping/src/internal/api"
+	"github.com/ruslanonly/blindtyping/src/internal/models"
+	"github.com/ruslanonly/blindtyping/src/internal/services/personal_token_service"
+	"github.com/ruslanonly/blindtyping/src/internal/shared/proto"
+)
+
+const (
+	middlewareName = "bearer_auth_middleware"
+
+	authorizationHeader = "Authorization"
+	bearerPrefix        = "Bearer "
+)
+
+type personalTokenAuthenticator interface {
+	Authenticate(ctx context.Context, value string) (*models.PersonalToken, error)
+}
+
+// Middleware extends the cookie auth middleware it wraps: requests carrying a
+// personal token in the Authorization header are authenticated by that token,
+// every other request is passed to the wrapped middleware unchanged.
+type Middleware struct {
+	next          proto.Middleware
+	authenticator personalTokenAuthenticator
+	logger        internal.Logger
+}
+
+func New(next proto.Middleware, authenticator personalTokenAuthenticator, logger internal.Logger) *Middleware {
+	return &Middleware{
+		next:          next,
+		authenticator: authenticator,
+		logger:        logger,
+	}
+}
+
+func bearerToken(c *gin.Context) (string, bool) {
+	header := c.GetHeader(authorizationHeader)
+	if !strings.HasPrefix(header, bearerPrefix) {
+		return "", false
+	}
+
+	token := strings.TrimSpace(strings.TrimPrefix(header, bearerPrefix))
+	if !strings.HasPrefix(token, personal_token_service.TokenPrefix) {
+		return "", false
+	}
+
+	return token, true
+}
+
+func (m *Middleware) Handle(c *gin.Context) {
+	value, ok := bearerToken(c)
+	if !ok {
+		m.next.Handle(c)
+		return
+	}
+
+	ctx := m.logger.WithHandlerName(c.Request.Context(), middlewareName)
+
+	token, err := m.authenticator.Authenticate(ctx, value)
+	if err != nil {
+		status := http.StatusInternalServerError
+		message := "something went wrong"
+		if personal_token_service.IsInvalidTokenError(err) || personal_token_service.IsTokenExpiredError(err) {
+			status = http.StatusUnauthorized
+			message = err.Error()
+		}
+
+		ctx = m.logger.WithError(m.logger.WithStatusCode(ctx, status), err)
+		if status == http.StatusUnauthorized {
+			m.logger.Warning(ctx)
+		} else {
+			m.logger.Error(ctx)
+		}
+
+		proto.WriteError(c, status, message)
+		c.Abort()
+		return
+	}
+
+	api.SetUserID(c, uint64(token.UserID))
+	api.SetPersonalTokenScopes(c, token.Scopes)
+
+	c.Next()
+}
+
+func (m *Middleware) Name() string {
+	return m.next.Name()
+}
@@ -0,0 +1,79 @@
+package client_ip_middleware
+
+import (
+	"net/netip"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/ruslanonly/blindtyping/src/internal/api"
+)
+
+const forwardedForHeader = "X-Forwarded-For"
+
+// parsePrefix accepts a single address or a subnet in CIDR notation.
+func parsePrefix(value string) (netip.Prefix, error) {
+	if strings.Contains(value, "/") {
+		return netip.ParsePrefix(value)
+	}
+
+	addr, err := netip.ParseAddr(value)
+	if err != nil {
+		return netip.Prefix{}, err
+	}
+	return netip.PrefixFrom(addr, addr.BitLen()), nil
+}
+
+func isTrusted(proxies []netip.Prefix, addr netip.Addr) bool {
+	for _, proxy := range proxies {
+		if proxy.Contains(addr.Unmap()) {
+			return true
+		}
+	}
+	return false
+}
+
+// clientIP walks X-Forwarded-For from the right and returns the first address
+// that is not a trusted proxy. The header is only read when the connection
+// itself comes from a trusted proxy, otherwise anyone could set it.
+func clientIP(proxies []netip.Prefix, c *gin.Context) string {
+	remote, err := netip.ParseAddr(c.RemoteIP())
+	if err != nil || !isTrusted(proxies, remote) {
+		return c.RemoteIP()
+	}
+
+	client := remote
+	hops := strings.Split(strings.Join(c.Request.Header.Values(forwardedForHeader), ","), ",")
+	for i := len(hops) - 1; i >= 0; i-- {
+		hop, err := netip.ParseAddr(strings.TrimSpace(hops[i]))
+		if err != nil {
+			break
+		}
+
+		client = hop
+		if !isTrusted(proxies, hop) {
+			break
+		}
+	}
+
+	return client.Unmap().String()
+}
+
+// New resolves the client address for every request, trusting X-Forwarded-For
+// only as far as it was appended by trustedProxies. Addresses must be valid,
+// they are checked when the config is loaded.
+func New(trustedProxies []string) gin.HandlerFunc {
+	proxies := make([]netip.Prefix, 0, len(trustedProxies))
+	for _, value := range trustedProxies {
+		proxy, err := parsePrefix(value)
+		if err != nil {
+			panic(err)
+		}
+		proxies = append(proxies, proxy)
+	}
+
+	return func(c *gin.Context) {
+		api.SetClientIP(c, clientIP(proxies, c))
+		c.Next()
+	}
+}
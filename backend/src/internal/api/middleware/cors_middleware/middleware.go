@@ -0,0 +1,40 @@
+package cors_middleware
+
+import (
+	"sync/atomic"
+
+	"github.com/gin-contrib/cors"
+	"github.com/gin-gonic/gin"
+)
+
+// Middleware applies the CORS policy. The policy can be replaced while the
+// server is running, e.g. to allow a new frontend origin.
+type Middleware struct {
+	handler atomic.Pointer[gin.HandlerFunc]
+}
+
+func New(cfg cors.Config) *Middleware {
+	m := &Middleware{}
+	if err := m.SetConfig(cfg); err != nil {
+		panic(err)
+	}
+
+	return m
+}
+
+// SetConfig replaces the policy. An invalid config is rejected and the current
+// policy is kept.
+func (m *Middleware) SetConfig(cfg cors.Config) error {
+	if err := cfg.Validate(); err != nil {
+		return err
+	}
+
+	handler := cors.New(cfg)
+	m.handler.Store(&handler)
+
+	return nil
+}
+
+func (m *Middleware) Handle(c *gin.Context) {
+	(*m.handler.Load())(c)
+}
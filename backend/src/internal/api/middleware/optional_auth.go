@@ -0,0 +1,5 @@
+package middleware
+
+// OptionalAuth authenticates the request when it carries credentials and lets
+// anonymous requests and requests with invalid credentials through anonymously.
+const OptionalAuth = "optional_auth"
@@ -0,0 +1,91 @@
+package optional_auth_middleware
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/ruslanonly/blindtyping/src/internal"
+	"github.com/ruslanonly/blindtyping/src/internal/api"
+	"github.com/ruslanonly/blindtyping/src/internal/api/middleware"
+	"github.com/ruslanonly/blindtyping/src/internal/shared/proto"
+)
+
+const middlewareName = "optional_auth_middleware"
+
+type probeKey struct{}
+
+// probe is what the wrapped middlewares left on the request when all of them
+// passed. request is nil if one of them rejected it.
+type probe struct {
+	keys    map[string]any
+	request *http.Request
+}
+
+// discardWriter drops the responses of the wrapped middlewares.
+type discardWriter struct {
+	header http.Header
+}
+
+func (w discardWriter) Header() http.Header         { return w.header }
+func (w discardWriter) Write(b []byte) (int, error) { return len(b), nil }
+func (w discardWriter) WriteHeader(int)             {}
+
+// Middleware runs the auth middlewares it wraps on a detached copy of the
+// request. The copy is served by a private gin engine that has nothing but
+// those middlewares, and its response is discarded, so their rejections are
+// never written to the client. When all of them pass, the values they set are
+// copied to the request and it is marked with api.SetAuthenticated, otherwise
+// the request goes on anonymously. Cookies the wrapped middlewares set are
+// dropped.
+type Middleware struct {
+	engine *gin.Engine
+	logger internal.Logger
+}
+
+func New(logger internal.Logger, next ...proto.Middleware) *Middleware {
+	handlers := make([]gin.HandlerFunc, 0, len(next)+1)
+	for _, middleware := range next {
+		handlers = append(handlers, middleware.Handle)
+	}
+	handlers = append(handlers, func(c *gin.Context) {
+		result := c.Request.Context().Value(probeKey{}).(*probe)
+		result.keys = c.Keys
+		result.request = c.Request
+	})
+
+	// The engine has no routes, every request goes through NoRoute
+	engine := gin.New()
+	engine.NoRoute(handlers...)
+
+	return &Middleware{
+		engine: engine,
+		logger: logger,
+	}
+}
+
+func (m *Middleware) Handle(c *gin.Context) {
+	result := &probe{}
+	request := c.Request.WithContext(context.WithValue(c.Request.Context(), probeKey{}, result))
+	m.engine.ServeHTTP(discardWriter{header: make(http.Header)}, request)
+
+	if result.request == nil {
+		ctx := m.logger.WithHandlerName(c.Request.Context(), middlewareName)
+		m.logger.Debug(m.logger.WithMsg(ctx, "credentials rejected, going on anonymously"))
+		c.Next()
+		return
+	}
+
+	for key, value := range result.keys {
+		c.Set(key, value)
+	}
+	c.Request = result.request
+	api.SetAuthenticated(c)
+
+	c.Next()
+}
+
+func (m *Middleware) Name() string {
+	return middleware.OptionalAuth
+}
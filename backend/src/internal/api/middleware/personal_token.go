@@ -0,0 +1,10 @@
+package middleware
+
+const (
+	// Session rejects requests authenticated by a personal token
+	Session = "session"
+
+	StatisticsRead  = "scope_statistics_read"
+	StatisticsWrite = "scope_statistics_write"
+	ProfileRead     = "scope_profile_read"
+)
@@ -0,0 +1,9 @@
+package middleware
+
+// Rate limits are backed by redis, so they hold across every instance
+const (
+	StatisticsWriteRateLimit = "rate_limit_statistics_write"
+	UsernameCheckRateLimit   = "rate_limit_username_check"
+	OAuthBeginRateLimit      = "rate_limit_oauth_begin"
+	AvatarUploadRateLimit    = "rate_limit_avatar_upload"
+)
@@ -0,0 +1,117 @@
+package rate_limit_middleware
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/ruslanonly/blindtyping/src/internal"
+	"github.com/ruslanonly/blindtyping/src/internal/api"
+	"github.com/ruslanonly/blindtyping/src/internal/shared/proto"
+)
+
+const middlewareName = "rate_limit_middleware"
+
+// By tells what requests share a limit.
+type By string
+
+const (
+	ByUser  By = "user" // Anonymous requests are limited by IP
+	ByIP    By = "ip"
+	ByRoute By = "route"
+)
+
+type rateLimiter interface {
+	Hit(ctx context.Context, key string, period time.Duration) (int64, time.Duration, error)
+}
+
+type window struct {
+	limit  int64
+	period time.Duration
+}
+
+// Middleware allows at most limit requests per period to every route it is
+// declared on. Counters are kept in redis, so the limit holds across every
+// instance. When redis is unavailable requests pass through, since losing the
+// limit for a while is better than rejecting every request.
+type Middleware struct {
+	name        string
+	by          By
+	rateLimiter rateLimiter
+	logger      internal.Logger
+	window      atomic.Pointer[window]
+}
+
+func New(
+	name string,
+	by By,
+	rateLimiter rateLimiter,
+	logger internal.Logger,
+	limit int64,
+	period time.Duration,
+) *Middleware {
+	m := &Middleware{
+		name:        name,
+		by:          by,
+		rateLimiter: rateLimiter,
+		logger:      logger,
+	}
+	m.SetLimit(limit, period)
+
+	return m
+}
+
+// SetLimit changes the limit for the following requests. Counters of the
+// current period are kept.
+func (m *Middleware) SetLimit(limit int64, period time.Duration) {
+	m.window.Store(&window{limit: limit, period: period})
+}
+
+func (m *Middleware) key(c *gin.Context) string {
+	route := m.name + ":" + c.Request.Method + ":" + c.FullPath()
+
+	switch m.by {
+	case ByUser:
+		if userID := api.GetUserID(c); userID != 0 {
+			return fmt.Sprintf("%s:user:%d", route, userID)
+		}
+		return route + ":ip:" + api.GetClientIP(c)
+	case ByIP:
+		return route + ":ip:" + api.GetClientIP(c)
+	default:
+		return route
+	}
+}
+
+func (m *Middleware) Handle(c *gin.Context) {
+	ctx := m.logger.WithHandlerName(c.Request.Context(), middlewareName)
+	ctx = m.logger.WithField(ctx, "rate_limit", m.name)
+
+	w := m.window.Load()
+
+	count, reset, err := m.rateLimiter.Hit(ctx, m.key(c), w.period)
+	if err != nil {
+		m.logger.Error(m.logger.WithError(ctx, err))
+		c.Next()
+		return
+	}
+
+	api.SetRateLimitHeaders(c, w.limit, count, reset)
+
+	if count > w.limit {
+		m.logger.Warning(m.logger.WithStatusCode(ctx, http.StatusTooManyRequests))
+		proto.WriteError(c, http.StatusTooManyRequests, "too many requests")
+		c.Abort()
+		return
+	}
+
+	c.Next()
+}
+
+func (m *Middleware) Name() string {
+	return m.name
+}
@@ -0,0 +1,6 @@
+package middleware
+
+const (
+	Moderator = "moderator"
+	Admin     = "admin"
+)
@@ -0,0 +1,64 @@
+package role_middleware
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/ruslanonly/blindtyping/src/internal"
+	"github.com/ruslanonly/blindtyping/src/internal/api"
+	"github.com/ruslanonly/blindtyping/src/internal/models"
+	"github.com/ruslanonly/blindtyping/src/internal/shared/proto"
+)
+
+const middlewareName = "role_middleware"
+
+type roleGetter interface {
+	GetRole(ctx context.Context, id models.ID) (models.Role, error)
+}
+
+// Middleware requires the user to have at least the given role. The role is
+// read from the database on every request, so a role change applies at once.
+type Middleware struct {
+	name       string
+	role       models.Role
+	roleGetter roleGetter
+	logger     internal.Logger
+}
+
+func New(name string, role models.Role, roleGetter roleGetter, logger internal.Logger) *Middleware {
+	return &Middleware{
+		name:       name,
+		role:       role,
+		roleGetter: roleGetter,
+		logger:     logger,
+	}
+}
+
+func (m *Middleware) Handle(c *gin.Context) {
+	ctx := m.logger.WithHandlerName(c.Request.Context(), middlewareName)
+
+	role, err := m.roleGetter.GetRole(ctx, models.ID(api.GetUserID(c)))
+	if err != nil {
+		ctx = m.logger.WithStatusCode(ctx, http.StatusInternalServerError)
+		m.logger.Error(m.logger.WithError(ctx, err))
+		proto.WriteError(c, http.StatusInternalServerError, "something went wrong")
+		c.Abort()
+		return
+	}
+
+	if !role.AtLeast(m.role) {
+		ctx = m.logger.WithUserID(m.logger.WithStatusCode(ctx, http.StatusForbidden), int64(api.GetUserID(c)))
+		m.logger.Warning(m.logger.WithMsg(ctx, "insufficient role"))
+		proto.WriteError(c, http.StatusForbidden, "forbidden")
+		c.Abort()
+		return
+	}
+
+	c.Next()
+}
+
+func (m *Middleware) Name() string {
+	return m.name
+}
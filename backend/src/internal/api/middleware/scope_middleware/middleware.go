@@ -0,0 +1,55 @@
+package scope_middleware
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/ruslanonly/blindtyping/src/internal"
+	"github.com/ruslanonly/blindtyping/src/internal/api"
+	"github.com/ruslanonly/blindtyping/src/internal/models"
+	"github.com/ruslanonly/blindtyping/src/internal/shared/proto"
+)
+
+const middlewareName = "scope_middleware"
+
+// Middleware requires a personal token to have the scope. Session cookie
+// requests have every scope and pass through.
+type Middleware struct {
+	name   string
+	scope  models.Scope
+	logger internal.Logger
+}
+
+func New(name string, scope models.Scope, logger internal.Logger) *Middleware {
+	return &Middleware{
+		name:   name,
+		scope:  scope,
+		logger: logger,
+	}
+}
+
+func (m *Middleware) Handle(c *gin.Context) {
+	scopes, ok := api.GetPersonalTokenScopes(c)
+	if !ok {
+		c.Next()
+		return
+	}
+
+	for _, scope := range scopes {
+		if scope == m.scope {
+			c.Next()
+			return
+		}
+	}
+
+	ctx := m.logger.WithHandlerName(c.Request.Context(), middlewareName)
+	ctx = m.logger.WithField(m.logger.WithStatusCode(ctx, http.StatusForbidden), "scope", string(m.scope))
+	m.logger.Warning(m.logger.WithMsg(ctx, "personal token has no required scope"))
+	proto.WriteError(c, http.StatusForbidden, "token has no "+string(m.scope)+" scope")
+	c.Abort()
+}
+
+func (m *Middleware) Name() string {
+	return m.name
+}
@@ -0,0 +1,4 @@
+package middleware
+
+// Service authenticates internal callers by HMAC-signed request headers
+const Service = "service"
@@ -0,0 +1,141 @@
+package service_auth_middleware
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/ruslanonly/blindtyping/src/internal"
+	"github.com/ruslanonly/blindtyping/src/internal/api"
+	"github.com/ruslanonly/blindtyping/src/internal/api/middleware"
+	"github.com/ruslanonly/blindtyping/src/internal/models"
+	"github.com/ruslanonly/blindtyping/src/internal/services/service_client_service"
+	"github.com/ruslanonly/blindtyping/src/internal/shared/proto"
+)
+
+const (
+	middlewareName = "service_auth_middleware"
+
+	clientHeader    = "X-Service-Client"
+	timestampHeader = "X-Service-Timestamp"
+	signatureHeader = "X-Service-Signature"
+)
+
+type authenticator interface {
+	Authenticate(ctx context.Context, in *service_client_service.AuthenticateIn) (*models.ServiceClient, error)
+}
+
+type rateLimiter interface {
+	Hit(ctx context.Context, key string, period time.Duration) (int64, time.Duration, error)
+}
+
+// Middleware authenticates internal callers by HMAC-signed headers and limits
+// how often each caller may hit each endpoint. The limit is kept in redis, so it
+// is shared by every instance. The body is read before the caller is known,
+// so it is limited to maxBodySize.
+type Middleware struct {
+	authenticator authenticator
+	rateLimiter   rateLimiter
+	logger        internal.Logger
+	limit         int64
+	period        time.Duration
+	maxBodySize   int64
+}
+
+func New(
+	authenticator authenticator,
+	rateLimiter rateLimiter,
+	logger internal.Logger,
+	limit int64,
+	period time.Duration,
+	maxBodySize int64,
+) *Middleware {
+	return &Middleware{
+		authenticator: authenticator,
+		rateLimiter:   rateLimiter,
+		logger:        logger,
+		limit:         limit,
+		period:        period,
+		maxBodySize:   maxBodySize,
+	}
+}
+
+func (m *Middleware) abort(ctx context.Context, c *gin.Context, status int, message string, err error) {
+	ctx = m.logger.WithError(m.logger.WithStatusCode(ctx, status), err)
+
+	switch status {
+	case http.StatusInternalServerError:
+		m.logger.Error(ctx)
+	default:
+		m.logger.Warning(ctx)
+	}
+
+	proto.WriteError(c, status, message)
+	c.Abort()
+}
+
+func (m *Middleware) Handle(c *gin.Context) {
+	ctx := m.logger.WithHandlerName(c.Request.Context(), middlewareName)
+
+	body, err := io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, m.maxBodySize))
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			m.abort(ctx, c, http.StatusRequestEntityTooLarge, "request body too large", err)
+			return
+		}
+
+		m.abort(ctx, c, http.StatusBadRequest, "can not read request body", err)
+		return
+	}
+	c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+	client, err := m.authenticator.Authenticate(ctx, &service_client_service.AuthenticateIn{
+		ClientName: c.GetHeader(clientHeader),
+		Timestamp:  c.GetHeader(timestampHeader),
+		Signature:  c.GetHeader(signatureHeader),
+		Method:     c.Request.Method,
+		Path:       c.Request.URL.RequestURI(),
+		Body:       body,
+	})
+	if err != nil {
+		switch {
+		case service_client_service.IsInvalidSignatureError(err),
+			service_client_service.IsRequestExpiredError(err),
+			service_client_service.IsRequestReplayedError(err):
+			m.abort(ctx, c, http.StatusUnauthorized, err.Error(), err)
+		default:
+			m.abort(ctx, c, http.StatusInternalServerError, "something went wrong", err)
+		}
+		return
+	}
+
+	ctx = m.logger.WithField(ctx, "service_client", client.Name)
+
+	count, reset, err := m.rateLimiter.Hit(ctx, client.Name+":"+c.FullPath(), m.period)
+	if err != nil {
+		m.abort(ctx, c, http.StatusInternalServerError, "something went wrong", err)
+		return
+	}
+
+	api.SetRateLimitHeaders(c, m.limit, count, reset)
+	if count > m.limit {
+		m.logger.Warning(m.logger.WithStatusCode(ctx, http.StatusTooManyRequests))
+		proto.WriteError(c, http.StatusTooManyRequests, "too many requests")
+		c.Abort()
+		return
+	}
+
+	api.SetServiceClient(c, client.Name)
+
+	c.Next()
+}
+
+func (m *Middleware) Name() string {
+	return middleware.Service
+}
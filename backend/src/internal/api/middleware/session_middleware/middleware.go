@@ -0,0 +1,40 @@
+package session_middleware
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/ruslanonly/blindtyping/src/internal"
+	"github.com/ruslanonly/blindtyping/src/internal/api"
+	"github.com/ruslanonly/blindtyping/src/internal/api/middleware"
+	"github.com/ruslanonly/blindtyping/src/internal/shared/proto"
+)
+
+const middlewareName = "session_middleware"
+
+// Middleware restricts a handler to browser sessions, so personal tokens
+// can not manage the account they belong to.
+type Middleware struct {
+	logger internal.Logger
+}
+
+func New(logger internal.Logger) *Middleware {
+	return &Middleware{logger: logger}
+}
+
+func (m *Middleware) Handle(c *gin.Context) {
+	if !api.IsPersonalTokenRequest(c) {
+		c.Next()
+		return
+	}
+
+	ctx := m.logger.WithHandlerName(c.Request.Context(), middlewareName)
+	m.logger.Warning(m.logger.WithMsg(m.logger.WithStatusCode(ctx, http.StatusForbidden), "personal token used for session only handler"))
+	proto.WriteError(c, http.StatusForbidden, "personal tokens are not allowed here")
+	c.Abort()
+}
+
+func (m *Middleware) Name() string {
+	return middleware.Session
+}
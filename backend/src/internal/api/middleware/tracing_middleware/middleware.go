@@ -0,0 +1,58 @@
+package tracing_middleware
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/ruslanonly/blindtyping/src/internal"
+	"github.com/ruslanonly/blindtyping/src/internal/api"
+)
+
+const unmatchedRoute = "unmatched"
+
+// New starts a server span for every request, continuing the trace of the
+// caller if it sent one. Trace and span ids are added to the logger context, so
+// every log line of the request can be found by its trace.
+func New(tracer trace.Tracer, propagator propagation.TextMapPropagator, logger internal.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		route := c.FullPath()
+		if route == "" {
+			route = unmatchedRoute
+		}
+
+		ctx := propagator.Extract(c.Request.Context(), propagation.HeaderCarrier(c.Request.Header))
+		ctx, span := tracer.Start(ctx, c.Request.Method+" "+route,
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(
+				attribute.String("http.request.method", c.Request.Method),
+				attribute.String("http.route", route),
+				attribute.String("url.path", c.Request.URL.Path),
+				attribute.String("client.address", api.GetClientIP(c)),
+			),
+		)
+		defer span.End()
+
+		spanContext := span.SpanContext()
+		if spanContext.IsValid() {
+			ctx = logger.WithFields(ctx, map[string]any{
+				"trace_id": spanContext.TraceID().String(),
+				"span_id":  spanContext.SpanID().String(),
+			})
+		}
+
+		c.Request = c.Request.WithContext(ctx)
+
+		c.Next()
+
+		status := c.Writer.Status()
+		span.SetAttributes(attribute.Int("http.response.status_code", status))
+		if status >= http.StatusInternalServerError {
+			span.SetStatus(codes.Error, http.StatusText(status))
+		}
+	}
+}
@@ -0,0 +1,15 @@
+package api
+
+import "github.com/gin-gonic/gin"
+
+const authenticatedKey = "authenticated"
+
+// SetAuthenticated marks a request passed through middleware.OptionalAuth as
+// authenticated, so the user ID it carries can be trusted.
+func SetAuthenticated(c *gin.Context) {
+	c.Set(authenticatedKey, true)
+}
+
+func IsAuthenticated(c *gin.Context) bool {
+	return c.GetBool(authenticatedKey)
+}
@@ -0,0 +1,31 @@
+package api
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/ruslanonly/blindtyping/src/internal/models"
+)
+
+const personalTokenScopesKey = "personal_token_scopes"
+
+// SetPersonalTokenScopes marks the request as authenticated by a personal token.
+func SetPersonalTokenScopes(c *gin.Context, scopes []models.Scope) {
+	c.Set(personalTokenScopesKey, scopes)
+}
+
+// GetPersonalTokenScopes returns scopes of the personal token the request was
+// authenticated with. ok is false for requests authenticated by session cookies.
+func GetPersonalTokenScopes(c *gin.Context) (scopes []models.Scope, ok bool) {
+	value, exists := c.Get(personalTokenScopesKey)
+	if !exists {
+		return nil, false
+	}
+
+	scopes, ok = value.([]models.Scope)
+	return scopes, ok
+}
+
+func IsPersonalTokenRequest(c *gin.Context) bool {
+	_, ok := GetPersonalTokenScopes(c)
+	return ok
+}
@@ -0,0 +1,32 @@
+package api
+
+import (
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	rateLimitLimitHeader     = "RateLimit-Limit"
+	rateLimitRemainingHeader = "RateLimit-Remaining"
+	rateLimitResetHeader     = "RateLimit-Reset"
+	retryAfterHeader         = "Retry-After"
+)
+
+// SetRateLimitHeaders tells the client how many requests are left in the
+// current window and in how many seconds it resets. Requests over the limit
+// also get Retry-After.
+func SetRateLimitHeaders(c *gin.Context, limit, count int64, reset time.Duration) {
+	reset = max(reset, 0)
+	resetSeconds := strconv.FormatInt(int64(math.Ceil(reset.Seconds())), 10)
+
+	c.Header(rateLimitLimitHeader, strconv.FormatInt(limit, 10))
+	c.Header(rateLimitRemainingHeader, strconv.FormatInt(max(limit-count, 0), 10))
+	c.Header(rateLimitResetHeader, resetSeconds)
+
+	if count > limit {
+		c.Header(retryAfterHeader, resetSeconds)
+	}
+}
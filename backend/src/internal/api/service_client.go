@@ -0,0 +1,14 @@
+package api
+
+import "github.com/gin-gonic/gin"
+
+const serviceClientKey = "service_client"
+
+// SetServiceClient marks the request as made by the internal caller with the given name.
+func SetServiceClient(c *gin.Context, name string) {
+	c.Set(serviceClientKey, name)
+}
+
+func GetServiceClient(c *gin.Context) string {
+	return c.GetString(serviceClientKey)
+}
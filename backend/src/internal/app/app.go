@@ -2,6 +2,12 @@ package app
 
 import (
 	"context"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/redis/go-redis/extra/redisotel/v9"
 
 	_ "github.com/ruslanonly/blindtyping/src/docs"
 	"github.com/ruslanonly/blindtyping/src/internal/app/config"
@@ -21,6 +27,10 @@ func (a *App) Run() {
 	logger := diContainer.Logger()
 	logger.Info(logger.WithMsg(ctx, "di container created"))
 
+	// Tracing
+	tracing := diContainer.Tracing()
+	defer tracing.MustShutdown(ctx)
+
 	// Postgres
 	postgres := diContainer.Postgres()
 	postgres.MustConnect(ctx, cfg.Postgres.Connection)
@@ -32,14 +42,24 @@ func (a *App) Run() {
 	redisClient := diContainer.Redis()
 	redisClient.MustConnect(ctx)
 	defer redisClient.MustClose()
+	if err := redisotel.InstrumentTracing(redisClient, redisotel.WithTracerProvider(tracing.TracerProvider())); err != nil {
+		panic(err)
+	}
 	logger.Info(logger.WithMsg(ctx, "connected to redis"))
 
 	// Scheduler
+	diContainer.MustScheduleJobs()
 	scheduler := diContainer.Scheduler()
 	scheduler.Start()
 	defer scheduler.Stop()
 	logger.Info(logger.WithMsg(ctx, "scheduler started"))
 
+	// Analytics
+	analyticsService := diContainer.AnalyticsService()
+	analyticsService.Start()
+	defer analyticsService.Stop()
+	logger.Info(logger.WithMsg(ctx, "analytics started"))
+
 	// Antifroad
 	if !cfg.Antifroad.IsDisabled {
 		antifroadService := diContainer.AntifroadService()
@@ -48,10 +68,38 @@ func (a *App) Run() {
 			panic(err)
 		}
 		logger.Info(logger.WithMsg(ctx, "antifroad service initialized"))
+
+		if err = diContainer.AntifroadKeyService().Init(ctx); err != nil {
+			panic(err)
+		}
+		logger.Info(logger.WithMsg(ctx, "antifroad keys initialized"))
 	}
 
+	// Config reload
+	configReloader := diContainer.ConfigReloader()
+	configReloader.Start()
+	defer configReloader.Stop()
+	logger.Info(logger.WithMsg(ctx, "config reloader started"))
+
+	// Shutdown. On a signal the instance first reports not ready and keeps
+	// serving for readiness_delay, so load balancers stop sending new requests
+	// to it. Only then the server context is cancelled and the server shuts
+	// down, finishing the current requests within shutdown_timeout.
+	healthService := diContainer.HealthService()
+	serverCtx, stopServer := context.WithCancel(ctx)
+	defer stopServer()
+	shutdown := make(chan os.Signal, 1)
+	signal.Notify(shutdown, syscall.SIGINT, syscall.SIGTERM)
+	go func() {
+		<-shutdown
+		healthService.Drain()
+		logger.Info(logger.WithMsg(ctx, "draining connections"))
+		time.Sleep(cfg.Server.ReadinessDelay.Duration)
+		stopServer()
+	}()
+
 	// HTTP server
 	server := diContainer.Server()
-	server.MustRun(ctx)
+	server.MustRun(serverCtx)
 	logger.Info(logger.WithMsg(ctx, "server stopped"))
 }
@@ -1,33 +1,67 @@
 package config
 
 type Config struct {
-	Server     Server     `yaml:"server"`
-	Logger     Logger     `yaml:"logger"`
-	CORS       CORS       `yaml:"cors"`
-	Redis      Redis      `yaml:"redis"`
-	Swagger    Swagger    `yaml:"swagger"`
-	Cookie     Cookie     `yaml:"cookie"`
-	Scheduler  Scheduler  `yaml:"scheduler"`
-	Auth       Auth       `yaml:"auth"`
-	Postgres   Postgres   `yaml:"postgres"`
-	Profile    Profile    `yaml:"profile"`
-	Statistics Statistics `yaml:"statistics"`
-	Antifroad  Antifroad  `yaml:"antifroad"`
-	Languages  []string   `yaml:"languages"`
+	Environment string      `yaml:"environment"` // Окружение: debug или production. В production нельзя оставлять секреты по умолчанию
+	Server      Server      `yaml:"server"`
+	Logger      Logger      `yaml:"logger"`
+	CORS        CORS        `yaml:"cors"`
+	Redis       Redis       `yaml:"redis"`
+	Swagger     Swagger     `yaml:"swagger"`
+	Metrics     Metrics     `yaml:"metrics"`
+	Tracing     Tracing     `yaml:"tracing"`
+	Analytics   Analytics   `yaml:"analytics"`
+	Cookie      Cookie      `yaml:"cookie"`
+	Scheduler   Scheduler   `yaml:"scheduler"`
+	Auth        Auth        `yaml:"auth"`
+	Postgres    Postgres    `yaml:"postgres"`
+	Profile     Profile     `yaml:"profile"`
+	Account     Account     `yaml:"account"`
+	Usernames   Usernames   `yaml:"usernames"`
+	Avatars     Avatars     `yaml:"avatars"`
+	Statistics  Statistics  `yaml:"statistics"`
+	Antifroad   Antifroad   `yaml:"antifroad"`
+	Languages   []string    `yaml:"languages"`
+	Tokens      Tokens      `yaml:"tokens"`
+	ServiceAuth ServiceAuth `yaml:"service_auth"`
+	RateLimits  RateLimits  `yaml:"rate_limits"`
+
+	path    string // Файл, из которого загружен конфиг; читается снова при перезагрузке
+	envPath string
 }
 
 type Server struct {
-	Address         string `yaml:"address"`
-	ReadTimeout     string `yaml:"read_timeout"`
-	WriteTimeout    string `yaml:"write_timeout"`
-	IdleTimeout     string `yaml:"idle_timeout"`
-	ShutdownTimeout string `yaml:"shutdown_timeout"`
+	Address         string   `yaml:"address"`
+	ReadTimeout     Duration `yaml:"read_timeout"`
+	WriteTimeout    Duration `yaml:"write_timeout"`
+	IdleTimeout     Duration `yaml:"idle_timeout"`
+	ShutdownTimeout Duration `yaml:"shutdown_timeout"`
+	ReadinessDelay  Duration `yaml:"readiness_delay"` // Сколько после сигнала остановки отвечать not ready до остановки сервера
+	TrustedProxies  []string `yaml:"trusted_proxies"` // Адреса или подсети прокси, которым можно доверять X-Forwarded-For; без них клиентом считается адрес соединения
 }
 
 type Logger struct {
-	Enabled bool   `yaml:"enabled"`  // Нужно ли писать логи?
-	UseFile bool   `yaml:"use_file"` // Писать логи в файл иил в stdout?
-	Path    string `yaml:"path"`     // Путь к файлу с логами (если use_file = true)
+	Enabled      bool        `yaml:"enabled"`       // Нужно ли писать логи?
+	UseFile      bool        `yaml:"use_file"`      // Писать логи в файл иил в stdout? Учитывается, если sinks не заданы
+	Path         string      `yaml:"path"`          // Путь к файлу с логами (если use_file = true)
+	Level        string      `yaml:"level"`         // Минимальный уровень: debug, info, warning или error
+	Sinks        []LogSink   `yaml:"sinks"`         // Куда писать логи, можно в несколько мест сразу
+	Sampling     LogSampling `yaml:"sampling"`      // Прореживание предупреждений о 4xx ответах
+	RedactFields []string    `yaml:"redact_fields"` // Поля, значения которых не пишутся в лог (по полному имени без учета регистра)
+}
+
+type LogSink struct {
+	Output     string `yaml:"output"`       // stdout, stderr или file
+	Format     string `yaml:"format"`       // json или console
+	Path       string `yaml:"path"`         // Путь к файлу (если output = file)
+	MaxSizeMB  int    `yaml:"max_size_mb"`  // Размер файла в МБ, после которого он ротируется
+	MaxAgeDays int    `yaml:"max_age_days"` // Сколько дней хранить ротированные файлы (0 - не удалять по возрасту)
+	MaxBackups int    `yaml:"max_backups"`  // Сколько ротированных файлов хранить (0 - все)
+	Compress   bool   `yaml:"compress"`     // Сжимать ротированные файлы
+}
+
+type LogSampling struct {
+	WarningsPerHandler int      `yaml:"warnings_per_handler"` // Сколько предупреждений о 4xx одной ручки писать за интервал (0 - все)
+	Interval           Duration `yaml:"interval"`
 }
 
 type CORS struct {
@@ -62,6 +96,7 @@ type Provider struct {
 
 type Auth struct {
 	JWTSecret               string    `yaml:"jwt_secret"`
+	AccessTokenTTL          Duration  `yaml:"access_token_ttl"` // Время жизни access token, на которое запоминается отзыв токенов пользователя
 	Providers               Providers `yaml:"providers"`
 	LoggedInRedirectURL     string    `yaml:"logged_in_redirect_url"`
 	RegistrationRedirectURL string    `yaml:"registration_redirect_url"`
@@ -73,27 +108,136 @@ type Swagger struct {
 	Password string `yaml:"password"`
 }
 
+type Metrics struct {
+	Login    string `yaml:"login"`    // Логин для доступа к /metrics
+	Password string `yaml:"password"` // Пароль для доступа к /metrics
+}
+
+type Tracing struct {
+	Exporter    string  `yaml:"exporter"`     // Куда отправлять спаны: none, stdout или otlp
+	Endpoint    string  `yaml:"endpoint"`     // Адрес OTLP коллектора (host:port), если exporter = otlp
+	Insecure    bool    `yaml:"insecure"`     // Отправлять спаны в коллектор без TLS
+	ServiceName string  `yaml:"service_name"` // Имя сервиса в трейсах
+	SampleRatio float64 `yaml:"sample_ratio"` // Доля запросов, для которых пишутся трейсы
+}
+
+type Analytics struct {
+	BatchSize     int      `yaml:"batch_size"`     // Сколько событий записывать в базу за раз
+	BufferSize    int      `yaml:"buffer_size"`    // Сколько событий держать в памяти до записи; лишние отбрасываются
+	FlushInterval Duration `yaml:"flush_interval"` // Как часто записывать накопленные события
+	TestTimeout   Duration `yaml:"test_timeout"`   // Через сколько после начала незавершенный тест считается брошенным
+}
+
 type Cookie struct {
 	Secure bool   `yaml:"secure"`
 	Key    string `yaml:"key"`
 }
 
 type Scheduler struct {
-	DeleteExpiredSessionsInterval string `yaml:"delete_expired_sessions_interval"`
+	DeleteExpiredSessionsInterval   string `yaml:"delete_expired_sessions_interval"`
+	LiftExpiredSanctionsInterval    string `yaml:"lift_expired_sanctions_interval"`    // Интервал снятия истекших санкций с пользователей
+	DeleteScheduledAccountsInterval string `yaml:"delete_scheduled_accounts_interval"` // Интервал удаления аккаунтов, у которых прошел льготный период
+	AbandonStaleTestsInterval       string `yaml:"abandon_stale_tests_interval"`       // Интервал поиска брошенных тестов
+}
+
+type Account struct {
+	DeletionGracePeriod       Duration `yaml:"deletion_grace_period"`       // Через сколько после запроса аккаунт удаляется окончательно
+	UsernameReservationPeriod Duration `yaml:"username_reservation_period"` // Сколько прежнее имя пользователя недоступно другим
+	UsernameChangeInterval    Duration `yaml:"username_change_interval"`    // Как часто пользователь может менять имя
+}
+
+type Usernames struct {
+	MinLength   int                 `yaml:"min_length"`   // Длина считается в символах, а не в байтах
+	MaxLength   int                 `yaml:"max_length"`   // От 5, чтобы в предложенных вариантах перед числом оставалась буква, и не больше 32, столько вмещает users.nickname
+	Scripts     []string            `yaml:"scripts"`      // Разрешенные алфавиты: latin, cyrillic. В одном имени буквы только одного из них
+	AllowDigits bool                `yaml:"allow_digits"` // Можно ли использовать цифры
+	Symbols     string              `yaml:"symbols"`      // Разрешенные символы кроме букв и цифр
+	Reserved    []string            `yaml:"reserved"`     // Имена, которые нельзя занять, вместе с похожими на них
+	Profanity   map[string][]string `yaml:"profanity"`    // Слова, которых не должно быть в имени, по языкам
+	Suggestions int                 `yaml:"suggestions"`  // Сколько свободных вариантов предлагать вместо отклоненного имени
+}
+
+type Avatars struct {
+	Size          int           `yaml:"size"`            // Сторона квадратной аватарки в пикселях после уменьшения
+	MaxUploadSize int64         `yaml:"max_upload_size"` // Максимальный размер загружаемого файла в байтах
+	URL           string        `yaml:"url"`             // Адрес, по которому отдаются загруженные аватарки, например "http://localhost:5001/avatars"
+	Storage       AvatarStorage `yaml:"storage"`
+}
+
+type AvatarStorage struct {
+	Backend   string `yaml:"backend"`    // local или s3
+	Path      string `yaml:"path"`       // Каталог с аватарками для local
+	Endpoint  string `yaml:"endpoint"`   // Адрес S3-совместимого хранилища для s3, например "https://storage.yandexcloud.net"
+	Region    string `yaml:"region"`     // Регион для подписи запросов к s3
+	Bucket    string `yaml:"bucket"`     // Бакет для s3
+	AccessKey string `yaml:"access_key"` // Ключи доступа к s3
+	SecretKey string `yaml:"secret_key"`
 }
 
 type Profile struct {
-	Expiration string `yaml:"expiration"`
-	UseRedis   bool   `yaml:"use_redis"`
+	Expiration Duration `yaml:"expiration"` // Время жизни кэша профиля, например "10m"
+	UseRedis   bool     `yaml:"use_redis"`
 }
 
 type Statistics struct {
-	PBExpirationTime string `yaml:"pb_expiration_time"`
+	PBExpirationTime Duration `yaml:"pb_expiration_time"` // Время жизни кэша личных рекордов, например "15m"
+	RestoreWindow    Duration `yaml:"restore_window"`     // Сколько времени можно восстановить удаленную статистику
 }
 
 type Antifroad struct {
-	IsDisabled       bool   `yaml:"is_disabled"`       // Нужно ли выключить модуль антифрода?
-	MaxKeys          int    `yaml:"max_keys"`          // Максимальное кол-во одновременно существующих в базе ключей
-	RotationInterval string `yaml:"rotation_interval"` // Интервал ротации ключей в базе
-	Password         string `yaml:"password"`          // Пароль для авторизации в модуле антифрода внутри контура
+	IsDisabled               bool       `yaml:"is_disabled"`                // Нужно ли выключить модуль антифрода?
+	MaxKeys                  int        `yaml:"max_keys"`                   // Максимальное кол-во одновременно существующих в базе ключей
+	RotationInterval         string     `yaml:"rotation_interval"`          // Интервал ротации ключей в базе
+	KeyGracePeriod           Duration   `yaml:"key_grace_period"`           // Сколько ключ остается действительным после ротации
+	SessionTTL               Duration   `yaml:"session_ttl"`                // Время жизни сессии подписи результатов
+	RequireSessionSignatures bool       `yaml:"require_session_signatures"` // Принимать только результаты, подписанные ключом сессии; в production обязательно
+	Password                 string     `yaml:"password"`                   // Пароль для авторизации в модуле антифрода внутри контура
+	Anomaly                  Anomaly    `yaml:"anomaly"`                    // Детектор аномалий в результатах
+	Biometrics               Biometrics `yaml:"biometrics"`                 // Анализ ритма нажатий клавиш
+}
+
+type Biometrics struct {
+	MinKeystrokes         int     `yaml:"min_keystrokes"`           // Минимальное кол-во нажатий для анализа
+	MaxKeystrokes         int     `yaml:"max_keystrokes"`           // Максимальное кол-во нажатий в одном результате
+	MinIntervalCV         float64 `yaml:"min_interval_cv"`          // Минимальный коэффициент вариации интервалов между нажатиями
+	MinDwellStdDev        float64 `yaml:"min_dwell_std_dev"`        // Минимальное стандартное отклонение времени удержания клавиш в мс
+	MinBigramRepeats      int     `yaml:"min_bigram_repeats"`       // Сколько раз должна встретиться пара клавиш, чтобы ее проверять
+	MaxUniformBigramShare float64 `yaml:"max_uniform_bigram_share"` // Допустимая доля пар клавиш с одинаковыми интервалами
+	MinTypedShare         float64 `yaml:"min_typed_share"`          // Минимальное отношение нажатий к набранным символам
+	MinProfileSamples     int     `yaml:"min_profile_samples"`      // Сколько результатов нужно для сравнения с профилем пользователя
+	MaxProfileDeviation   float64 `yaml:"max_profile_deviation"`    // Допустимое относительное отклонение от профиля пользователя
+}
+
+type Anomaly struct {
+	HistoryWindow              Duration `yaml:"history_window"`                // За какой период брать прошлые результаты пользователя
+	MinHistory                 int      `yaml:"min_history"`                   // Минимальное кол-во прошлых результатов для поиска скачков WPM
+	WPMZScore                  float64  `yaml:"wpm_z_score"`                   // Во сколько стандартных отклонений от среднего WPM считается скачком
+	DurationTolerance          Duration `yaml:"duration_tolerance"`            // Допустимое расхождение длительности и finishedAt - startedAt
+	MinUncompletedTestDuration Duration `yaml:"min_uncompleted_test_duration"` // Минимальная средняя длительность незавершенного теста
+	MinKeystrokeInterval       Duration `yaml:"min_keystroke_interval"`        // Минимальный правдоподобный интервал между нажатиями
+	MaxFastKeystrokeShare      float64  `yaml:"max_fast_keystroke_share"`      // Допустимая доля слишком быстрых нажатий
+	Threshold                  float64  `yaml:"threshold"`                     // Результаты с таким и большим счетом отправляются на проверку
+}
+
+type Tokens struct {
+	MaxPerUser int `yaml:"max_per_user"` // Максимальное кол-во персональных токенов у одного пользователя
+}
+
+type ServiceAuth struct {
+	MaxClockSkew    Duration `yaml:"max_clock_skew"`    // Допустимое расхождение времени запроса и сервера
+	RateLimit       int64    `yaml:"rate_limit"`        // Сколько запросов к одной ручке может сделать сервис за период
+	RateLimitPeriod Duration `yaml:"rate_limit_period"` // Период ограничения запросов
+	MaxBodySize     int64    `yaml:"max_body_size"`     // Максимальный размер тела запроса в байтах, читается до проверки подписи
+}
+
+type RateLimits struct {
+	StatisticsWrite RateLimit `yaml:"statistics_write"` // Отправка результатов, на пользователя
+	UsernameCheck   RateLimit `yaml:"username_check"`   // Проверка доступности никнейма, на IP
+	OAuthBegin      RateLimit `yaml:"oauth_begin"`      // Начало входа через OAuth, на IP
+	AvatarUpload    RateLimit `yaml:"avatar_upload"`    // Загрузка аватарки, на пользователя
+}
+
+type RateLimit struct {
+	Limit  int64    `yaml:"limit"`  // Сколько запросов можно сделать за период
+	Period Duration `yaml:"period"` // Период ограничения запросов
 }
@@ -0,0 +1,142 @@
+package config
+
+import "time"
+
+const (
+	EnvironmentDebug      = "debug"
+	EnvironmentProduction = "production"
+)
+
+// defaultConfig is the config before the file is applied. Keys missing from the
+// file keep these values. Connection strings and secrets have no defaults.
+func defaultConfig() *Config {
+	return &Config{
+		Environment: EnvironmentProduction,
+		Server: Server{
+			Address:         ":5001",
+			ReadTimeout:     Duration{60 * time.Second},
+			WriteTimeout:    Duration{60 * time.Second},
+			IdleTimeout:     Duration{60 * time.Second},
+			ShutdownTimeout: Duration{15 * time.Second},
+			ReadinessDelay:  Duration{5 * time.Second},
+		},
+		Logger: Logger{
+			Enabled: true,
+			Level:   "info",
+			Sampling: LogSampling{
+				WarningsPerHandler: 20,
+				Interval:           Duration{time.Minute},
+			},
+			RedactFields: []string{
+				"token", "access_token", "refresh_token", "id_token",
+				"password", "secret", "client_secret", "secret_key", "access_key",
+				"email", "authorization", "cookie", "set_cookie",
+				"sign", "session_sign", "signature", "x_service_signature",
+			},
+		},
+		Postgres: Postgres{
+			Migrations: "file://src/migrations",
+		},
+		Tracing: Tracing{
+			Exporter:    "none",
+			ServiceName: "blindtyping",
+			SampleRatio: 1,
+		},
+		Analytics: Analytics{
+			BatchSize:     100,
+			BufferSize:    10000,
+			FlushInterval: Duration{5 * time.Second},
+			TestTimeout:   Duration{30 * time.Minute},
+		},
+		Scheduler: Scheduler{
+			DeleteExpiredSessionsInterval:   "@every 24h",
+			LiftExpiredSanctionsInterval:    "@every 5m",
+			DeleteScheduledAccountsInterval: "@every 1h",
+			AbandonStaleTestsInterval:       "@every 5m",
+		},
+		Auth: Auth{
+			AccessTokenTTL: Duration{time.Hour},
+		},
+		Profile: Profile{
+			Expiration: Duration{10 * time.Minute},
+			UseRedis:   true,
+		},
+		Account: Account{
+			DeletionGracePeriod:       Duration{30 * 24 * time.Hour},
+			UsernameReservationPeriod: Duration{90 * 24 * time.Hour},
+			UsernameChangeInterval:    Duration{15 * 24 * time.Hour},
+		},
+		Usernames: Usernames{
+			MinLength:   3,
+			MaxLength:   32,
+			Scripts:     []string{"latin", "cyrillic"},
+			AllowDigits: true,
+			Symbols:     "_-.",
+			Reserved: []string{
+				"admin", "administrator", "moderator", "support", "api", "me", "root",
+				"system", "help", "auth", "login", "register", "settings", "blindtyping",
+			},
+			Profanity: map[string][]string{
+				"english": {"fuck", "shit", "bitch", "cunt", "whore", "slut"},
+				"russian": {"хуй", "хуе", "пизд", "ебан", "ебат", "бляд", "сука", "мудак"},
+			},
+			Suggestions: 3,
+		},
+		Avatars: Avatars{
+			Size:          256,
+			MaxUploadSize: 5 << 20,
+			Storage: AvatarStorage{
+				Backend: "local",
+				Path:    "avatars",
+			},
+		},
+		Statistics: Statistics{
+			PBExpirationTime: Duration{15 * time.Minute},
+			RestoreWindow:    Duration{7 * 24 * time.Hour},
+		},
+		Antifroad: Antifroad{
+			MaxKeys:                  5,
+			RotationInterval:         "@every 24h",
+			KeyGracePeriod:           Duration{10 * time.Minute},
+			SessionTTL:               Duration{24 * time.Hour},
+			RequireSessionSignatures: true,
+			Anomaly: Anomaly{
+				HistoryWindow:              Duration{720 * time.Hour},
+				MinHistory:                 5,
+				WPMZScore:                  4,
+				DurationTolerance:          Duration{2 * time.Second},
+				MinUncompletedTestDuration: Duration{300 * time.Millisecond},
+				MinKeystrokeInterval:       Duration{15 * time.Millisecond},
+				MaxFastKeystrokeShare:      0.1,
+				Threshold:                  1,
+			},
+			Biometrics: Biometrics{
+				MinKeystrokes:         30,
+				MaxKeystrokes:         20000,
+				MinIntervalCV:         0.05,
+				MinDwellStdDev:        2,
+				MinBigramRepeats:      3,
+				MaxUniformBigramShare: 0.5,
+				MinTypedShare:         0.5,
+				MinProfileSamples:     10,
+				MaxProfileDeviation:   0.6,
+			},
+		},
+		Languages: []string{"english", "russian"},
+		Tokens: Tokens{
+			MaxPerUser: 20,
+		},
+		ServiceAuth: ServiceAuth{
+			MaxClockSkew:    Duration{5 * time.Minute},
+			RateLimit:       10,
+			RateLimitPeriod: Duration{time.Minute},
+			MaxBodySize:     1 << 20,
+		},
+		RateLimits: RateLimits{
+			StatisticsWrite: RateLimit{Limit: 30, Period: Duration{time.Minute}},
+			UsernameCheck:   RateLimit{Limit: 60, Period: Duration{time.Minute}},
+			OAuthBegin:      RateLimit{Limit: 20, Period: Duration{time.Minute}},
+			AvatarUpload:    RateLimit{Limit: 10, Period: Duration{time.Hour}},
+		},
+	}
+}
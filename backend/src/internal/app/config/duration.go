@@ -0,0 +1,38 @@
+package config
+
+import (
+	"fmt"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Duration is parsed from strings like "15s" or "24h" when the config is
+// loaded, so a malformed value stops the startup instead of a later panic.
+type Duration struct {
+	time.Duration
+}
+
+func (d *Duration) UnmarshalYAML(node *yaml.Node) error {
+	var value string
+	if err := node.Decode(&value); err != nil {
+		return err
+	}
+
+	if err := d.parse(value); err != nil {
+		// TypeError lets the decoder go on and report the other fields too
+		return &yaml.TypeError{Errors: []string{fmt.Sprintf("line %d: %s", node.Line, err)}}
+	}
+
+	return nil
+}
+
+func (d *Duration) parse(value string) error {
+	parsed, err := time.ParseDuration(value)
+	if err != nil {
+		return fmt.Errorf("invalid duration %q", value)
+	}
+
+	d.Duration = parsed
+	return nil
+}
@@ -0,0 +1,139 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+const envPrefix = "BT"
+
+var (
+	envVarPattern = regexp.MustCompile(`\${([^}:]+)(:-([^}]*))?}`)
+	durationType  = reflect.TypeOf(Duration{})
+)
+
+// expandEnvVars replaces ${VAR} with environment variables. ${VAR:-default}
+// falls back to the default; a variable without one must be set.
+func expandEnvVars(content []byte) ([]byte, []error) {
+	var errs []error
+
+	expanded := envVarPattern.ReplaceAllFunc(content, func(match []byte) []byte {
+		groups := envVarPattern.FindSubmatch(match)
+		name := string(groups[1])
+
+		if value, ok := os.LookupEnv(name); ok {
+			return []byte(value)
+		}
+		if len(groups[2]) > 0 {
+			return groups[3]
+		}
+
+		errs = append(errs, fmt.Errorf("environment variable %s is not set", name))
+		return nil
+	})
+
+	return expanded, errs
+}
+
+// applyEnvOverrides sets every key that has a BT_ environment variable, named
+// after the key path: server.shutdown_timeout is BT_SERVER_SHUTDOWN_TIMEOUT.
+// Lists of strings are comma separated. Maps and lists of objects are given as
+// JSON, for example BT_LOGGER_SINKS='[{"output": "stdout"}]', and replace the
+// value from the file as a whole.
+func applyEnvOverrides(cfg *Config) []error {
+	var errs []error
+	overrideStruct(reflect.ValueOf(cfg).Elem(), envPrefix, &errs)
+	return errs
+}
+
+func overrideStruct(v reflect.Value, prefix string, errs *[]error) {
+	t := v.Type()
+
+	for i := range t.NumField() {
+		key, _, _ := strings.Cut(t.Field(i).Tag.Get("yaml"), ",")
+		if key == "" || key == "-" {
+			continue
+		}
+
+		name := prefix + "_" + strings.ToUpper(key)
+		field := v.Field(i)
+
+		if field.Kind() == reflect.Struct && field.Type() != durationType {
+			overrideStruct(field, name, errs)
+			continue
+		}
+
+		value, ok := os.LookupEnv(name)
+		if !ok {
+			continue
+		}
+
+		if err := setField(field, value); err != nil {
+			*errs = append(*errs, fmt.Errorf("%s: %w", name, err))
+		}
+	}
+}
+
+func setField(field reflect.Value, value string) error {
+	if field.Type() == durationType {
+		return field.Addr().Interface().(*Duration).parse(value)
+	}
+
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(value)
+	case reflect.Bool:
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("invalid bool %q", value)
+		}
+		field.SetBool(parsed)
+	case reflect.Int, reflect.Int64:
+		parsed, err := strconv.ParseInt(value, 10, field.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("invalid integer %q", value)
+		}
+		field.SetInt(parsed)
+	case reflect.Float64:
+		parsed, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return fmt.Errorf("invalid number %q", value)
+		}
+		field.SetFloat(parsed)
+	case reflect.Map:
+		return setFromJSON(field, value)
+	case reflect.Slice:
+		if field.Type().Elem().Kind() != reflect.String {
+			return setFromJSON(field, value)
+		}
+		items := make([]string, 0)
+		for _, item := range strings.Split(value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+		field.Set(reflect.ValueOf(items))
+	default:
+		return fmt.Errorf("unsupported type %s", field.Type())
+	}
+
+	return nil
+}
+
+// setFromJSON decodes the value with the YAML decoder the file goes through, so
+// the yaml tags and Duration parsing apply. JSON is valid YAML.
+func setFromJSON(field reflect.Value, value string) error {
+	parsed := reflect.New(field.Type())
+	if err := yaml.Unmarshal([]byte(value), parsed.Interface()); err != nil {
+		return fmt.Errorf("invalid JSON for %s: %w", field.Type(), err)
+	}
+
+	field.Set(parsed.Elem())
+	return nil
+}
@@ -0,0 +1,43 @@
+package config
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestApplyEnvOverrides(t *testing.T) {
+	t.Setenv("BT_SERVER_SHUTDOWN_TIMEOUT", "7s")
+	t.Setenv("BT_LANGUAGES", "english, german")
+	t.Setenv("BT_USERNAMES_PROFANITY", `{"english": ["darn"], "russian": []}`)
+	t.Setenv("BT_LOGGER_SINKS", `[{"output": "file", "format": "json", "path": "/tmp/bt.log", "max_size_mb": 10}]`)
+
+	cfg := defaultConfig()
+	if errs := applyEnvOverrides(cfg); len(errs) > 0 {
+		t.Fatalf("errors = %v", errs)
+	}
+
+	if cfg.Server.ShutdownTimeout.Duration != 7*time.Second {
+		t.Errorf("server.shutdown_timeout = %s, want 7s", cfg.Server.ShutdownTimeout)
+	}
+	if want := []string{"english", "german"}; !reflect.DeepEqual(cfg.Languages, want) {
+		t.Errorf("languages = %v, want %v", cfg.Languages, want)
+	}
+	if want := map[string][]string{"english": {"darn"}, "russian": {}}; !reflect.DeepEqual(cfg.Usernames.Profanity, want) {
+		t.Errorf("usernames.profanity = %v, want %v", cfg.Usernames.Profanity, want)
+	}
+	want := []LogSink{{Output: "file", Format: "json", Path: "/tmp/bt.log", MaxSizeMB: 10}}
+	if !reflect.DeepEqual(cfg.Logger.Sinks, want) {
+		t.Errorf("logger.sinks = %+v, want %+v", cfg.Logger.Sinks, want)
+	}
+}
+
+func TestApplyEnvOverridesReportsInvalidJSON(t *testing.T) {
+	t.Setenv("BT_LOGGER_SINKS", `[{"output": "stdout"`)
+	t.Setenv("BT_USERNAMES_PROFANITY", `["darn"]`)
+
+	errs := applyEnvOverrides(defaultConfig())
+	if len(errs) != 2 {
+		t.Errorf("errors = %v, want one per variable", errs)
+	}
+}
@@ -1,12 +1,10 @@
 package config
 
 import (
+	"errors"
 	"flag"
 	"fmt"
 	"io/ioutil"
-	"os"
-	"regexp"
-	"strings"
 
 	"github.com/joho/godotenv"
 	"gopkg.in/yaml.v3"
@@ -33,24 +31,15 @@ func MustParse() *Config {
 
 	config, err := loadConfig(*configPath, *envPath)
 	if err != nil {
-		panic(err)
+		panic(fmt.Errorf("invalid config:\n%w", err))
 	}
 
 	return config
 }
 
-// expandEnvVars replaces ${VAR} with environment variables
-func expandEnvVars(content []byte) []byte {
-	re := regexp.MustCompile(`\${([^}]+)}`)
-	return re.ReplaceAllFunc(content, func(match []byte) []byte {
-		envVar := strings.Trim(string(match), "${}")
-		if value, exists := os.LookupEnv(envVar); exists {
-			return []byte(value)
-		}
-		return match // return original if env var not found
-	})
-}
-
+// loadConfig applies the file and then BT_ environment variables over the
+// defaults and validates the result. Errors of every step are reported
+// together, so a broken config is fixed in one go.
 func loadConfig(configPath, envPath string) (*Config, error) {
 	if err := godotenv.Load(envPath); err != nil {
 		fmt.Println("Error loading .env file")
@@ -63,13 +52,30 @@ func loadConfig(configPath, envPath string) (*Config, error) {
 	}
 
 	// Expand environment variables
-	expandedContent := expandEnvVars(content)
+	expandedContent, errs := expandEnvVars(content)
 
 	// Parse YAML
-	var config Config
-	if err := yaml.Unmarshal(expandedContent, &config); err != nil {
+	config := defaultConfig()
+	config.path, config.envPath = configPath, envPath
+	if err = yaml.Unmarshal(expandedContent, config); err != nil {
+		var typeErr *yaml.TypeError
+		if !errors.As(err, &typeErr) {
+			return nil, err
+		}
+		for _, e := range typeErr.Errors {
+			errs = append(errs, errors.New(e))
+		}
+	}
+
+	errs = append(errs, applyEnvOverrides(config)...)
+
+	if err = validate(config); err != nil {
+		errs = append(errs, err)
+	}
+
+	if err = errors.Join(errs...); err != nil {
 		return nil, err
 	}
 
-	return &config, nil
+	return config, nil
 }
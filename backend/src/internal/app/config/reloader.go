@@ -0,0 +1,166 @@
+package config
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"reflect"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/ruslanonly/blindtyping/src/internal"
+)
+
+const (
+	reloaderName  = "config_reloader"
+	watchInterval = 5 * time.Second
+)
+
+// withReloadable takes the keys that are safe to change at runtime from next
+// and keeps the rest of c. A key is reloadable only when the container applies
+// it to the components that use it.
+func (c *Config) withReloadable(next *Config) *Config {
+	merged := *c
+
+	merged.Languages = next.Languages
+	merged.Logger.Level = next.Logger.Level
+	merged.CORS.AllowedOrigins = next.CORS.AllowedOrigins
+	merged.RateLimits = next.RateLimits
+	merged.Profile.Expiration = next.Profile.Expiration
+	merged.Statistics.PBExpirationTime = next.Statistics.PBExpirationTime
+	merged.Auth.LoggedInRedirectURL = next.Auth.LoggedInRedirectURL
+	merged.Auth.RegistrationRedirectURL = next.Auth.RegistrationRedirectURL
+	merged.Auth.ErrorRedirectURL = next.Auth.ErrorRedirectURL
+
+	return &merged
+}
+
+// changedSections lists the top-level keys that differ between the configs.
+func changedSections(a, b *Config) []string {
+	va, vb := reflect.ValueOf(a).Elem(), reflect.ValueOf(b).Elem()
+
+	sections := make([]string, 0)
+	for i := range va.NumField() {
+		field := va.Type().Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		if !reflect.DeepEqual(va.Field(i).Interface(), vb.Field(i).Interface()) {
+			sections = append(sections, strings.Split(field.Tag.Get("yaml"), ",")[0])
+		}
+	}
+
+	return sections
+}
+
+// Reloader reads the config file again on SIGHUP or when the file changes and
+// passes the keys that are safe to change at runtime to apply. Other changes
+// need a restart and are ignored. An invalid config is rejected as a whole and
+// the current one is kept.
+type Reloader struct {
+	logger internal.Logger
+	apply  func(cfg *Config) error
+
+	mu       sync.Mutex
+	current  *Config
+	modTime  time.Time
+	stop     chan struct{}
+	done     chan struct{}
+	signals  chan os.Signal
+	stopOnce sync.Once
+}
+
+func NewReloader(cfg *Config, logger internal.Logger, apply func(cfg *Config) error) *Reloader {
+	r := &Reloader{
+		logger:  logger,
+		apply:   apply,
+		current: cfg,
+		stop:    make(chan struct{}),
+		done:    make(chan struct{}),
+		signals: make(chan os.Signal, 1),
+	}
+	r.modTime, _ = r.fileModTime()
+
+	return r
+}
+
+func (r *Reloader) fileModTime() (time.Time, error) {
+	info, err := os.Stat(r.current.path)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return info.ModTime(), nil
+}
+
+// Start watches for SIGHUP and changes of the file until Stop.
+func (r *Reloader) Start() {
+	signal.Notify(r.signals, syscall.SIGHUP)
+
+	go func() {
+		defer close(r.done)
+
+		ticker := time.NewTicker(watchInterval)
+		defer ticker.Stop()
+
+		ctx := r.logger.WithHandlerName(context.Background(), reloaderName)
+
+		for {
+			select {
+			case <-r.signals:
+				r.Reload(r.logger.WithField(ctx, "trigger", "sighup"))
+			case <-ticker.C:
+				modTime, err := r.fileModTime()
+				if err == nil && !modTime.Equal(r.modTime) {
+					r.Reload(r.logger.WithField(ctx, "trigger", "file_change"))
+				}
+			case <-r.stop:
+				return
+			}
+		}
+	}()
+}
+
+func (r *Reloader) Stop() {
+	r.stopOnce.Do(func() {
+		signal.Stop(r.signals)
+		close(r.stop)
+		<-r.done
+	})
+}
+
+// Reload applies the config file now.
+func (r *Reloader) Reload(ctx context.Context) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if modTime, err := r.fileModTime(); err == nil {
+		r.modTime = modTime
+	}
+
+	next, err := loadConfig(r.current.path, r.current.envPath)
+	if err != nil {
+		r.logger.Error(r.logger.WithError(r.logger.WithMsg(ctx, "config rejected, keeping the current one"), err))
+		return
+	}
+
+	merged := r.current.withReloadable(next)
+	if sections := changedSections(merged, next); len(sections) > 0 {
+		ctx := r.logger.WithField(ctx, "sections", strings.Join(sections, ","))
+		r.logger.Warning(r.logger.WithMsg(ctx, "config has changes that need a restart, they are ignored"))
+	}
+
+	if reflect.DeepEqual(merged, r.current) {
+		r.logger.Info(r.logger.WithMsg(ctx, "config has no changes to apply"))
+		return
+	}
+
+	if err = r.apply(merged); err != nil {
+		r.logger.Error(r.logger.WithError(r.logger.WithMsg(ctx, "config rejected, keeping the current one"), err))
+		return
+	}
+
+	r.current = merged
+	r.logger.Info(r.logger.WithMsg(ctx, "config reloaded"))
+}
@@ -0,0 +1,227 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net/netip"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/robfig/cron"
+)
+
+// insecureSecrets are values from examples and the debug config that must not
+// reach production.
+var insecureSecrets = []string{"", "admin", "blindtyping", "user", "password", "secret", "changeme"}
+
+type validator struct {
+	errs []error
+}
+
+func (v *validator) check(ok bool, key, format string, args ...any) {
+	if !ok {
+		v.errs = append(v.errs, fmt.Errorf("%s: %s", key, fmt.Sprintf(format, args...)))
+	}
+}
+
+func (v *validator) required(key, value string) {
+	v.check(value != "", key, "is required")
+}
+
+func (v *validator) positive(key string, value Duration) {
+	v.check(value.Duration > 0, key, "must be positive")
+}
+
+func (v *validator) url(key, value string) {
+	u, err := url.Parse(value)
+	v.check(err == nil && u.Scheme != "" && u.Host != "", key, "must be an absolute URL, got %q", value)
+}
+
+func (v *validator) schedule(key, spec string) {
+	_, err := cron.Parse(spec)
+	v.check(err == nil, key, "invalid schedule %q", spec)
+}
+
+func (v *validator) secret(key, value string, isProduction bool) {
+	switch {
+	case value == "":
+		v.required(key, value)
+	case isProduction:
+		v.check(!slices.Contains(insecureSecrets, value), key, "must be set to a non-default secret in production")
+	}
+}
+
+func (v *validator) rateLimit(key string, limit RateLimit) {
+	v.check(limit.Limit > 0, key+".limit", "must be positive")
+	v.positive(key+".period", limit.Period)
+}
+
+// validate reports every invalid key at once.
+func validate(cfg *Config) error {
+	var (
+		v            validator
+		isProduction = cfg.Environment == EnvironmentProduction
+	)
+
+	v.check(
+		slices.Contains([]string{EnvironmentDebug, EnvironmentProduction}, cfg.Environment),
+		"environment", "must be %s or %s, got %q", EnvironmentDebug, EnvironmentProduction, cfg.Environment,
+	)
+
+	v.required("server.address", cfg.Server.Address)
+	v.positive("server.read_timeout", cfg.Server.ReadTimeout)
+	v.positive("server.write_timeout", cfg.Server.WriteTimeout)
+	v.positive("server.idle_timeout", cfg.Server.IdleTimeout)
+	v.positive("server.shutdown_timeout", cfg.Server.ShutdownTimeout)
+	v.check(cfg.Server.ReadinessDelay.Duration >= 0, "server.readiness_delay", "must not be negative")
+	for i, proxy := range cfg.Server.TrustedProxies {
+		_, prefixErr := netip.ParsePrefix(proxy)
+		_, addrErr := netip.ParseAddr(proxy)
+		v.check(
+			prefixErr == nil || addrErr == nil,
+			fmt.Sprintf("server.trusted_proxies[%d]", i), "must be an IP address or a CIDR subnet, got %q", proxy,
+		)
+	}
+
+	if cfg.Logger.Enabled && cfg.Logger.UseFile && len(cfg.Logger.Sinks) == 0 {
+		v.required("logger.path", cfg.Logger.Path)
+	}
+	v.check(
+		slices.Contains([]string{"debug", "info", "warning", "error"}, cfg.Logger.Level),
+		"logger.level", "must be debug, info, warning or error, got %q", cfg.Logger.Level,
+	)
+	for i, sink := range cfg.Logger.Sinks {
+		key := fmt.Sprintf("logger.sinks[%d]", i)
+		v.check(
+			slices.Contains([]string{"stdout", "stderr", "file"}, sink.Output),
+			key+".output", "must be stdout, stderr or file, got %q", sink.Output,
+		)
+		v.check(
+			slices.Contains([]string{"json", "console"}, sink.Format),
+			key+".format", "must be json or console, got %q", sink.Format,
+		)
+		if sink.Output == "file" {
+			v.required(key+".path", sink.Path)
+		}
+	}
+	if cfg.Logger.Sampling.WarningsPerHandler > 0 {
+		v.positive("logger.sampling.interval", cfg.Logger.Sampling.Interval)
+	}
+
+	if cfg.CORS.AllowAllOrigins {
+		v.check(len(cfg.CORS.AllowedOrigins) == 0, "cors.allowed_origins", "must be empty when allow_all_origins is set")
+	} else {
+		v.check(len(cfg.CORS.AllowedOrigins) > 0, "cors.allowed_origins", "at least one origin is required unless allow_all_origins is set")
+	}
+	for _, origin := range cfg.CORS.AllowedOrigins {
+		v.check(
+			strings.HasPrefix(origin, "http://") || strings.HasPrefix(origin, "https://"),
+			"cors.allowed_origins", "origin %q must start with http:// or https://", origin,
+		)
+	}
+
+	v.required("postgres.connection", cfg.Postgres.Connection)
+	v.required("postgres.migrations", cfg.Postgres.Migrations)
+	v.required("redis.address", cfg.Redis.Address)
+
+	v.required("swagger.login", cfg.Swagger.Login)
+	v.secret("swagger.password", cfg.Swagger.Password, isProduction)
+	v.required("metrics.login", cfg.Metrics.Login)
+	v.secret("metrics.password", cfg.Metrics.Password, isProduction)
+
+	v.check(
+		slices.Contains([]string{"none", "stdout", "otlp"}, cfg.Tracing.Exporter),
+		"tracing.exporter", "must be none, stdout or otlp, got %q", cfg.Tracing.Exporter,
+	)
+	if cfg.Tracing.Exporter == "otlp" {
+		v.required("tracing.endpoint", cfg.Tracing.Endpoint)
+	}
+	v.check(cfg.Tracing.SampleRatio >= 0 && cfg.Tracing.SampleRatio <= 1, "tracing.sample_ratio", "must be from 0 to 1")
+
+	v.check(cfg.Analytics.BatchSize > 0, "analytics.batch_size", "must be positive")
+	v.check(cfg.Analytics.BufferSize >= cfg.Analytics.BatchSize, "analytics.buffer_size", "must not be less than batch_size")
+	v.positive("analytics.flush_interval", cfg.Analytics.FlushInterval)
+	v.positive("analytics.test_timeout", cfg.Analytics.TestTimeout)
+
+	v.secret("cookie.key", cfg.Cookie.Key, isProduction)
+
+	v.schedule("scheduler.delete_expired_sessions_interval", cfg.Scheduler.DeleteExpiredSessionsInterval)
+	v.schedule("scheduler.lift_expired_sanctions_interval", cfg.Scheduler.LiftExpiredSanctionsInterval)
+	v.schedule("scheduler.delete_scheduled_accounts_interval", cfg.Scheduler.DeleteScheduledAccountsInterval)
+	v.schedule("scheduler.abandon_stale_tests_interval", cfg.Scheduler.AbandonStaleTestsInterval)
+
+	v.secret("auth.jwt_secret", cfg.Auth.JWTSecret, isProduction)
+	v.positive("auth.access_token_ttl", cfg.Auth.AccessTokenTTL)
+	v.url("auth.logged_in_redirect_url", cfg.Auth.LoggedInRedirectURL)
+	v.url("auth.registration_redirect_url", cfg.Auth.RegistrationRedirectURL)
+	v.url("auth.error_redirect_url", cfg.Auth.ErrorRedirectURL)
+
+	v.positive("profile.expiration", cfg.Profile.Expiration)
+	v.positive("account.deletion_grace_period", cfg.Account.DeletionGracePeriod)
+	v.positive("account.username_reservation_period", cfg.Account.UsernameReservationPeriod)
+	v.positive("account.username_change_interval", cfg.Account.UsernameChangeInterval)
+	v.check(cfg.Usernames.MinLength > 0, "usernames.min_length", "must be positive")
+	v.check(
+		cfg.Usernames.MaxLength >= max(cfg.Usernames.MinLength, 5) && cfg.Usernames.MaxLength <= 32,
+		"usernames.max_length", "must be from min_length and at least 5 to 32",
+	)
+	v.check(len(cfg.Usernames.Scripts) > 0, "usernames.scripts", "at least one script is required")
+	for _, script := range cfg.Usernames.Scripts {
+		v.check(
+			slices.Contains([]string{"latin", "cyrillic"}, script),
+			"usernames.scripts", "script must be latin or cyrillic, got %q", script,
+		)
+	}
+	v.check(cfg.Usernames.Suggestions >= 0, "usernames.suggestions", "must not be negative")
+	v.check(cfg.Avatars.Size > 0, "avatars.size", "must be positive")
+	v.check(cfg.Avatars.MaxUploadSize > 0, "avatars.max_upload_size", "must be positive")
+	v.url("avatars.url", cfg.Avatars.URL)
+	switch cfg.Avatars.Storage.Backend {
+	case "local":
+		v.required("avatars.storage.path", cfg.Avatars.Storage.Path)
+	case "s3":
+		v.url("avatars.storage.endpoint", cfg.Avatars.Storage.Endpoint)
+		v.required("avatars.storage.region", cfg.Avatars.Storage.Region)
+		v.required("avatars.storage.bucket", cfg.Avatars.Storage.Bucket)
+		v.required("avatars.storage.access_key", cfg.Avatars.Storage.AccessKey)
+		v.secret("avatars.storage.secret_key", cfg.Avatars.Storage.SecretKey, isProduction)
+	default:
+		v.check(false, "avatars.storage.backend", "must be local or s3, got %q", cfg.Avatars.Storage.Backend)
+	}
+	v.positive("statistics.pb_expiration_time", cfg.Statistics.PBExpirationTime)
+	v.positive("statistics.restore_window", cfg.Statistics.RestoreWindow)
+
+	if !cfg.Antifroad.IsDisabled {
+		v.secret("antifroad.password", cfg.Antifroad.Password, isProduction)
+		v.check(cfg.Antifroad.MaxKeys > 0, "antifroad.max_keys", "must be positive")
+		v.schedule("antifroad.rotation_interval", cfg.Antifroad.RotationInterval)
+		v.positive("antifroad.key_grace_period", cfg.Antifroad.KeyGracePeriod)
+		v.positive("antifroad.session_ttl", cfg.Antifroad.SessionTTL)
+		v.check(
+			cfg.Antifroad.RequireSessionSignatures || !isProduction,
+			"antifroad.require_session_signatures", "must be true in production",
+		)
+		v.positive("antifroad.anomaly.history_window", cfg.Antifroad.Anomaly.HistoryWindow)
+	}
+	v.check(
+		cfg.Antifroad.Biometrics.MaxKeystrokes >= cfg.Antifroad.Biometrics.MinKeystrokes &&
+			cfg.Antifroad.Biometrics.MaxKeystrokes > 0,
+		"antifroad.biometrics.max_keystrokes", "must be positive and at least min_keystrokes",
+	)
+
+	v.check(len(cfg.Languages) > 0, "languages", "at least one language is required")
+	v.check(cfg.Tokens.MaxPerUser > 0, "tokens.max_per_user", "must be positive")
+
+	v.positive("service_auth.max_clock_skew", cfg.ServiceAuth.MaxClockSkew)
+	v.check(cfg.ServiceAuth.RateLimit > 0, "service_auth.rate_limit", "must be positive")
+	v.positive("service_auth.rate_limit_period", cfg.ServiceAuth.RateLimitPeriod)
+	v.check(cfg.ServiceAuth.MaxBodySize > 0, "service_auth.max_body_size", "must be positive")
+
+	v.rateLimit("rate_limits.statistics_write", cfg.RateLimits.StatisticsWrite)
+	v.rateLimit("rate_limits.username_check", cfg.RateLimits.UsernameCheck)
+	v.rateLimit("rate_limits.oauth_begin", cfg.RateLimits.OAuthBegin)
+	v.rateLimit("rate_limits.avatar_upload", cfg.RateLimits.AvatarUpload)
+
+	return errors.Join(v.errs...)
+}
@@ -3,6 +3,23 @@ package di
 import (
 	"github.com/gin-contrib/cors"
 
+	"github.com/ruslanonly/blindtyping/src/internal/api/handlers/admin_analytics_get_handler"
+	"github.com/ruslanonly/blindtyping/src/internal/api/handlers/admin_audit_get_handler"
+	"github.com/ruslanonly/blindtyping/src/internal/api/handlers/admin_reviews_get_handler"
+	"github.com/ruslanonly/blindtyping/src/internal/api/handlers/admin_reviews_id_approve_post_handler"
+	"github.com/ruslanonly/blindtyping/src/internal/api/handlers/admin_reviews_id_reject_post_handler"
+	"github.com/ruslanonly/blindtyping/src/internal/api/handlers/admin_service_clients_get_handler"
+	"github.com/ruslanonly/blindtyping/src/internal/api/handlers/admin_service_clients_id_delete_handler"
+	"github.com/ruslanonly/blindtyping/src/internal/api/handlers/admin_service_clients_post_handler"
+	"github.com/ruslanonly/blindtyping/src/internal/api/handlers/admin_users_get_handler"
+	"github.com/ruslanonly/blindtyping/src/internal/api/handlers/admin_users_id_ban_post_handler"
+	"github.com/ruslanonly/blindtyping/src/internal/api/handlers/admin_users_id_get_handler"
+	"github.com/ruslanonly/blindtyping/src/internal/api/handlers/admin_users_id_role_patch_handler"
+	"github.com/ruslanonly/blindtyping/src/internal/api/handlers/admin_users_id_sanctions_get_handler"
+	"github.com/ruslanonly/blindtyping/src/internal/api/handlers/admin_users_id_sanctions_id_delete_handler"
+	"github.com/ruslanonly/blindtyping/src/internal/api/handlers/admin_users_id_sanctions_post_handler"
+	"github.com/ruslanonly/blindtyping/src/internal/api/handlers/admin_users_id_statistics_get_handler"
+	"github.com/ruslanonly/blindtyping/src/internal/api/handlers/admin_users_id_unban_post_handler"
 	"github.com/ruslanonly/blindtyping/src/internal/api/handlers/antifroad_key_get_handler"
 	"github.com/ruslanonly/blindtyping/src/internal/api/handlers/antifroad_rotate_keys_post_handler"
 	"github.com/ruslanonly/blindtyping/src/internal/api/handlers/auth_logout_post_handler"
@@ -11,51 +28,103 @@ import (
 	"github.com/ruslanonly/blindtyping/src/internal/api/handlers/auth_provider_get_handler"
 	"github.com/ruslanonly/blindtyping/src/internal/api/handlers/auth_refresh_post_handler"
 	"github.com/ruslanonly/blindtyping/src/internal/api/handlers/auth_register_post_handler"
+	"github.com/ruslanonly/blindtyping/src/internal/api/handlers/avatars_name_get_handler"
+	"github.com/ruslanonly/blindtyping/src/internal/api/handlers/healthz_get_handler"
+	"github.com/ruslanonly/blindtyping/src/internal/api/handlers/readyz_get_handler"
+	"github.com/ruslanonly/blindtyping/src/internal/api/handlers/users_me_antifroad_session_post_handler"
+	"github.com/ruslanonly/blindtyping/src/internal/api/handlers/users_me_audit_get_handler"
+	"github.com/ruslanonly/blindtyping/src/internal/api/handlers/users_me_avatar_put_handler"
+	"github.com/ruslanonly/blindtyping/src/internal/api/handlers/users_me_data_get_handler"
+	"github.com/ruslanonly/blindtyping/src/internal/api/handlers/users_me_delete_handler"
 	"github.com/ruslanonly/blindtyping/src/internal/api/handlers/users_me_get_handler"
+	"github.com/ruslanonly/blindtyping/src/internal/api/handlers/users_me_privacy_get_handler"
+	"github.com/ruslanonly/blindtyping/src/internal/api/handlers/users_me_privacy_patch_handler"
+	"github.com/ruslanonly/blindtyping/src/internal/api/handlers/users_me_profile_patch_handler"
 	"github.com/ruslanonly/blindtyping/src/internal/api/handlers/users_me_statistics_delete_handler"
 	"github.com/ruslanonly/blindtyping/src/internal/api/handlers/users_me_statistics_get_handler"
+	"github.com/ruslanonly/blindtyping/src/internal/api/handlers/users_me_statistics_id_delete_handler"
 	"github.com/ruslanonly/blindtyping/src/internal/api/handlers/users_me_statistics_post_handler"
+	"github.com/ruslanonly/blindtyping/src/internal/api/handlers/users_me_statistics_restore_post_handler"
+	"github.com/ruslanonly/blindtyping/src/internal/api/handlers/users_me_tests_post_handler"
+	"github.com/ruslanonly/blindtyping/src/internal/api/handlers/users_me_tokens_get_handler"
+	"github.com/ruslanonly/blindtyping/src/internal/api/handlers/users_me_tokens_id_delete_handler"
+	"github.com/ruslanonly/blindtyping/src/internal/api/handlers/users_me_tokens_post_handler"
 	"github.com/ruslanonly/blindtyping/src/internal/api/handlers/users_me_username_patch_handler"
 	"github.com/ruslanonly/blindtyping/src/internal/api/handlers/users_username_availability_get_handler"
 	"github.com/ruslanonly/blindtyping/src/internal/api/handlers/users_username_profile_get_handler"
+	"github.com/ruslanonly/blindtyping/src/internal/api/middleware"
+	"github.com/ruslanonly/blindtyping/src/internal/api/middleware/access_revocation_middleware"
+	"github.com/ruslanonly/blindtyping/src/internal/api/middleware/audit_middleware"
 	"github.com/ruslanonly/blindtyping/src/internal/api/middleware/auth_middleware"
+	"github.com/ruslanonly/blindtyping/src/internal/api/middleware/bearer_auth_middleware"
+	"github.com/ruslanonly/blindtyping/src/internal/api/middleware/client_ip_middleware"
+	"github.com/ruslanonly/blindtyping/src/internal/api/middleware/cors_middleware"
+	"github.com/ruslanonly/blindtyping/src/internal/api/middleware/optional_auth_middleware"
+	"github.com/ruslanonly/blindtyping/src/internal/api/middleware/rate_limit_middleware"
 	"github.com/ruslanonly/blindtyping/src/internal/api/middleware/refresh_token_middleware"
 	"github.com/ruslanonly/blindtyping/src/internal/api/middleware/registration_middleware"
 	"github.com/ruslanonly/blindtyping/src/internal/api/middleware/request_id_middleware"
+	"github.com/ruslanonly/blindtyping/src/internal/api/middleware/role_middleware"
+	"github.com/ruslanonly/blindtyping/src/internal/api/middleware/scope_middleware"
+	"github.com/ruslanonly/blindtyping/src/internal/api/middleware/service_auth_middleware"
+	"github.com/ruslanonly/blindtyping/src/internal/api/middleware/session_middleware"
+	"github.com/ruslanonly/blindtyping/src/internal/api/middleware/tracing_middleware"
+	"github.com/ruslanonly/blindtyping/src/internal/app/config"
+	"github.com/ruslanonly/blindtyping/src/internal/models"
+	"github.com/ruslanonly/blindtyping/src/internal/shared/metrics"
 	"github.com/ruslanonly/blindtyping/src/internal/shared/proto"
 	"github.com/ruslanonly/blindtyping/src/internal/shared/proto/swagger"
 )
 
 func (c *Container) Router() *proto.Router {
 	if c.router == nil {
-		corsConfig := c.cfg.CORS
 		swaggerConfig := c.cfg.Swagger
+		metricsConfig := c.cfg.Metrics
 
 		router := proto.NewRouter()
 
+		// Client IP
+		router.Use(client_ip_middleware.New(c.cfg.Server.TrustedProxies))
+
 		// CORS
-		router.Use(cors.New(cors.Config{
-			AllowAllOrigins:  corsConfig.AllowAllOrigins,
-			AllowOrigins:     corsConfig.AllowedOrigins,
-			AllowMethods:     corsConfig.AllowedMethods,
-			AllowHeaders:     corsConfig.AllowedHeaders,
-			AllowCredentials: corsConfig.AllowCredentials,
-			ExposeHeaders:    corsConfig.ExposedHeaders,
-		}))
+		router.Use(c.CORSMiddleware().Handle)
 
 		// RequestID
 		router.Use(request_id_middleware.New(c.Logger(), c.UUIDGenerator()))
 
+		// Tracing
+		router.Use(tracing_middleware.New(c.Tracing().Tracer(), c.Tracing().Propagator(), c.Logger()))
+
+		// Metrics
+		router.Use(c.Metrics().Middleware())
+
+		// Audit
+		router.Use(audit_middleware.New())
+
 		// Middlewares
 		router.Middleware(
 			c.AuthMiddleware(),
 			c.RegistrationMiddleware(),
 			c.RefreshTokenMiddleware(),
+			c.AccessRevocationMiddleware(),
+			c.SessionMiddleware(),
+			c.OptionalAuthMiddleware(),
+			c.StatisticsReadScopeMiddleware(),
+			c.StatisticsWriteScopeMiddleware(),
+			c.ProfileReadScopeMiddleware(),
+			c.ModeratorRoleMiddleware(),
+			c.AdminRoleMiddleware(),
+			c.ServiceAuthMiddleware(),
+			c.StatisticsWriteRateLimitMiddleware(),
+			c.UsernameCheckRateLimitMiddleware(),
+			c.OAuthBeginRateLimitMiddleware(),
+			c.AvatarUploadRateLimitMiddleware(),
 		)
 
 		// Handlers
 		router.Handle(
 			swagger.New(swaggerConfig.Login, swaggerConfig.Password),
+			metrics.NewHandler(c.Metrics(), metricsConfig.Login, metricsConfig.Password),
 			c.AuthLogoutPostHandler(),
 			c.AuthPingGetHandler(),
 			c.AuthProviderCallbackGetHandler(),
@@ -71,6 +140,40 @@ func (c *Container) Router() *proto.Router {
 			c.AntifroadKeyGetHandler(),
 			c.AntifroadRotateKeysPostHandler(),
 			c.UsersMeUsernamePatchHandler(),
+			c.UsersMeTokensPostHandler(),
+			c.UsersMeTokensGetHandler(),
+			c.UsersMeTokensIDDeleteHandler(),
+			c.AdminUsersGetHandler(),
+			c.AdminUsersIDGetHandler(),
+			c.AdminUsersIDStatisticsGetHandler(),
+			c.AdminUsersIDBanPostHandler(),
+			c.AdminUsersIDUnbanPostHandler(),
+			c.AdminUsersIDRolePatchHandler(),
+			c.AdminUsersIDSanctionsPostHandler(),
+			c.AdminUsersIDSanctionsGetHandler(),
+			c.AdminUsersIDSanctionsIDDeleteHandler(),
+			c.AdminReviewsGetHandler(),
+			c.AdminReviewsIDApprovePostHandler(),
+			c.AdminReviewsIDRejectPostHandler(),
+			c.AdminServiceClientsPostHandler(),
+			c.AdminServiceClientsGetHandler(),
+			c.AdminServiceClientsIDDeleteHandler(),
+			c.UsersMeAntifroadSessionPostHandler(),
+			c.AdminAnalyticsGetHandler(),
+			c.HealthzGetHandler(),
+			c.ReadyzGetHandler(),
+			c.UsersMeAuditGetHandler(),
+			c.AdminAuditGetHandler(),
+			c.UsersMeDataGetHandler(),
+			c.UsersMeDeleteHandler(),
+			c.UsersMeStatisticsIDDeleteHandler(),
+			c.UsersMeStatisticsRestorePostHandler(),
+			c.UsersMeProfilePatchHandler(),
+			c.UsersMeAvatarPutHandler(),
+			c.AvatarsNameGetHandler(),
+			c.UsersMePrivacyGetHandler(),
+			c.UsersMePrivacyPatchHandler(),
+			c.UsersMeTestsPostHandler(),
 		)
 
 		c.router = router
@@ -78,11 +181,33 @@ func (c *Container) Router() *proto.Router {
 	return c.router
 }
 
+func newCORSConfig(cfg config.CORS) cors.Config {
+	return cors.Config{
+		AllowAllOrigins:  cfg.AllowAllOrigins,
+		AllowOrigins:     cfg.AllowedOrigins,
+		AllowMethods:     cfg.AllowedMethods,
+		AllowHeaders:     cfg.AllowedHeaders,
+		AllowCredentials: cfg.AllowCredentials,
+		ExposeHeaders:    cfg.ExposedHeaders,
+	}
+}
+
+func (c *Container) CORSMiddleware() *cors_middleware.Middleware {
+	if c.corsMiddleware == nil {
+		c.corsMiddleware = cors_middleware.New(newCORSConfig(c.cfg.CORS))
+	}
+	return c.corsMiddleware
+}
+
 func (c *Container) AuthMiddleware() proto.Middleware {
 	if c.authMiddleware == nil {
-		c.authMiddleware = auth_middleware.New(
-			c.AuthService(),
-			c.CookieManager(),
+		c.authMiddleware = bearer_auth_middleware.New(
+			auth_middleware.New(
+				c.AuthService(),
+				c.CookieManager(),
+				c.Logger(),
+			),
+			c.PersonalTokenService(),
 			c.Logger(),
 		)
 	}
@@ -109,13 +234,178 @@ func (c *Container) RefreshTokenMiddleware() proto.Middleware {
 	return c.refreshTokenMiddleware
 }
 
+func (c *Container) AccessRevocationMiddleware() proto.Middleware {
+	if c.accessRevocationMiddleware == nil {
+		c.accessRevocationMiddleware = access_revocation_middleware.New(
+			c.TokenFamilyService(),
+			c.Logger(),
+		)
+	}
+	return c.accessRevocationMiddleware
+}
+
+func (c *Container) SessionMiddleware() proto.Middleware {
+	if c.sessionMiddleware == nil {
+		c.sessionMiddleware = session_middleware.New(c.Logger())
+	}
+	return c.sessionMiddleware
+}
+
+func (c *Container) OptionalAuthMiddleware() proto.Middleware {
+	if c.optionalAuthMiddleware == nil {
+		c.optionalAuthMiddleware = optional_auth_middleware.New(
+			c.Logger(),
+			c.AuthMiddleware(),
+			c.AccessRevocationMiddleware(),
+		)
+	}
+	return c.optionalAuthMiddleware
+}
+
+func (c *Container) StatisticsReadScopeMiddleware() proto.Middleware {
+	if c.statisticsReadScopeMiddleware == nil {
+		c.statisticsReadScopeMiddleware = scope_middleware.New(
+			middleware.StatisticsRead,
+			models.ScopeStatisticsRead,
+			c.Logger(),
+		)
+	}
+	return c.statisticsReadScopeMiddleware
+}
+
+func (c *Container) StatisticsWriteScopeMiddleware() proto.Middleware {
+	if c.statisticsWriteScopeMiddleware == nil {
+		c.statisticsWriteScopeMiddleware = scope_middleware.New(
+			middleware.StatisticsWrite,
+			models.ScopeStatisticsWrite,
+			c.Logger(),
+		)
+	}
+	return c.statisticsWriteScopeMiddleware
+}
+
+func (c *Container) ProfileReadScopeMiddleware() proto.Middleware {
+	if c.profileReadScopeMiddleware == nil {
+		c.profileReadScopeMiddleware = scope_middleware.New(
+			middleware.ProfileRead,
+			models.ScopeProfileRead,
+			c.Logger(),
+		)
+	}
+	return c.profileReadScopeMiddleware
+}
+
+func (c *Container) ModeratorRoleMiddleware() proto.Middleware {
+	if c.moderatorRoleMiddleware == nil {
+		c.moderatorRoleMiddleware = role_middleware.New(
+			middleware.Moderator,
+			models.RoleModerator,
+			c.AdminService(),
+			c.Logger(),
+		)
+	}
+	return c.moderatorRoleMiddleware
+}
+
+func (c *Container) AdminRoleMiddleware() proto.Middleware {
+	if c.adminRoleMiddleware == nil {
+		c.adminRoleMiddleware = role_middleware.New(
+			middleware.Admin,
+			models.RoleAdmin,
+			c.AdminService(),
+			c.Logger(),
+		)
+	}
+	return c.adminRoleMiddleware
+}
+
+func (c *Container) ServiceAuthMiddleware() proto.Middleware {
+	if c.serviceAuthMiddleware == nil {
+		cfg := c.cfg.ServiceAuth
+		c.serviceAuthMiddleware = service_auth_middleware.New(
+			c.ServiceClientService(),
+			c.RateLimitRepository(),
+			c.Logger(),
+			cfg.RateLimit,
+			cfg.RateLimitPeriod.Duration,
+			cfg.MaxBodySize,
+		)
+	}
+	return c.serviceAuthMiddleware
+}
+
+func (c *Container) StatisticsWriteRateLimitMiddleware() *rate_limit_middleware.Middleware {
+	if c.statisticsWriteRateLimitMiddleware == nil {
+		cfg := c.cfg.RateLimits.StatisticsWrite
+		c.statisticsWriteRateLimitMiddleware = rate_limit_middleware.New(
+			middleware.StatisticsWriteRateLimit,
+			rate_limit_middleware.ByUser,
+			c.RateLimitRepository(),
+			c.Logger(),
+			cfg.Limit,
+			cfg.Period.Duration,
+		)
+	}
+	return c.statisticsWriteRateLimitMiddleware
+}
+
+func (c *Container) UsernameCheckRateLimitMiddleware() *rate_limit_middleware.Middleware {
+	if c.usernameCheckRateLimitMiddleware == nil {
+		cfg := c.cfg.RateLimits.UsernameCheck
+		c.usernameCheckRateLimitMiddleware = rate_limit_middleware.New(
+			middleware.UsernameCheckRateLimit,
+			rate_limit_middleware.ByIP,
+			c.RateLimitRepository(),
+			c.Logger(),
+			cfg.Limit,
+			cfg.Period.Duration,
+		)
+	}
+	return c.usernameCheckRateLimitMiddleware
+}
+
+func (c *Container) OAuthBeginRateLimitMiddleware() *rate_limit_middleware.Middleware {
+	if c.oauthBeginRateLimitMiddleware == nil {
+		cfg := c.cfg.RateLimits.OAuthBegin
+		c.oauthBeginRateLimitMiddleware = rate_limit_middleware.New(
+			middleware.OAuthBeginRateLimit,
+			rate_limit_middleware.ByIP,
+			c.RateLimitRepository(),
+			c.Logger(),
+			cfg.Limit,
+			cfg.Period.Duration,
+		)
+	}
+	return c.oauthBeginRateLimitMiddleware
+}
+
+func (c *Container) AvatarUploadRateLimitMiddleware() *rate_limit_middleware.Middleware {
+	if c.avatarUploadRateLimitMiddleware == nil {
+		cfg := c.cfg.RateLimits.AvatarUpload
+		c.avatarUploadRateLimitMiddleware = rate_limit_middleware.New(
+			middleware.AvatarUploadRateLimit,
+			rate_limit_middleware.ByUser,
+			c.RateLimitRepository(),
+			c.Logger(),
+			cfg.Limit,
+			cfg.Period.Duration,
+		)
+	}
+	return c.avatarUploadRateLimitMiddleware
+}
+
 func (c *Container) AuthProviderCallbackGetHandler() *auth_provider_callback_post_handler.Handler {
 	if c.authProviderCallbackGetHandler == nil {
 		cfg := c.cfg.Auth
 		c.authProviderCallbackGetHandler = auth_provider_callback_post_handler.New(
 			c.AuthService(),
+			c.TokenFamilyService(),
+			c.AdminService(),
+			c.AccountService(),
 			c.OAuth(),
 			c.CookieManager(),
+			c.AuditService(),
+			c.ProfileDetailsService(),
 			c.Logger(),
 			cfg.LoggedInRedirectURL,
 			cfg.RegistrationRedirectURL,
@@ -141,6 +431,7 @@ func (c *Container) AuthLogoutPostHandler() *auth_logout_post_handler.Handler {
 			c.AuthService(),
 			c.CookieManager(),
 			c.OAuth(),
+			c.AuditService(),
 			c.Logger(),
 		)
 	}
@@ -151,6 +442,7 @@ func (c *Container) AuthRefreshPostHandler() *auth_refresh_post_handler.Handler
 	if c.authRefreshPostHandler == nil {
 		c.authRefreshPostHandler = auth_refresh_post_handler.New(
 			c.AuthService(),
+			c.TokenFamilyService(),
 			c.CookieManager(),
 			c.Logger(),
 		)
@@ -162,7 +454,12 @@ func (c *Container) AuthRegisterPostHandler() *auth_register_post_handler.Handle
 	if c.authRegisterPostHandler == nil {
 		c.authRegisterPostHandler = auth_register_post_handler.New(
 			c.AuthService(),
+			c.UsernameService(),
+			c.TokenFamilyService(),
 			c.CookieManager(),
+			c.Metrics(),
+			c.AnalyticsService(),
+			c.AuditService(),
 			c.Logger(),
 		)
 	}
@@ -172,7 +469,7 @@ func (c *Container) AuthRegisterPostHandler() *auth_register_post_handler.Handle
 func (c *Container) UsersUsernameAvailabilityGetHandler() *users_username_availability_get_handler.Handler {
 	if c.usersUsernameAvailabilityGetHandler == nil {
 		c.usersUsernameAvailabilityGetHandler = users_username_availability_get_handler.New(
-			c.AuthService(),
+			c.UsernameService(),
 			c.Logger(),
 		)
 	}
@@ -190,7 +487,12 @@ func (c *Container) UsersMeStatisticsPostHandler() *users_me_statistics_post_han
 	if c.usersMeStatisticsPostHandler == nil {
 		c.usersMeStatisticsPostHandler = users_me_statistics_post_handler.New(
 			c.StatisticsService(),
+			c.StatisticsReviewService(),
+			c.AntifroadKeyService(),
+			c.Metrics(),
+			c.AnalyticsService(),
 			c.Logger(),
+			c.cfg.Antifroad.Biometrics.MaxKeystrokes,
 		)
 	}
 	return c.usersMeStatisticsPostHandler
@@ -210,7 +512,7 @@ func (c *Container) UsersMeStatisticsDeleteHandler() *users_me_statistics_delete
 	if c.usersMeStatisticsDeleteHandler == nil {
 		c.usersMeStatisticsDeleteHandler = users_me_statistics_delete_handler.New(
 			c.Logger(),
-			c.StatisticsService(),
+			c.StatisticsDeletionService(),
 		)
 	}
 	return c.usersMeStatisticsDeleteHandler
@@ -219,7 +521,9 @@ func (c *Container) UsersMeStatisticsDeleteHandler() *users_me_statistics_delete
 func (c *Container) UsersUsernameProfileGetHandler() *users_username_profile_get_handler.Handler {
 	if c.usersUsernameProfileGetHandler == nil {
 		c.usersUsernameProfileGetHandler = users_username_profile_get_handler.New(
-			c.ProfileService(),
+			c.ProfilePrivacyService(),
+			c.ProfileDetailsService(),
+			c.UsernameService(),
 			c.Logger(),
 		)
 	}
@@ -239,7 +543,7 @@ func (c *Container) UsersMeGetHandler() *users_me_get_handler.Handler {
 func (c *Container) AntifroadKeyGetHandler() *antifroad_key_get_handler.Handler {
 	if c.antifroadKeyGetHandler == nil {
 		c.antifroadKeyGetHandler = antifroad_key_get_handler.New(
-			c.AntifroadService(),
+			c.AntifroadKeyService(),
 			c.Logger(),
 		)
 	}
@@ -249,7 +553,7 @@ func (c *Container) AntifroadKeyGetHandler() *antifroad_key_get_handler.Handler
 func (c *Container) AntifroadRotateKeysPostHandler() *antifroad_rotate_keys_post_handler.Handler {
 	if c.antifroadRotateKeysPostHandler == nil {
 		c.antifroadRotateKeysPostHandler = antifroad_rotate_keys_post_handler.New(
-			c.AntifroadService(),
+			c.AntifroadKeyService(),
 			c.Logger(),
 		)
 	}
@@ -260,8 +564,348 @@ func (c *Container) UsersMeUsernamePatchHandler() *users_me_username_patch_handl
 	if c.usersMeUsernamePatchHandler == nil {
 		c.usersMeUsernamePatchHandler = users_me_username_patch_handler.New(
 			c.Logger(),
-			c.UserService(),
+			c.UsernameService(),
+			c.AnalyticsService(),
 		)
 	}
 	return c.usersMeUsernamePatchHandler
 }
+
+func (c *Container) UsersMeTokensPostHandler() *users_me_tokens_post_handler.Handler {
+	if c.usersMeTokensPostHandler == nil {
+		c.usersMeTokensPostHandler = users_me_tokens_post_handler.New(
+			c.PersonalTokenService(),
+			c.Logger(),
+		)
+	}
+	return c.usersMeTokensPostHandler
+}
+
+func (c *Container) UsersMeTokensGetHandler() *users_me_tokens_get_handler.Handler {
+	if c.usersMeTokensGetHandler == nil {
+		c.usersMeTokensGetHandler = users_me_tokens_get_handler.New(
+			c.PersonalTokenService(),
+			c.Logger(),
+		)
+	}
+	return c.usersMeTokensGetHandler
+}
+
+func (c *Container) UsersMeTokensIDDeleteHandler() *users_me_tokens_id_delete_handler.Handler {
+	if c.usersMeTokensIDDeleteHandler == nil {
+		c.usersMeTokensIDDeleteHandler = users_me_tokens_id_delete_handler.New(
+			c.PersonalTokenService(),
+			c.Logger(),
+		)
+	}
+	return c.usersMeTokensIDDeleteHandler
+}
+
+func (c *Container) AdminUsersGetHandler() *admin_users_get_handler.Handler {
+	if c.adminUsersGetHandler == nil {
+		c.adminUsersGetHandler = admin_users_get_handler.New(
+			c.AdminService(),
+			c.Logger(),
+		)
+	}
+	return c.adminUsersGetHandler
+}
+
+func (c *Container) AdminUsersIDGetHandler() *admin_users_id_get_handler.Handler {
+	if c.adminUsersIDGetHandler == nil {
+		c.adminUsersIDGetHandler = admin_users_id_get_handler.New(
+			c.AdminService(),
+			c.Logger(),
+		)
+	}
+	return c.adminUsersIDGetHandler
+}
+
+func (c *Container) AdminUsersIDStatisticsGetHandler() *admin_users_id_statistics_get_handler.Handler {
+	if c.adminUsersIDStatisticsGetHandler == nil {
+		c.adminUsersIDStatisticsGetHandler = admin_users_id_statistics_get_handler.New(
+			c.StatisticsService(),
+			c.Logger(),
+		)
+	}
+	return c.adminUsersIDStatisticsGetHandler
+}
+
+func (c *Container) AdminUsersIDBanPostHandler() *admin_users_id_ban_post_handler.Handler {
+	if c.adminUsersIDBanPostHandler == nil {
+		c.adminUsersIDBanPostHandler = admin_users_id_ban_post_handler.New(
+			c.AdminService(),
+			c.Logger(),
+		)
+	}
+	return c.adminUsersIDBanPostHandler
+}
+
+func (c *Container) AdminUsersIDUnbanPostHandler() *admin_users_id_unban_post_handler.Handler {
+	if c.adminUsersIDUnbanPostHandler == nil {
+		c.adminUsersIDUnbanPostHandler = admin_users_id_unban_post_handler.New(
+			c.AdminService(),
+			c.Logger(),
+		)
+	}
+	return c.adminUsersIDUnbanPostHandler
+}
+
+func (c *Container) AdminUsersIDRolePatchHandler() *admin_users_id_role_patch_handler.Handler {
+	if c.adminUsersIDRolePatchHandler == nil {
+		c.adminUsersIDRolePatchHandler = admin_users_id_role_patch_handler.New(
+			c.AdminService(),
+			c.Logger(),
+		)
+	}
+	return c.adminUsersIDRolePatchHandler
+}
+
+func (c *Container) AdminUsersIDSanctionsPostHandler() *admin_users_id_sanctions_post_handler.Handler {
+	if c.adminUsersIDSanctionsPostHandler == nil {
+		c.adminUsersIDSanctionsPostHandler = admin_users_id_sanctions_post_handler.New(
+			c.AdminService(),
+			c.Logger(),
+		)
+	}
+	return c.adminUsersIDSanctionsPostHandler
+}
+
+func (c *Container) AdminUsersIDSanctionsGetHandler() *admin_users_id_sanctions_get_handler.Handler {
+	if c.adminUsersIDSanctionsGetHandler == nil {
+		c.adminUsersIDSanctionsGetHandler = admin_users_id_sanctions_get_handler.New(
+			c.AdminService(),
+			c.Logger(),
+		)
+	}
+	return c.adminUsersIDSanctionsGetHandler
+}
+
+func (c *Container) AdminUsersIDSanctionsIDDeleteHandler() *admin_users_id_sanctions_id_delete_handler.Handler {
+	if c.adminUsersIDSanctionsIDDeleteHandler == nil {
+		c.adminUsersIDSanctionsIDDeleteHandler = admin_users_id_sanctions_id_delete_handler.New(
+			c.AdminService(),
+			c.Logger(),
+		)
+	}
+	return c.adminUsersIDSanctionsIDDeleteHandler
+}
+
+func (c *Container) AdminReviewsGetHandler() *admin_reviews_get_handler.Handler {
+	if c.adminReviewsGetHandler == nil {
+		c.adminReviewsGetHandler = admin_reviews_get_handler.New(
+			c.StatisticsReviewService(),
+			c.Logger(),
+		)
+	}
+	return c.adminReviewsGetHandler
+}
+
+func (c *Container) AdminReviewsIDApprovePostHandler() *admin_reviews_id_approve_post_handler.Handler {
+	if c.adminReviewsIDApprovePostHandler == nil {
+		c.adminReviewsIDApprovePostHandler = admin_reviews_id_approve_post_handler.New(
+			c.StatisticsReviewService(),
+			c.Logger(),
+		)
+	}
+	return c.adminReviewsIDApprovePostHandler
+}
+
+func (c *Container) AdminReviewsIDRejectPostHandler() *admin_reviews_id_reject_post_handler.Handler {
+	if c.adminReviewsIDRejectPostHandler == nil {
+		c.adminReviewsIDRejectPostHandler = admin_reviews_id_reject_post_handler.New(
+			c.StatisticsReviewService(),
+			c.Logger(),
+		)
+	}
+	return c.adminReviewsIDRejectPostHandler
+}
+
+func (c *Container) AdminServiceClientsPostHandler() *admin_service_clients_post_handler.Handler {
+	if c.adminServiceClientsPostHandler == nil {
+		c.adminServiceClientsPostHandler = admin_service_clients_post_handler.New(
+			c.ServiceClientService(),
+			c.Logger(),
+		)
+	}
+	return c.adminServiceClientsPostHandler
+}
+
+func (c *Container) AdminServiceClientsGetHandler() *admin_service_clients_get_handler.Handler {
+	if c.adminServiceClientsGetHandler == nil {
+		c.adminServiceClientsGetHandler = admin_service_clients_get_handler.New(
+			c.ServiceClientService(),
+			c.Logger(),
+		)
+	}
+	return c.adminServiceClientsGetHandler
+}
+
+func (c *Container) AdminServiceClientsIDDeleteHandler() *admin_service_clients_id_delete_handler.Handler {
+	if c.adminServiceClientsIDDeleteHandler == nil {
+		c.adminServiceClientsIDDeleteHandler = admin_service_clients_id_delete_handler.New(
+			c.ServiceClientService(),
+			c.Logger(),
+		)
+	}
+	return c.adminServiceClientsIDDeleteHandler
+}
+
+func (c *Container) UsersMeAntifroadSessionPostHandler() *users_me_antifroad_session_post_handler.Handler {
+	if c.usersMeAntifroadSessionPostHandler == nil {
+		c.usersMeAntifroadSessionPostHandler = users_me_antifroad_session_post_handler.New(
+			c.AntifroadKeyService(),
+			c.Logger(),
+		)
+	}
+	return c.usersMeAntifroadSessionPostHandler
+}
+
+func (c *Container) AdminAnalyticsGetHandler() *admin_analytics_get_handler.Handler {
+	if c.adminAnalyticsGetHandler == nil {
+		c.adminAnalyticsGetHandler = admin_analytics_get_handler.New(
+			c.AnalyticsService(),
+			c.Logger(),
+		)
+	}
+	return c.adminAnalyticsGetHandler
+}
+
+func (c *Container) HealthzGetHandler() *healthz_get_handler.Handler {
+	if c.healthzGetHandler == nil {
+		c.healthzGetHandler = healthz_get_handler.New()
+	}
+	return c.healthzGetHandler
+}
+
+func (c *Container) ReadyzGetHandler() *readyz_get_handler.Handler {
+	if c.readyzGetHandler == nil {
+		c.readyzGetHandler = readyz_get_handler.New(
+			c.HealthService(),
+			c.Logger(),
+		)
+	}
+	return c.readyzGetHandler
+}
+
+func (c *Container) UsersMeAuditGetHandler() *users_me_audit_get_handler.Handler {
+	if c.usersMeAuditGetHandler == nil {
+		c.usersMeAuditGetHandler = users_me_audit_get_handler.New(
+			c.AuditService(),
+			c.Logger(),
+		)
+	}
+	return c.usersMeAuditGetHandler
+}
+
+func (c *Container) AdminAuditGetHandler() *admin_audit_get_handler.Handler {
+	if c.adminAuditGetHandler == nil {
+		c.adminAuditGetHandler = admin_audit_get_handler.New(
+			c.AuditService(),
+			c.Logger(),
+		)
+	}
+	return c.adminAuditGetHandler
+}
+
+func (c *Container) UsersMeDataGetHandler() *users_me_data_get_handler.Handler {
+	if c.usersMeDataGetHandler == nil {
+		c.usersMeDataGetHandler = users_me_data_get_handler.New(
+			c.AccountService(),
+			c.Logger(),
+		)
+	}
+	return c.usersMeDataGetHandler
+}
+
+func (c *Container) UsersMeDeleteHandler() *users_me_delete_handler.Handler {
+	if c.usersMeDeleteHandler == nil {
+		c.usersMeDeleteHandler = users_me_delete_handler.New(
+			c.AccountService(),
+			c.CookieManager(),
+			c.Logger(),
+		)
+	}
+	return c.usersMeDeleteHandler
+}
+
+func (c *Container) UsersMeStatisticsIDDeleteHandler() *users_me_statistics_id_delete_handler.Handler {
+	if c.usersMeStatisticsIDDeleteHandler == nil {
+		c.usersMeStatisticsIDDeleteHandler = users_me_statistics_id_delete_handler.New(
+			c.StatisticsDeletionService(),
+			c.Logger(),
+		)
+	}
+	return c.usersMeStatisticsIDDeleteHandler
+}
+
+func (c *Container) UsersMeStatisticsRestorePostHandler() *users_me_statistics_restore_post_handler.Handler {
+	if c.usersMeStatisticsRestorePostHandler == nil {
+		c.usersMeStatisticsRestorePostHandler = users_me_statistics_restore_post_handler.New(
+			c.StatisticsDeletionService(),
+			c.Logger(),
+		)
+	}
+	return c.usersMeStatisticsRestorePostHandler
+}
+
+func (c *Container) UsersMeProfilePatchHandler() *users_me_profile_patch_handler.Handler {
+	if c.usersMeProfilePatchHandler == nil {
+		c.usersMeProfilePatchHandler = users_me_profile_patch_handler.New(
+			c.ProfileDetailsService(),
+			c.Logger(),
+		)
+	}
+	return c.usersMeProfilePatchHandler
+}
+
+func (c *Container) UsersMeAvatarPutHandler() *users_me_avatar_put_handler.Handler {
+	if c.usersMeAvatarPutHandler == nil {
+		c.usersMeAvatarPutHandler = users_me_avatar_put_handler.New(
+			c.ProfileDetailsService(),
+			c.Logger(),
+			c.cfg.Avatars.MaxUploadSize,
+		)
+	}
+	return c.usersMeAvatarPutHandler
+}
+
+func (c *Container) AvatarsNameGetHandler() *avatars_name_get_handler.Handler {
+	if c.avatarsNameGetHandler == nil {
+		c.avatarsNameGetHandler = avatars_name_get_handler.New(
+			c.ProfileDetailsService(),
+			c.Logger(),
+		)
+	}
+	return c.avatarsNameGetHandler
+}
+
+func (c *Container) UsersMePrivacyGetHandler() *users_me_privacy_get_handler.Handler {
+	if c.usersMePrivacyGetHandler == nil {
+		c.usersMePrivacyGetHandler = users_me_privacy_get_handler.New(
+			c.ProfilePrivacyService(),
+			c.Logger(),
+		)
+	}
+	return c.usersMePrivacyGetHandler
+}
+
+func (c *Container) UsersMePrivacyPatchHandler() *users_me_privacy_patch_handler.Handler {
+	if c.usersMePrivacyPatchHandler == nil {
+		c.usersMePrivacyPatchHandler = users_me_privacy_patch_handler.New(
+			c.ProfilePrivacyService(),
+			c.Logger(),
+		)
+	}
+	return c.usersMePrivacyPatchHandler
+}
+
+func (c *Container) UsersMeTestsPostHandler() *users_me_tests_post_handler.Handler {
+	if c.usersMeTestsPostHandler == nil {
+		c.usersMeTestsPostHandler = users_me_tests_post_handler.New(
+			c.AnalyticsService(),
+			c.Logger(),
+		)
+	}
+	return c.usersMeTestsPostHandler
+}
@@ -1,10 +1,32 @@
 package di
 
 import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
 	"github.com/robfig/cron"
 
 	"github.com/ruslanonly/blindtyping/src/internal"
 	"github.com/ruslanonly/blindtyping/src/internal/api/cookie"
+	"github.com/ruslanonly/blindtyping/src/internal/api/handlers/admin_analytics_get_handler"
+	"github.com/ruslanonly/blindtyping/src/internal/api/handlers/admin_audit_get_handler"
+	"github.com/ruslanonly/blindtyping/src/internal/api/handlers/admin_reviews_get_handler"
+	"github.com/ruslanonly/blindtyping/src/internal/api/handlers/admin_reviews_id_approve_post_handler"
+	"github.com/ruslanonly/blindtyping/src/internal/api/handlers/admin_reviews_id_reject_post_handler"
+	"github.com/ruslanonly/blindtyping/src/internal/api/handlers/admin_service_clients_get_handler"
+	"github.com/ruslanonly/blindtyping/src/internal/api/handlers/admin_service_clients_id_delete_handler"
+	"github.com/ruslanonly/blindtyping/src/internal/api/handlers/admin_service_clients_post_handler"
+	"github.com/ruslanonly/blindtyping/src/internal/api/handlers/admin_users_get_handler"
+	"github.com/ruslanonly/blindtyping/src/internal/api/handlers/admin_users_id_ban_post_handler"
+	"github.com/ruslanonly/blindtyping/src/internal/api/handlers/admin_users_id_get_handler"
+	"github.com/ruslanonly/blindtyping/src/internal/api/handlers/admin_users_id_role_patch_handler"
+	"github.com/ruslanonly/blindtyping/src/internal/api/handlers/admin_users_id_sanctions_get_handler"
+	"github.com/ruslanonly/blindtyping/src/internal/api/handlers/admin_users_id_sanctions_id_delete_handler"
+	"github.com/ruslanonly/blindtyping/src/internal/api/handlers/admin_users_id_sanctions_post_handler"
+	"github.com/ruslanonly/blindtyping/src/internal/api/handlers/admin_users_id_statistics_get_handler"
+	"github.com/ruslanonly/blindtyping/src/internal/api/handlers/admin_users_id_unban_post_handler"
 	"github.com/ruslanonly/blindtyping/src/internal/api/handlers/antifroad_key_get_handler"
 	"github.com/ruslanonly/blindtyping/src/internal/api/handlers/antifroad_rotate_keys_post_handler"
 	"github.com/ruslanonly/blindtyping/src/internal/api/handlers/auth_logout_post_handler"
@@ -13,91 +35,252 @@ import (
 	"github.com/ruslanonly/blindtyping/src/internal/api/handlers/auth_provider_get_handler"
 	"github.com/ruslanonly/blindtyping/src/internal/api/handlers/auth_refresh_post_handler"
 	"github.com/ruslanonly/blindtyping/src/internal/api/handlers/auth_register_post_handler"
+	"github.com/ruslanonly/blindtyping/src/internal/api/handlers/avatars_name_get_handler"
+	"github.com/ruslanonly/blindtyping/src/internal/api/handlers/healthz_get_handler"
+	"github.com/ruslanonly/blindtyping/src/internal/api/handlers/readyz_get_handler"
+	"github.com/ruslanonly/blindtyping/src/internal/api/handlers/users_me_antifroad_session_post_handler"
+	"github.com/ruslanonly/blindtyping/src/internal/api/handlers/users_me_audit_get_handler"
+	"github.com/ruslanonly/blindtyping/src/internal/api/handlers/users_me_avatar_put_handler"
+	"github.com/ruslanonly/blindtyping/src/internal/api/handlers/users_me_data_get_handler"
+	"github.com/ruslanonly/blindtyping/src/internal/api/handlers/users_me_delete_handler"
 	"github.com/ruslanonly/blindtyping/src/internal/api/handlers/users_me_get_handler"
+	"github.com/ruslanonly/blindtyping/src/internal/api/handlers/users_me_privacy_get_handler"
+	"github.com/ruslanonly/blindtyping/src/internal/api/handlers/users_me_privacy_patch_handler"
+	"github.com/ruslanonly/blindtyping/src/internal/api/handlers/users_me_profile_patch_handler"
 	"github.com/ruslanonly/blindtyping/src/internal/api/handlers/users_me_statistics_delete_handler"
 	"github.com/ruslanonly/blindtyping/src/internal/api/handlers/users_me_statistics_get_handler"
+	"github.com/ruslanonly/blindtyping/src/internal/api/handlers/users_me_statistics_id_delete_handler"
 	"github.com/ruslanonly/blindtyping/src/internal/api/handlers/users_me_statistics_post_handler"
+	"github.com/ruslanonly/blindtyping/src/internal/api/handlers/users_me_statistics_restore_post_handler"
+	"github.com/ruslanonly/blindtyping/src/internal/api/handlers/users_me_tokens_get_handler"
+	"github.com/ruslanonly/blindtyping/src/internal/api/handlers/users_me_tokens_id_delete_handler"
+	"github.com/ruslanonly/blindtyping/src/internal/api/handlers/users_me_tokens_post_handler"
 	"github.com/ruslanonly/blindtyping/src/internal/api/handlers/users_me_username_patch_handler"
 	"github.com/ruslanonly/blindtyping/src/internal/api/handlers/users_username_availability_get_handler"
 	"github.com/ruslanonly/blindtyping/src/internal/api/handlers/users_username_profile_get_handler"
+	"github.com/ruslanonly/blindtyping/src/internal/api/middleware/cors_middleware"
+	"github.com/ruslanonly/blindtyping/src/internal/api/middleware/rate_limit_middleware"
 	"github.com/ruslanonly/blindtyping/src/internal/app/config"
+	"github.com/ruslanonly/blindtyping/src/internal/repositories/access_revocation_repository"
+	"github.com/ruslanonly/blindtyping/src/internal/repositories/account_export_repository"
+	"github.com/ruslanonly/blindtyping/src/internal/repositories/analytics_event_repository"
 	"github.com/ruslanonly/blindtyping/src/internal/repositories/antifroad_key_repository"
+	"github.com/ruslanonly/blindtyping/src/internal/repositories/antifroad_key_version_repository"
+	"github.com/ruslanonly/blindtyping/src/internal/repositories/antifroad_session_repository"
+	"github.com/ruslanonly/blindtyping/src/internal/repositories/audit_event_repository"
 	"github.com/ruslanonly/blindtyping/src/internal/repositories/blocked_token_repository"
+	"github.com/ruslanonly/blindtyping/src/internal/repositories/keystroke_profile_repository"
 	"github.com/ruslanonly/blindtyping/src/internal/repositories/language_repository"
+	"github.com/ruslanonly/blindtyping/src/internal/repositories/migration_repository"
 	"github.com/ruslanonly/blindtyping/src/internal/repositories/pb_cache"
+	"github.com/ruslanonly/blindtyping/src/internal/repositories/personal_token_repository"
+	"github.com/ruslanonly/blindtyping/src/internal/repositories/profile_cache_repository"
+	"github.com/ruslanonly/blindtyping/src/internal/repositories/profile_details_repository"
+	"github.com/ruslanonly/blindtyping/src/internal/repositories/profile_privacy_repository"
 	"github.com/ruslanonly/blindtyping/src/internal/repositories/profiles_repository"
+	"github.com/ruslanonly/blindtyping/src/internal/repositories/rate_limit_repository"
+	"github.com/ruslanonly/blindtyping/src/internal/repositories/sanction_repository"
+	"github.com/ruslanonly/blindtyping/src/internal/repositories/service_client_repository"
+	"github.com/ruslanonly/blindtyping/src/internal/repositories/service_nonce_repository"
+	"github.com/ruslanonly/blindtyping/src/internal/repositories/session_family_repository"
 	"github.com/ruslanonly/blindtyping/src/internal/repositories/session_repository"
+	"github.com/ruslanonly/blindtyping/src/internal/repositories/statistics_deletion_repository"
 	"github.com/ruslanonly/blindtyping/src/internal/repositories/statistics_repository"
+	"github.com/ruslanonly/blindtyping/src/internal/repositories/statistics_review_repository"
+	"github.com/ruslanonly/blindtyping/src/internal/repositories/test_run_repository"
+	"github.com/ruslanonly/blindtyping/src/internal/repositories/user_account_repository"
 	"github.com/ruslanonly/blindtyping/src/internal/repositories/user_repository"
+	"github.com/ruslanonly/blindtyping/src/internal/repositories/username_history_repository"
+	"github.com/ruslanonly/blindtyping/src/internal/scheduler/handlers/antifroad_key_rotate_handler"
 	"github.com/ruslanonly/blindtyping/src/internal/scheduler/handlers/antifroad_rotate_keys_handler"
+	"github.com/ruslanonly/blindtyping/src/internal/scheduler/handlers/expired_sanctions_handler"
+	"github.com/ruslanonly/blindtyping/src/internal/scheduler/handlers/expired_session_families_handler"
 	"github.com/ruslanonly/blindtyping/src/internal/scheduler/handlers/expired_sessions_handler"
+	"github.com/ruslanonly/blindtyping/src/internal/scheduler/handlers/scheduled_accounts_deletion_handler"
+	"github.com/ruslanonly/blindtyping/src/internal/scheduler/handlers/stale_tests_handler"
+	"github.com/ruslanonly/blindtyping/src/internal/services/account_service"
+	"github.com/ruslanonly/blindtyping/src/internal/services/admin_service"
+	"github.com/ruslanonly/blindtyping/src/internal/services/analytics_service"
+	"github.com/ruslanonly/blindtyping/src/internal/services/antifroad_key_service"
 	"github.com/ruslanonly/blindtyping/src/internal/services/antifroad_service"
+	"github.com/ruslanonly/blindtyping/src/internal/services/audit_service"
 	"github.com/ruslanonly/blindtyping/src/internal/services/auth_service"
+	"github.com/ruslanonly/blindtyping/src/internal/services/health_service"
 	"github.com/ruslanonly/blindtyping/src/internal/services/pb_service"
+	"github.com/ruslanonly/blindtyping/src/internal/services/personal_token_service"
+	"github.com/ruslanonly/blindtyping/src/internal/services/profile_details_service"
+	"github.com/ruslanonly/blindtyping/src/internal/services/profile_privacy_service"
 	"github.com/ruslanonly/blindtyping/src/internal/services/profile_service"
+	"github.com/ruslanonly/blindtyping/src/internal/services/service_client_service"
 	"github.com/ruslanonly/blindtyping/src/internal/services/session_service"
+	"github.com/ruslanonly/blindtyping/src/internal/services/statistics_deletion_service"
+	"github.com/ruslanonly/blindtyping/src/internal/services/statistics_review_service"
 	"github.com/ruslanonly/blindtyping/src/internal/services/statistics_service"
+	"github.com/ruslanonly/blindtyping/src/internal/services/token_family_service"
 	"github.com/ruslanonly/blindtyping/src/internal/services/user_service"
+	"github.com/ruslanonly/blindtyping/src/internal/services/username_service"
+	"github.com/ruslanonly/blindtyping/src/internal/shared/logger"
+	"github.com/ruslanonly/blindtyping/src/internal/shared/metrics"
 	"github.com/ruslanonly/blindtyping/src/internal/shared/oauth"
 	"github.com/ruslanonly/blindtyping/src/internal/shared/postgres"
 	"github.com/ruslanonly/blindtyping/src/internal/shared/proto"
 	"github.com/ruslanonly/blindtyping/src/internal/shared/redis"
+	"github.com/ruslanonly/blindtyping/src/internal/shared/storage"
+	"github.com/ruslanonly/blindtyping/src/internal/shared/tracing"
 	"github.com/ruslanonly/blindtyping/src/internal/shared/uuid_generator"
 )
 
 type Container struct {
-	logger        internal.Logger
-	cfg           *config.Config
-	postgres      *postgres.Database
-	redis         *redis.Client
-	uuidGenerator *uuid_generator.Generator
-	oauth         *oauth.OAuth
-	cookieManager *cookie.Manager
+	logger           internal.Logger
+	structuredLogger *logger.Logger
+	cfg              *config.Config
+	postgres         *postgres.Database
+	redis            *redis.Client
+	uuidGenerator    *uuid_generator.Generator
+	oauth            *oauth.OAuth
+	cookieManager    *cookie.Manager
+	metrics          *metrics.Metrics
+	tracing          *tracing.Tracing
+	tracedPostgres   *tracing.Postgres
+	avatarStorage    storage.Storage
+	configReloader   *config.Reloader
 	// Repositories
-	userRepository         *user_repository.Repository
-	sessionRepository      *session_repository.Repository
-	blockedTokenRepository *blocked_token_repository.Repository
-	statisticsRepository   *statistics_repository.Repository
-	profileRepository      *profiles_repository.Repository
-	pbCache                *pb_cache.Cache
-	languageRepository     *language_repository.Repository
-	antifroadKeyRepository *antifroad_key_repository.Repository
+	userRepository                *user_repository.Repository
+	sessionRepository             *session_repository.Repository
+	blockedTokenRepository        *blocked_token_repository.Repository
+	statisticsRepository          *statistics_repository.Repository
+	profileRepository             *profiles_repository.Repository
+	pbCache                       *pb_cache.Cache
+	languageRepository            *language_repository.Repository
+	antifroadKeyRepository        *antifroad_key_repository.Repository
+	sessionFamilyRepository       *session_family_repository.Repository
+	accessRevocationRepository    *access_revocation_repository.Repository
+	personalTokenRepository       *personal_token_repository.Repository
+	userAccountRepository         *user_account_repository.Repository
+	sanctionRepository            *sanction_repository.Repository
+	statisticsReviewRepository    *statistics_review_repository.Repository
+	keystrokeProfileRepository    *keystroke_profile_repository.Repository
+	antifroadKeyVersionRepository *antifroad_key_version_repository.Repository
+	serviceClientRepository       *service_client_repository.Repository
+	serviceNonceRepository        *service_nonce_repository.Repository
+	rateLimitRepository           *rate_limit_repository.Repository
+	antifroadSessionRepository    *antifroad_session_repository.Repository
+	analyticsEventRepository      *analytics_event_repository.Repository
+	migrationRepository           *migration_repository.Repository
+	auditEventRepository          *audit_event_repository.Repository
+	accountExportRepository       *account_export_repository.Repository
+	statisticsDeletionRepository  *statistics_deletion_repository.Repository
+	usernameHistoryRepository     *username_history_repository.Repository
+	profileDetailsRepository      *profile_details_repository.Repository
+	profilePrivacyRepository      *profile_privacy_repository.Repository
+	profileCacheRepository        *profile_cache_repository.Repository
+	testRunRepository             *test_run_repository.Repository
 	// Services
-	sessionService        *session_service.Service
-	authService           *auth_service.Service
-	userService           *user_service.Service
-	statisticsService     *statistics_service.Service
-	pbService             *pb_service.Service
-	profileService        *profile_service.Service
-	antifroadKeyGenerator *antifroad_service.KeyGenerator
-	antifroadService      *antifroad_service.Service
+	sessionService            *session_service.Service
+	authService               *auth_service.Service
+	userService               *user_service.Service
+	statisticsService         *statistics_service.Service
+	pbService                 *pb_service.Service
+	profileService            *profile_service.Service
+	antifroadKeyGenerator     *antifroad_service.KeyGenerator
+	antifroadService          *antifroad_service.Service
+	tokenFamilyService        *token_family_service.Service
+	personalTokenService      *personal_token_service.Service
+	adminService              *admin_service.Service
+	anomalyScorer             *antifroad_service.AnomalyScorer
+	keystrokeAnalyzer         *antifroad_service.KeystrokeRuleSet
+	statisticsReviewService   *statistics_review_service.Service
+	antifroadKeyService       *antifroad_key_service.Service
+	serviceClientService      *service_client_service.Service
+	analyticsService          *analytics_service.Service
+	healthService             *health_service.Service
+	auditService              *audit_service.Service
+	accountService            *account_service.Service
+	statisticsDeletionService *statistics_deletion_service.Service
+	usernameService           *username_service.Service
+	usernamePolicy            *username_service.Policy
+	profileDetailsService     *profile_details_service.Service
+	profilePrivacyService     *profile_privacy_service.Service
 	// Handlers
-	authProviderCallbackGetHandler      *auth_provider_callback_post_handler.Handler
-	authProviderGetHandler              *auth_provider_get_handler.Handler
-	authLogoutPostHandler               *auth_logout_post_handler.Handler
-	authRefreshPostHandler              *auth_refresh_post_handler.Handler
-	authPingGetHandler                  *auth_ping_get_handler.Handler
-	authRegisterPostHandler             *auth_register_post_handler.Handler
-	usersUsernameAvailabilityGetHandler *users_username_availability_get_handler.Handler
-	usersMeStatisticsPostHandler        *users_me_statistics_post_handler.Handler
-	usersMeStatisticsGetHandler         *users_me_statistics_get_handler.Handler
-	usersMeStatisticsDeleteHandler      *users_me_statistics_delete_handler.Handler
-	usersUsernameProfileGetHandler      *users_username_profile_get_handler.Handler
-	usersMeGetHandler                   *users_me_get_handler.Handler
-	usersMeUsernamePatchHandler         *users_me_username_patch_handler.Handler
-	antifroadKeyGetHandler              *antifroad_key_get_handler.Handler
-	antifroadRotateKeysPostHandler      *antifroad_rotate_keys_post_handler.Handler
+	authProviderCallbackGetHandler       *auth_provider_callback_post_handler.Handler
+	authProviderGetHandler               *auth_provider_get_handler.Handler
+	authLogoutPostHandler                *auth_logout_post_handler.Handler
+	authRefreshPostHandler               *auth_refresh_post_handler.Handler
+	authPingGetHandler                   *auth_ping_get_handler.Handler
+	authRegisterPostHandler              *auth_register_post_handler.Handler
+	usersUsernameAvailabilityGetHandler  *users_username_availability_get_handler.Handler
+	usersMeStatisticsPostHandler         *users_me_statistics_post_handler.Handler
+	usersMeStatisticsGetHandler          *users_me_statistics_get_handler.Handler
+	usersMeStatisticsDeleteHandler       *users_me_statistics_delete_handler.Handler
+	usersUsernameProfileGetHandler       *users_username_profile_get_handler.Handler
+	usersMeGetHandler                    *users_me_get_handler.Handler
+	usersMeUsernamePatchHandler          *users_me_username_patch_handler.Handler
+	antifroadKeyGetHandler               *antifroad_key_get_handler.Handler
+	antifroadRotateKeysPostHandler       *antifroad_rotate_keys_post_handler.Handler
+	usersMeTokensPostHandler             *users_me_tokens_post_handler.Handler
+	usersMeTokensGetHandler              *users_me_tokens_get_handler.Handler
+	usersMeTokensIDDeleteHandler         *users_me_tokens_id_delete_handler.Handler
+	adminUsersGetHandler                 *admin_users_get_handler.Handler
+	adminUsersIDGetHandler               *admin_users_id_get_handler.Handler
+	adminUsersIDStatisticsGetHandler     *admin_users_id_statistics_get_handler.Handler
+	adminUsersIDBanPostHandler           *admin_users_id_ban_post_handler.Handler
+	adminUsersIDUnbanPostHandler         *admin_users_id_unban_post_handler.Handler
+	adminUsersIDRolePatchHandler         *admin_users_id_role_patch_handler.Handler
+	adminUsersIDSanctionsPostHandler     *admin_users_id_sanctions_post_handler.Handler
+	adminUsersIDSanctionsGetHandler      *admin_users_id_sanctions_get_handler.Handler
+	adminUsersIDSanctionsIDDeleteHandler *admin_users_id_sanctions_id_delete_handler.Handler
+	adminReviewsGetHandler               *admin_reviews_get_handler.Handler
+	adminReviewsIDApprovePostHandler     *admin_reviews_id_approve_post_handler.Handler
+	adminReviewsIDRejectPostHandler      *admin_reviews_id_reject_post_handler.Handler
+	adminServiceClientsPostHandler       *admin_service_clients_post_handler.Handler
+	adminServiceClientsGetHandler        *admin_service_clients_get_handler.Handler
+	adminServiceClientsIDDeleteHandler   *admin_service_clients_id_delete_handler.Handler
+	usersMeAntifroadSessionPostHandler   *users_me_antifroad_session_post_handler.Handler
+	adminAnalyticsGetHandler             *admin_analytics_get_handler.Handler
+	healthzGetHandler                    *healthz_get_handler.Handler
+	readyzGetHandler                     *readyz_get_handler.Handler
+	usersMeAuditGetHandler               *users_me_audit_get_handler.Handler
+	adminAuditGetHandler                 *admin_audit_get_handler.Handler
+	usersMeDataGetHandler                *users_me_data_get_handler.Handler
+	usersMeDeleteHandler                 *users_me_delete_handler.Handler
+	usersMeStatisticsIDDeleteHandler     *users_me_statistics_id_delete_handler.Handler
+	usersMeStatisticsRestorePostHandler  *users_me_statistics_restore_post_handler.Handler
+	usersMeProfilePatchHandler           *users_me_profile_patch_handler.Handler
+	usersMeAvatarPutHandler              *users_me_avatar_put_handler.Handler
+	avatarsNameGetHandler                *avatars_name_get_handler.Handler
+	usersMePrivacyGetHandler             *users_me_privacy_get_handler.Handler
+	usersMePrivacyPatchHandler           *users_me_privacy_patch_handler.Handler
+	usersMeTestsPostHandler              *users_me_tests_post_handler.Handler
 	//Middleware
-	authMiddleware         proto.Middleware
-	registrationMiddleware proto.Middleware
-	refreshTokenMiddleware proto.Middleware
+	corsMiddleware                     *cors_middleware.Middleware
+	authMiddleware                     proto.Middleware
+	registrationMiddleware             proto.Middleware
+	refreshTokenMiddleware             proto.Middleware
+	accessRevocationMiddleware         proto.Middleware
+	sessionMiddleware                  proto.Middleware
+	optionalAuthMiddleware             proto.Middleware
+	statisticsReadScopeMiddleware      proto.Middleware
+	statisticsWriteScopeMiddleware     proto.Middleware
+	profileReadScopeMiddleware         proto.Middleware
+	moderatorRoleMiddleware            proto.Middleware
+	adminRoleMiddleware                proto.Middleware
+	serviceAuthMiddleware              proto.Middleware
+	statisticsWriteRateLimitMiddleware *rate_limit_middleware.Middleware
+	usernameCheckRateLimitMiddleware   *rate_limit_middleware.Middleware
+	oauthBeginRateLimitMiddleware      *rate_limit_middleware.Middleware
+	avatarUploadRateLimitMiddleware    *rate_limit_middleware.Middleware
 	// Server
 	router *proto.Router
 	server *proto.Server
 	// Scheduler
-	scheduler                  *cron.Cron
-	expiredSessionsHandler     *expired_sessions_handler.Handler
-	antifroadRotateKeysHandler *antifroad_rotate_keys_handler.Handler
+	scheduler                        *cron.Cron
+	expiredSessionsHandler           *expired_sessions_handler.Handler
+	antifroadRotateKeysHandler       *antifroad_rotate_keys_handler.Handler
+	expiredSessionFamiliesHandler    *expired_session_families_handler.Handler
+	expiredSanctionsHandler          *expired_sanctions_handler.Handler
+	antifroadKeyRotateHandler        *antifroad_key_rotate_handler.Handler
+	scheduledAccountsDeletionHandler *scheduled_accounts_deletion_handler.Handler
+	staleTestsHandler                *stale_tests_handler.Handler
 }
 
 func (c *Container) Server() *proto.Server {
@@ -107,17 +290,699 @@ func (c *Container) Server() *proto.Server {
 		c.server = proto.NewServer(
 			c.Router(),
 			cfg.Address,
-			proto.MustUnmarshalDuration(cfg.ShutdownTimeout),
-			proto.MustUnmarshalDuration(cfg.ReadTimeout),
-			proto.MustUnmarshalDuration(cfg.WriteTimeout),
-			proto.MustUnmarshalDuration(cfg.IdleTimeout),
+			cfg.ShutdownTimeout.Duration,
+			cfg.ReadTimeout.Duration,
+			cfg.WriteTimeout.Duration,
+			cfg.IdleTimeout.Duration,
 		)
 	}
 	return c.server
 }
 
+func (c *Container) SessionFamilyRepository() *session_family_repository.Repository {
+	if c.sessionFamilyRepository == nil {
+		c.sessionFamilyRepository = session_family_repository.New(c.TracedPostgres())
+	}
+	return c.sessionFamilyRepository
+}
+
+func (c *Container) AccessRevocationRepository() *access_revocation_repository.Repository {
+	if c.accessRevocationRepository == nil {
+		c.accessRevocationRepository = access_revocation_repository.New(
+			c.Redis(),
+			c.cfg.Auth.AccessTokenTTL.Duration,
+		)
+	}
+	return c.accessRevocationRepository
+}
+
+func (c *Container) TokenFamilyService() *token_family_service.Service {
+	if c.tokenFamilyService == nil {
+		c.tokenFamilyService = token_family_service.New(
+			c.SessionFamilyRepository(),
+			c.AccessRevocationRepository(),
+			c.AuditService(),
+			c.Logger(),
+		)
+	}
+	return c.tokenFamilyService
+}
+
+func (c *Container) ExpiredSessionFamiliesHandler() *expired_session_families_handler.Handler {
+	if c.expiredSessionFamiliesHandler == nil {
+		c.expiredSessionFamiliesHandler = expired_session_families_handler.New(
+			c.TokenFamilyService(),
+			c.Metrics(),
+			c.Logger(),
+		)
+	}
+	return c.expiredSessionFamiliesHandler
+}
+
+func (c *Container) PersonalTokenRepository() *personal_token_repository.Repository {
+	if c.personalTokenRepository == nil {
+		c.personalTokenRepository = personal_token_repository.New(c.TracedPostgres())
+	}
+	return c.personalTokenRepository
+}
+
+func (c *Container) PersonalTokenService() *personal_token_service.Service {
+	if c.personalTokenService == nil {
+		c.personalTokenService = personal_token_service.New(
+			c.PersonalTokenRepository(),
+			c.AuditService(),
+			c.cfg.Tokens.MaxPerUser,
+		)
+	}
+	return c.personalTokenService
+}
+
+func (c *Container) UserAccountRepository() *user_account_repository.Repository {
+	if c.userAccountRepository == nil {
+		c.userAccountRepository = user_account_repository.New(c.TracedPostgres())
+	}
+	return c.userAccountRepository
+}
+
+func (c *Container) SanctionRepository() *sanction_repository.Repository {
+	if c.sanctionRepository == nil {
+		c.sanctionRepository = sanction_repository.New(c.TracedPostgres())
+	}
+	return c.sanctionRepository
+}
+
+func (c *Container) AdminService() *admin_service.Service {
+	if c.adminService == nil {
+		c.adminService = admin_service.New(
+			c.UserAccountRepository(),
+			c.AccessRevocationRepository(),
+			c.SanctionRepository(),
+			c.AuditService(),
+			c.Logger(),
+		)
+	}
+	return c.adminService
+}
+
+func (c *Container) StatisticsReviewRepository() *statistics_review_repository.Repository {
+	if c.statisticsReviewRepository == nil {
+		c.statisticsReviewRepository = statistics_review_repository.New(c.TracedPostgres())
+	}
+	return c.statisticsReviewRepository
+}
+
+func (c *Container) KeystrokeProfileRepository() *keystroke_profile_repository.Repository {
+	if c.keystrokeProfileRepository == nil {
+		c.keystrokeProfileRepository = keystroke_profile_repository.New(c.TracedPostgres())
+	}
+	return c.keystrokeProfileRepository
+}
+
+func (c *Container) KeystrokeAnalyzer() *antifroad_service.KeystrokeRuleSet {
+	if c.keystrokeAnalyzer == nil {
+		cfg := c.cfg.Antifroad.Biometrics
+		c.keystrokeAnalyzer = antifroad_service.NewDefaultKeystrokeRuleSet(antifroad_service.BiometricsConfig{
+			MinKeystrokes:         cfg.MinKeystrokes,
+			MinIntervalCV:         cfg.MinIntervalCV,
+			MinDwellStdDev:        cfg.MinDwellStdDev,
+			MinBigramRepeats:      cfg.MinBigramRepeats,
+			MaxUniformBigramShare: cfg.MaxUniformBigramShare,
+			MinTypedShare:         cfg.MinTypedShare,
+			MinProfileSamples:     cfg.MinProfileSamples,
+			MaxProfileDeviation:   cfg.MaxProfileDeviation,
+		})
+	}
+	return c.keystrokeAnalyzer
+}
+
+func (c *Container) AnomalyScorer() *antifroad_service.AnomalyScorer {
+	if c.anomalyScorer == nil {
+		cfg := c.cfg.Antifroad.Anomaly
+		c.anomalyScorer = antifroad_service.NewAnomalyScorer(antifroad_service.AnomalyConfig{
+			MinHistory:                 cfg.MinHistory,
+			WPMZScore:                  cfg.WPMZScore,
+			DurationTolerance:          cfg.DurationTolerance.Duration,
+			MinUncompletedTestDuration: cfg.MinUncompletedTestDuration.Duration,
+			MinKeystrokeInterval:       cfg.MinKeystrokeInterval.Duration,
+			MaxFastKeystrokeShare:      cfg.MaxFastKeystrokeShare,
+			Threshold:                  cfg.Threshold,
+		})
+	}
+	return c.anomalyScorer
+}
+
+func (c *Container) StatisticsReviewService() *statistics_review_service.Service {
+	if c.statisticsReviewService == nil {
+		cfg := c.cfg.Antifroad
+		c.statisticsReviewService = statistics_review_service.New(
+			c.StatisticsReviewRepository(),
+			c.KeystrokeProfileRepository(),
+			c.StatisticsService(),
+			c.AnomalyScorer(),
+			c.KeystrokeAnalyzer(),
+			c.Metrics(),
+			c.Logger(),
+			cfg.Anomaly.HistoryWindow.Duration,
+			cfg.Biometrics.MinKeystrokes,
+		)
+	}
+	return c.statisticsReviewService
+}
+
+func (c *Container) ExpiredSanctionsHandler() *expired_sanctions_handler.Handler {
+	if c.expiredSanctionsHandler == nil {
+		c.expiredSanctionsHandler = expired_sanctions_handler.New(
+			c.AdminService(),
+			c.Metrics(),
+			c.Logger(),
+		)
+	}
+	return c.expiredSanctionsHandler
+}
+
+func (c *Container) AntifroadKeyVersionRepository() *antifroad_key_version_repository.Repository {
+	if c.antifroadKeyVersionRepository == nil {
+		c.antifroadKeyVersionRepository = antifroad_key_version_repository.New(c.TracedPostgres())
+	}
+	return c.antifroadKeyVersionRepository
+}
+
+func (c *Container) AntifroadSessionRepository() *antifroad_session_repository.Repository {
+	if c.antifroadSessionRepository == nil {
+		c.antifroadSessionRepository = antifroad_session_repository.New(c.Redis())
+	}
+	return c.antifroadSessionRepository
+}
+
+func (c *Container) AntifroadKeyService() *antifroad_key_service.Service {
+	if c.antifroadKeyService == nil {
+		cfg := c.cfg.Antifroad
+		c.antifroadKeyService = antifroad_key_service.New(
+			c.AntifroadKeyVersionRepository(),
+			c.AntifroadSessionRepository(),
+			c.AuditService(),
+			c.Metrics(),
+			c.Logger(),
+			cfg.KeyGracePeriod.Duration,
+			cfg.SessionTTL.Duration,
+			cfg.RequireSessionSignatures,
+		)
+	}
+	return c.antifroadKeyService
+}
+
+func (c *Container) ServiceClientRepository() *service_client_repository.Repository {
+	if c.serviceClientRepository == nil {
+		c.serviceClientRepository = service_client_repository.New(c.TracedPostgres())
+	}
+	return c.serviceClientRepository
+}
+
+func (c *Container) ServiceNonceRepository() *service_nonce_repository.Repository {
+	if c.serviceNonceRepository == nil {
+		c.serviceNonceRepository = service_nonce_repository.New(c.Redis())
+	}
+	return c.serviceNonceRepository
+}
+
+func (c *Container) RateLimitRepository() *rate_limit_repository.Repository {
+	if c.rateLimitRepository == nil {
+		c.rateLimitRepository = rate_limit_repository.New(c.Redis())
+	}
+	return c.rateLimitRepository
+}
+
+func (c *Container) ServiceClientService() *service_client_service.Service {
+	if c.serviceClientService == nil {
+		c.serviceClientService = service_client_service.New(
+			c.ServiceClientRepository(),
+			c.ServiceNonceRepository(),
+			c.cfg.ServiceAuth.MaxClockSkew.Duration,
+		)
+	}
+	return c.serviceClientService
+}
+
+func (c *Container) AntifroadKeyRotateHandler() *antifroad_key_rotate_handler.Handler {
+	if c.antifroadKeyRotateHandler == nil {
+		c.antifroadKeyRotateHandler = antifroad_key_rotate_handler.New(
+			c.AntifroadKeyService(),
+			c.Metrics(),
+			c.Logger(),
+		)
+	}
+	return c.antifroadKeyRotateHandler
+}
+
+func (c *Container) Metrics() *metrics.Metrics {
+	if c.metrics == nil {
+		c.metrics = metrics.New()
+		c.metrics.MustRegister(
+			metrics.NewPostgresCollector(c.Postgres()),
+			metrics.NewRedisCollector(c.Redis()),
+		)
+	}
+	return c.metrics
+}
+
+func (c *Container) Tracing() *tracing.Tracing {
+	if c.tracing == nil {
+		cfg := c.cfg.Tracing
+		c.tracing = tracing.MustNew(context.Background(), tracing.Config{
+			Exporter:    tracing.Exporter(cfg.Exporter),
+			Endpoint:    cfg.Endpoint,
+			Insecure:    cfg.Insecure,
+			ServiceName: cfg.ServiceName,
+			SampleRatio: cfg.SampleRatio,
+		})
+	}
+	return c.tracing
+}
+
+// TracedPostgres records a span for every statement of the repositories that use it.
+func (c *Container) TracedPostgres() *tracing.Postgres {
+	if c.tracedPostgres == nil {
+		c.tracedPostgres = tracing.NewPostgres(c.Postgres(), c.Tracing().Tracer())
+	}
+	return c.tracedPostgres
+}
+
+func (c *Container) AnalyticsEventRepository() *analytics_event_repository.Repository {
+	if c.analyticsEventRepository == nil {
+		c.analyticsEventRepository = analytics_event_repository.New(c.TracedPostgres())
+	}
+	return c.analyticsEventRepository
+}
+
+func (c *Container) AnalyticsService() *analytics_service.Service {
+	if c.analyticsService == nil {
+		cfg := c.cfg.Analytics
+		c.analyticsService = analytics_service.New(
+			c.AnalyticsEventRepository(),
+			c.TestRunRepository(),
+			c.UserAccountRepository(),
+			c.Logger(),
+			cfg.BatchSize,
+			cfg.BufferSize,
+			cfg.FlushInterval.Duration,
+			cfg.TestTimeout.Duration,
+		)
+	}
+	return c.analyticsService
+}
+
+func (c *Container) TestRunRepository() *test_run_repository.Repository {
+	if c.testRunRepository == nil {
+		c.testRunRepository = test_run_repository.New(c.TracedPostgres())
+	}
+	return c.testRunRepository
+}
+
+func (c *Container) StaleTestsHandler() *stale_tests_handler.Handler {
+	if c.staleTestsHandler == nil {
+		c.staleTestsHandler = stale_tests_handler.New(
+			c.AnalyticsService(),
+			c.Metrics(),
+			c.Logger(),
+		)
+	}
+	return c.staleTestsHandler
+}
+
+func (c *Container) AuditEventRepository() *audit_event_repository.Repository {
+	if c.auditEventRepository == nil {
+		c.auditEventRepository = audit_event_repository.New(c.TracedPostgres())
+	}
+	return c.auditEventRepository
+}
+
+func (c *Container) AuditService() *audit_service.Service {
+	if c.auditService == nil {
+		c.auditService = audit_service.New(
+			c.AuditEventRepository(),
+			c.UserAccountRepository(),
+			c.Logger(),
+		)
+	}
+	return c.auditService
+}
+
+func (c *Container) AccountExportRepository() *account_export_repository.Repository {
+	if c.accountExportRepository == nil {
+		c.accountExportRepository = account_export_repository.New(c.TracedPostgres())
+	}
+	return c.accountExportRepository
+}
+
+func (c *Container) AccountService() *account_service.Service {
+	if c.accountService == nil {
+		c.accountService = account_service.New(
+			c.UserAccountRepository(),
+			c.AccountExportRepository(),
+			c.PersonalTokenRepository(),
+			c.AccessRevocationRepository(),
+			c.ProfileDetailsService(),
+			c.AuditService(),
+			c.Logger(),
+			c.cfg.Account.DeletionGracePeriod.Duration,
+		)
+	}
+	return c.accountService
+}
+
+func (c *Container) StatisticsDeletionRepository() *statistics_deletion_repository.Repository {
+	if c.statisticsDeletionRepository == nil {
+		c.statisticsDeletionRepository = statistics_deletion_repository.New(c.TracedPostgres())
+	}
+	return c.statisticsDeletionRepository
+}
+
+func (c *Container) StatisticsDeletionService() *statistics_deletion_service.Service {
+	if c.statisticsDeletionService == nil {
+		c.statisticsDeletionService = statistics_deletion_service.New(
+			c.StatisticsDeletionRepository(),
+			c.StatisticsService(),
+			c.AuditService(),
+			c.Logger(),
+			c.cfg.Statistics.RestoreWindow.Duration,
+		)
+	}
+	return c.statisticsDeletionService
+}
+
+func (c *Container) UsernameHistoryRepository() *username_history_repository.Repository {
+	if c.usernameHistoryRepository == nil {
+		c.usernameHistoryRepository = username_history_repository.New(c.TracedPostgres())
+	}
+	return c.usernameHistoryRepository
+}
+
+func (c *Container) AvatarStorage() storage.Storage {
+	if c.avatarStorage == nil {
+		cfg := c.cfg.Avatars.Storage
+		c.avatarStorage = storage.MustNew(storage.Config{
+			Backend:   storage.Backend(cfg.Backend),
+			Path:      cfg.Path,
+			Endpoint:  cfg.Endpoint,
+			Region:    cfg.Region,
+			Bucket:    cfg.Bucket,
+			AccessKey: cfg.AccessKey,
+			SecretKey: cfg.SecretKey,
+		})
+	}
+	return c.avatarStorage
+}
+
+func (c *Container) ProfileDetailsRepository() *profile_details_repository.Repository {
+	if c.profileDetailsRepository == nil {
+		c.profileDetailsRepository = profile_details_repository.New(c.TracedPostgres())
+	}
+	return c.profileDetailsRepository
+}
+
+func (c *Container) ProfileDetailsService() *profile_details_service.Service {
+	if c.profileDetailsService == nil {
+		c.profileDetailsService = profile_details_service.New(
+			c.ProfileDetailsRepository(),
+			c.AvatarStorage(),
+			c.Logger(),
+			c.cfg.Avatars.Size,
+			c.cfg.Avatars.URL,
+		)
+	}
+	return c.profileDetailsService
+}
+
+func (c *Container) ProfilePrivacyRepository() *profile_privacy_repository.Repository {
+	if c.profilePrivacyRepository == nil {
+		c.profilePrivacyRepository = profile_privacy_repository.New(c.TracedPostgres())
+	}
+	return c.profilePrivacyRepository
+}
+
+func (c *Container) ProfileCacheRepository() *profile_cache_repository.Repository {
+	if c.profileCacheRepository == nil {
+		c.profileCacheRepository = profile_cache_repository.New(c.Redis(), c.cfg.Profile.Expiration.Duration)
+	}
+	return c.profileCacheRepository
+}
+
+func (c *Container) ProfilePrivacyService() *profile_privacy_service.Service {
+	if c.profilePrivacyService == nil {
+		c.profilePrivacyService = profile_privacy_service.New(
+			c.ProfilePrivacyRepository(),
+			c.AdminService(),
+			c.ProfileService(),
+			c.ProfileCacheRepository(),
+			c.Logger(),
+		)
+	}
+	return c.profilePrivacyService
+}
+
+func (c *Container) UsernamePolicy() *username_service.Policy {
+	if c.usernamePolicy == nil {
+		cfg := c.cfg.Usernames
+		c.usernamePolicy = username_service.NewPolicy(username_service.PolicyConfig{
+			MinLength:   cfg.MinLength,
+			MaxLength:   cfg.MaxLength,
+			Scripts:     cfg.Scripts,
+			AllowDigits: cfg.AllowDigits,
+			Symbols:     cfg.Symbols,
+			Reserved:    cfg.Reserved,
+			Profanity:   cfg.Profanity,
+		})
+	}
+	return c.usernamePolicy
+}
+
+func (c *Container) UsernameService() *username_service.Service {
+	if c.usernameService == nil {
+		c.usernameService = username_service.New(
+			c.UserAccountRepository(),
+			c.UsernameHistoryRepository(),
+			c.ProfileCacheRepository(),
+			c.UsernamePolicy(),
+			c.AuthService(),
+			c.AuditService(),
+			c.Logger(),
+			c.cfg.Account.UsernameReservationPeriod.Duration,
+			c.cfg.Account.UsernameChangeInterval.Duration,
+			c.cfg.Usernames.Suggestions,
+		)
+	}
+	return c.usernameService
+}
+
+func (c *Container) ScheduledAccountsDeletionHandler() *scheduled_accounts_deletion_handler.Handler {
+	if c.scheduledAccountsDeletionHandler == nil {
+		c.scheduledAccountsDeletionHandler = scheduled_accounts_deletion_handler.New(
+			c.AccountService(),
+			c.Metrics(),
+			c.Logger(),
+		)
+	}
+	return c.scheduledAccountsDeletionHandler
+}
+
+func (c *Container) MigrationRepository() *migration_repository.Repository {
+	if c.migrationRepository == nil {
+		c.migrationRepository = migration_repository.New(c.TracedPostgres(), c.cfg.Postgres.Migrations)
+	}
+	return c.migrationRepository
+}
+
+func (c *Container) HealthService() *health_service.Service {
+	if c.healthService == nil {
+		c.healthService = health_service.New(
+			c.Postgres(),
+			c.Redis(),
+			c.MigrationRepository(),
+			c.AntifroadKeyService(),
+			c.cfg.Antifroad.IsDisabled,
+		)
+	}
+	return c.healthService
+}
+
+// ConfigReloader applies keys of config.yaml that are safe to change at runtime
+// without a restart.
+func (c *Container) ConfigReloader() *config.Reloader {
+	if c.configReloader == nil {
+		c.configReloader = config.NewReloader(c.cfg, c.Logger(), c.applyConfig)
+	}
+	return c.configReloader
+}
+
+// applyConfig passes reloaded keys to the components that use them. Steps that
+// can fail go first, so a rejected config changes nothing.
+func (c *Container) applyConfig(cfg *config.Config) error {
+	if _, err := logger.ParseLevel(cfg.Logger.Level); err != nil {
+		return err
+	}
+
+	if err := c.CORSMiddleware().SetConfig(newCORSConfig(cfg.CORS)); err != nil {
+		return err
+	}
+
+	_ = c.structuredLogger.SetLevel(cfg.Logger.Level)
+
+	rateLimits := cfg.RateLimits
+	c.StatisticsWriteRateLimitMiddleware().SetLimit(rateLimits.StatisticsWrite.Limit, rateLimits.StatisticsWrite.Period.Duration)
+	c.UsernameCheckRateLimitMiddleware().SetLimit(rateLimits.UsernameCheck.Limit, rateLimits.UsernameCheck.Period.Duration)
+	c.OAuthBeginRateLimitMiddleware().SetLimit(rateLimits.OAuthBegin.Limit, rateLimits.OAuthBegin.Period.Duration)
+	c.AvatarUploadRateLimitMiddleware().SetLimit(rateLimits.AvatarUpload.Limit, rateLimits.AvatarUpload.Period.Duration)
+
+	c.LanguageRepository().SetLanguages(cfg.Languages)
+	c.ProfileCacheRepository().SetTTL(cfg.Profile.Expiration.Duration)
+	c.PBCache().SetExpiration(cfg.Statistics.PBExpirationTime.Duration)
+
+	c.AuthProviderCallbackGetHandler().SetRedirectURLs(&auth_provider_callback_post_handler.RedirectURLs{
+		LoggedIn:     cfg.Auth.LoggedInRedirectURL,
+		Registration: cfg.Auth.RegistrationRedirectURL,
+		Error:        cfg.Auth.ErrorRedirectURL,
+	})
+
+	return nil
+}
+
+// MustScheduleJobs registers scheduler jobs that are not part of the base scheduler setup.
+// It must run before the scheduler is taken from the container, because it
+// replaces the base scheduler.
+func (c *Container) MustScheduleJobs() {
+	cfg := c.cfg.Scheduler
+	scheduler := c.rebuildBaseScheduler(c.Scheduler())
+	c.scheduler = scheduler
+
+	if err := scheduler.AddJob(cfg.DeleteExpiredSessionsInterval, c.ExpiredSessionFamiliesHandler()); err != nil {
+		panic(err)
+	}
+
+	if err := scheduler.AddJob(cfg.LiftExpiredSanctionsInterval, c.ExpiredSanctionsHandler()); err != nil {
+		panic(err)
+	}
+
+	if err := scheduler.AddJob(cfg.DeleteScheduledAccountsInterval, c.ScheduledAccountsDeletionHandler()); err != nil {
+		panic(err)
+	}
+
+	if err := scheduler.AddJob(cfg.AbandonStaleTestsInterval, c.StaleTestsHandler()); err != nil {
+		panic(err)
+	}
+
+	if !c.cfg.Antifroad.IsDisabled {
+		if err := scheduler.AddJob(c.cfg.Antifroad.RotationInterval, c.AntifroadKeyRotateHandler()); err != nil {
+			panic(err)
+		}
+	}
+}
+
+// rebuildBaseScheduler returns a copy of the base scheduler whose jobs report
+// their runs to metrics, without its antifroad_rotate_keys_handler job. Keys
+// are rotated by antifroad_key_rotate_handler only, the base job would rotate
+// them twice and leave no rotation record. cron has no way to remove or wrap a
+// job, so the jobs are moved to a new scheduler.
+func (c *Container) rebuildBaseScheduler(base *cron.Cron) *cron.Cron {
+	scheduler := cron.NewWithLocation(base.Location())
+	scheduler.ErrorLog = base.ErrorLog
+
+	for _, entry := range base.Entries() {
+		if _, ok := entry.Job.(*antifroad_rotate_keys_handler.Handler); ok {
+			continue
+		}
+		scheduler.Schedule(entry.Schedule, &observedJob{
+			name:    jobName(entry.Job),
+			job:     entry.Job,
+			metrics: c.Metrics(),
+		})
+	}
+
+	return scheduler
+}
+
+// observedJob reports runs of a base scheduler job, which does not report them
+// itself. The job logs its own errors, so only panics count as failed runs.
+type observedJob struct {
+	name    string
+	job     cron.Job
+	metrics *metrics.Metrics
+}
+
+func (j *observedJob) Run() {
+	startedAt := time.Now()
+	defer func() {
+		if r := recover(); r != nil {
+			j.metrics.ObserveJob(j.name, startedAt, fmt.Errorf("panic: %v", r))
+			panic(r)
+		}
+	}()
+
+	j.job.Run()
+	j.metrics.ObserveJob(j.name, startedAt, nil)
+}
+
+// jobName is the package name of the job handler, the name the other jobs
+// report under.
+func jobName(job cron.Job) string {
+	name := strings.TrimPrefix(fmt.Sprintf("%T", job), "*")
+	if i := strings.Index(name, "."); i >= 0 {
+		name = name[:i]
+	}
+	return name
+}
+
+// newLoggerConfig falls back to the single stdout or file sink of use_file when
+// no sinks are configured. Secrets of the config are never written to the log.
+func newLoggerConfig(cfg *config.Config) logger.Config {
+	sinks := make([]logger.Sink, 0, len(cfg.Logger.Sinks))
+	for _, sink := range cfg.Logger.Sinks {
+		sinks = append(sinks, logger.Sink{
+			Output:     sink.Output,
+			Format:     sink.Format,
+			Path:       sink.Path,
+			MaxSizeMB:  sink.MaxSizeMB,
+			MaxAgeDays: sink.MaxAgeDays,
+			MaxBackups: sink.MaxBackups,
+			Compress:   sink.Compress,
+		})
+	}
+
+	if len(sinks) == 0 {
+		sink := logger.Sink{Output: logger.OutputStdout, Format: logger.FormatConsole}
+		if cfg.Logger.UseFile {
+			sink = logger.Sink{Output: logger.OutputFile, Format: logger.FormatJSON, Path: cfg.Logger.Path}
+		}
+		sinks = append(sinks, sink)
+	}
+
+	return logger.Config{
+		Enabled: cfg.Logger.Enabled,
+		Level:   cfg.Logger.Level,
+		Sinks:   sinks,
+		Sampling: logger.Sampling{
+			First:    cfg.Logger.Sampling.WarningsPerHandler,
+			Interval: cfg.Logger.Sampling.Interval.Duration,
+		},
+		RedactFields: cfg.Logger.RedactFields,
+		Secrets: []string{
+			cfg.Auth.JWTSecret,
+			cfg.Antifroad.Password,
+			cfg.Cookie.Key,
+			cfg.Redis.Password,
+			cfg.Metrics.Password,
+			cfg.Swagger.Password,
+		},
+	}
+}
+
+// NewContainer creates the logger right away, so Logger() returns it and
+// everything logs through the configured sinks from the start.
 func NewContainer(cfg *config.Config) *Container {
+	structuredLogger := logger.MustNew(newLoggerConfig(cfg))
+
 	return &Container{
-		cfg: cfg,
+		cfg:              cfg,
+		logger:           structuredLogger,
+		structuredLogger: structuredLogger,
 	}
 }
@@ -0,0 +1,38 @@
+package models
+
+import "time"
+
+// AccountExport is everything stored about a user, handed out to the user on
+// request.
+type AccountExport struct {
+	Account        *UserAccount
+	Details        *ProfileDetails
+	Sessions       []*AccountSession
+	PersonalTokens []*PersonalToken
+	Statistics     []*ExportedResult
+	ExportedAt     time.Time
+}
+
+// AccountSession is a login of the user. Refresh tokens themselves are never
+// exported.
+type AccountSession struct {
+	ExpiresAt time.Time
+}
+
+// ExportedResult is a stored test result, including the ones the user deleted
+// but that are still kept.
+type ExportedResult struct {
+	ID                            ID
+	WPM                           float64
+	CPM                           float64
+	Accuracy                      float64
+	Duration                      time.Duration
+	PlayedAt                      time.Time
+	Language                      string
+	Mode                          string
+	SubMode                       string
+	IsPunctuation                 bool
+	UncompletedTestsCount         int64
+	UncompletedTestsTotalDuration time.Duration
+	IsDeleted                     bool
+}
@@ -0,0 +1,46 @@
+package models
+
+import "time"
+
+type AnalyticsEventName string
+
+const (
+	AnalyticsTestStarted           AnalyticsEventName = "test_started"
+	AnalyticsTestCompleted         AnalyticsEventName = "test_completed"
+	AnalyticsTestAbandoned         AnalyticsEventName = "test_abandoned"
+	AnalyticsRegistrationCompleted AnalyticsEventName = "registration_completed"
+	AnalyticsUsernameChanged       AnalyticsEventName = "username_changed"
+	AnalyticsPersonalBest          AnalyticsEventName = "personal_best"
+)
+
+// AnalyticsFunnelSteps are the key user scenario steps in the order users are
+// expected to go through them.
+var AnalyticsFunnelSteps = []AnalyticsEventName{
+	AnalyticsRegistrationCompleted,
+	AnalyticsTestStarted,
+	AnalyticsTestCompleted,
+	AnalyticsPersonalBest,
+}
+
+// AnalyticsEvent is a product event. Events are only ever appended.
+type AnalyticsEvent struct {
+	Name       AnalyticsEventName
+	UserID     *ID
+	Properties map[string]any
+	OccurredAt time.Time
+}
+
+// AnalyticsFunnelStep tells how many users reached the step after going
+// through every previous one.
+type AnalyticsFunnelStep struct {
+	Name  AnalyticsEventName
+	Users int64
+}
+
+// AnalyticsCohort is a group of users registered in the same week. Active[i]
+// is how many of them completed a test i weeks after the cohort week started.
+type AnalyticsCohort struct {
+	Week   time.Time
+	Users  int64
+	Active []int64
+}
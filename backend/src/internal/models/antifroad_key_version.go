@@ -0,0 +1,41 @@
+package models
+
+import "time"
+
+type AntifroadRotationTrigger string
+
+const (
+	AntifroadRotationInit      AntifroadRotationTrigger = "init"
+	AntifroadRotationScheduler AntifroadRotationTrigger = "scheduler"
+	AntifroadRotationAPI       AntifroadRotationTrigger = "api"
+)
+
+// AntifroadKeyVersion is an antifroad key together with its id. Clients send the
+// id with a signature, so a result signed just before a rotation is verified
+// with exactly the key that signed it.
+type AntifroadKeyVersion struct {
+	KID       string
+	Value     string
+	NotBefore time.Time
+	NotAfter  *time.Time // Set when the key is rotated out
+}
+
+func (k *AntifroadKeyVersion) IsValidAt(at time.Time) bool {
+	if at.Before(k.NotBefore) {
+		return false
+	}
+	return k.NotAfter == nil || at.Before(*k.NotAfter)
+}
+
+func (k *AntifroadKeyVersion) IsRetired() bool {
+	return k.NotAfter != nil
+}
+
+// AntifroadKeyRotation is an audit record of a single key rotation.
+type AntifroadKeyRotation struct {
+	ID          ID
+	KID         string
+	RetiredKIDs []string
+	Trigger     AntifroadRotationTrigger
+	RotatedAt   time.Time
+}
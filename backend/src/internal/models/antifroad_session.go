@@ -0,0 +1,13 @@
+package models
+
+import "time"
+
+// AntifroadSession lets an authenticated client sign its results with a secret
+// derived for that session and user alone, so the global antifroad key never
+// leaves the server and a leaked secret can not forge results of other users.
+type AntifroadSession struct {
+	ID        string
+	UserID    ID
+	KID       string // Key the session secret is derived from
+	ExpiresAt time.Time
+}
@@ -0,0 +1,61 @@
+package models
+
+import "time"
+
+type AuditAction string
+
+const (
+	AuditLogin               AuditAction = "login"
+	AuditRegistration        AuditAction = "registration"
+	AuditUsernameChanged     AuditAction = "username_changed"
+	AuditStatisticsWiped     AuditAction = "statistics_wiped"
+	AuditResultDeleted       AuditAction = "statistics_result_deleted"
+	AuditStatisticsRestored  AuditAction = "statistics_restored"
+	AuditSessionRevoked      AuditAction = "session_revoked"
+	AuditAntifroadKeyRotated AuditAction = "antifroad_key_rotated"
+	AuditDeletionScheduled   AuditAction = "account_deletion_scheduled"
+	AuditAccountDeleted      AuditAction = "account_deleted"
+)
+
+func (a AuditAction) IsValid() bool {
+	switch a {
+	case AuditLogin, AuditRegistration, AuditUsernameChanged,
+		AuditStatisticsWiped, AuditResultDeleted, AuditStatisticsRestored,
+		AuditSessionRevoked, AuditAntifroadKeyRotated,
+		AuditDeletionScheduled, AuditAccountDeleted:
+		return true
+	default:
+		return false
+	}
+}
+
+// AuditEvent is a security-relevant action made on an account. UserID is the
+// account the action is about and ActorID is the user that made it, they differ
+// when an admin acts on someone else. Actions made by an internal service or by
+// the server itself have ActorService set instead. Events are never changed,
+// except that IP and UserAgent are cleared when the account is deleted.
+type AuditEvent struct {
+	ID           ID
+	Action       AuditAction
+	UserID       *ID
+	ActorID      *ID
+	ActorService string
+	IP           string
+	UserAgent    string
+	RequestID    string
+	Details      map[string]any
+	CreatedAt    time.Time
+}
+
+// AuditFilter selects audit events. Empty fields match everything. Events are
+// returned newest first, BeforeID continues from the last event of a page.
+type AuditFilter struct {
+	UserID   *ID
+	ActorID  *ID
+	Action   AuditAction
+	IP       string
+	From     *time.Time
+	To       *time.Time
+	BeforeID *ID
+	Limit    int
+}
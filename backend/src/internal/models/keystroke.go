@@ -0,0 +1,41 @@
+package models
+
+import "time"
+
+// Keystroke is a single key press recorded by the client during a test. Both
+// times are offsets from the start of the test.
+type Keystroke struct {
+	Key        string
+	PressedAt  time.Duration
+	ReleasedAt time.Duration
+}
+
+// Dwell is how long the key was held down.
+func (k *Keystroke) Dwell() time.Duration {
+	return k.ReleasedAt - k.PressedAt
+}
+
+// KeystrokeIntervals returns the time between consecutive key presses.
+func KeystrokeIntervals(keystrokes []Keystroke) []time.Duration {
+	if len(keystrokes) < 2 {
+		return nil
+	}
+
+	intervals := make([]time.Duration, 0, len(keystrokes)-1)
+	for i := 1; i < len(keystrokes); i++ {
+		intervals = append(intervals, keystrokes[i].PressedAt-keystrokes[i-1].PressedAt)
+	}
+
+	return intervals
+}
+
+// KeystrokeProfile is the typing rhythm of a user averaged over accepted runs.
+// Times are in milliseconds.
+type KeystrokeProfile struct {
+	UserID       ID
+	Samples      int
+	IntervalMean float64
+	IntervalCV   float64
+	DwellMean    float64
+	UpdatedAt    time.Time
+}
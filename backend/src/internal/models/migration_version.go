@@ -0,0 +1,8 @@
+package models
+
+// MigrationVersion is the state of the database schema. A dirty version means
+// its migration failed halfway and the schema has to be fixed by hand.
+type MigrationVersion struct {
+	Version uint
+	Dirty   bool
+}
@@ -0,0 +1,45 @@
+package models
+
+import "time"
+
+type Scope string
+
+const (
+	ScopeStatisticsRead  Scope = "statistics:read"
+	ScopeStatisticsWrite Scope = "statistics:write"
+	ScopeProfileRead     Scope = "profile:read"
+)
+
+func (s Scope) IsValid() bool {
+	switch s {
+	case ScopeStatisticsRead, ScopeStatisticsWrite, ScopeProfileRead:
+		return true
+	default:
+		return false
+	}
+}
+
+// PersonalToken is a long-lived token a user creates for third-party clients.
+// Only the hash of the token value is stored.
+type PersonalToken struct {
+	ID         ID
+	UserID     ID
+	Name       string
+	Scopes     []Scope
+	CreatedAt  time.Time
+	ExpiresAt  *time.Time
+	LastUsedAt *time.Time
+}
+
+func (t *PersonalToken) IsExpired(now time.Time) bool {
+	return t.ExpiresAt != nil && !now.Before(*t.ExpiresAt)
+}
+
+func (t *PersonalToken) HasScope(scope Scope) bool {
+	for _, s := range t.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,72 @@
+package models
+
+import (
+	"errors"
+	"unicode/utf8"
+)
+
+const (
+	MaxBioLength      = 300
+	MaxKeyboardLength = 64
+	MaxLayoutLength   = 32
+)
+
+var (
+	ErrBioTooLong      = errors.New("bio is too long")
+	ErrInvalidCountry  = errors.New("country must be an ISO 3166-1 alpha-2 code")
+	ErrKeyboardTooLong = errors.New("keyboard is too long")
+	ErrLayoutTooLong   = errors.New("layout is too long")
+)
+
+// ProfileDetails are the parts of the public profile the user fills in. The
+// avatar is either uploaded by the user or taken from the OAuth provider, an
+// uploaded one wins. AvatarURL is where the chosen one is served from.
+type ProfileDetails struct {
+	AvatarKey         string
+	ProviderAvatarURL string
+	AvatarURL         string
+	Bio               string
+	Country           string
+	Keyboard          string
+	Layout            string
+}
+
+// ProfileDetailsUpdate changes the fields that are not nil, an empty string
+// clears a field.
+type ProfileDetailsUpdate struct {
+	Bio      *string
+	Country  *string
+	Keyboard *string
+	Layout   *string
+}
+
+func (u *ProfileDetailsUpdate) Validate() error {
+	if u.Bio != nil && utf8.RuneCountInString(*u.Bio) > MaxBioLength {
+		return ErrBioTooLong
+	}
+	if u.Country != nil && *u.Country != "" && !isCountryCode(*u.Country) {
+		return ErrInvalidCountry
+	}
+	if u.Keyboard != nil && utf8.RuneCountInString(*u.Keyboard) > MaxKeyboardLength {
+		return ErrKeyboardTooLong
+	}
+	if u.Layout != nil && utf8.RuneCountInString(*u.Layout) > MaxLayoutLength {
+		return ErrLayoutTooLong
+	}
+
+	return nil
+}
+
+func IsProfileDetailsValidationError(err error) bool {
+	return errors.Is(err, ErrBioTooLong) ||
+		errors.Is(err, ErrInvalidCountry) ||
+		errors.Is(err, ErrKeyboardTooLong) ||
+		errors.Is(err, ErrLayoutTooLong)
+}
+
+// isCountryCode checks the shape of the code only: two uppercase Latin letters.
+func isCountryCode(code string) bool {
+	return len(code) == 2 &&
+		code[0] >= 'A' && code[0] <= 'Z' &&
+		code[1] >= 'A' && code[1] <= 'Z'
+}
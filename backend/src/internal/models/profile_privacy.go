@@ -0,0 +1,142 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+)
+
+type ProfileVisibility string
+
+const (
+	// ProfilePublic profiles are shown to everyone.
+	ProfilePublic ProfileVisibility = "public"
+	// ProfileUnlisted profiles are shown to anyone with the link, but are not
+	// meant to be found: search engines are asked not to index them.
+	ProfileUnlisted ProfileVisibility = "unlisted"
+	// ProfilePrivate profiles are shown to their owner only.
+	ProfilePrivate ProfileVisibility = "private"
+)
+
+func (v ProfileVisibility) IsValid() bool {
+	switch v {
+	case ProfilePublic, ProfileUnlisted, ProfilePrivate:
+		return true
+	default:
+		return false
+	}
+}
+
+var ErrInvalidProfileVisibility = errors.New("visibility must be public, unlisted or private")
+
+// ProfilePrivacy is who may see the profile and which of its sections are
+// hidden from everyone but the owner. Activity is the number of started and
+// completed tests.
+type ProfilePrivacy struct {
+	UserID            ID
+	Visibility        ProfileVisibility
+	HidePersonalBests bool
+	HideTimePlayed    bool
+	HideLanguageStats bool
+	HideActivity      bool
+
+	// HideResults is set for everyone but the owner while the user is
+	// shadow-banned. The profile then looks like one of a user without results.
+	HideResults bool
+}
+
+// ForViewer returns the privacy as it applies to the viewer, viewerID is nil
+// for anonymous viewers. The owner sees every section.
+func (p *ProfilePrivacy) ForViewer(viewerID *ID) *ProfilePrivacy {
+	if viewerID != nil && *viewerID == p.UserID {
+		return &ProfilePrivacy{UserID: p.UserID, Visibility: p.Visibility}
+	}
+
+	return p
+}
+
+// ViewerClass tells apart the ways the privacy cuts a profile down, so a
+// profile cut down once serves every viewer of the class. The owner falls into
+// the class that hides nothing.
+func (p *ProfilePrivacy) ViewerClass() string {
+	var mask int
+	for _, hidden := range []bool{p.HideResults, p.HidePersonalBests, p.HideTimePlayed, p.HideLanguageStats, p.HideActivity} {
+		mask <<= 1
+		if hidden {
+			mask |= 1
+		}
+	}
+
+	return fmt.Sprintf("%05b", mask)
+}
+
+// ProfileViewerClasses returns every class ViewerClass can return.
+func ProfileViewerClasses() []string {
+	classes := make([]string, 0, 32)
+	for mask := range 32 {
+		classes = append(classes, fmt.Sprintf("%05b", mask))
+	}
+
+	return classes
+}
+
+// Cut returns a copy of the profile without the sections the privacy hides.
+// Per-language counters and time are hidden together with the totals,
+// otherwise the totals could be summed back up.
+func (p *ProfilePrivacy) Cut(profile *Profile) *Profile {
+	cut := *profile
+
+	if p.HideResults {
+		cut.PersonalBests, cut.LanguageStats = nil, nil
+		cut.TimePlayed, cut.StartedTests, cut.CompletedTests = 0, 0, 0
+		return &cut
+	}
+
+	if p.HidePersonalBests {
+		cut.PersonalBests = nil
+	}
+
+	if p.HideLanguageStats {
+		cut.LanguageStats = nil
+	} else {
+		cut.LanguageStats = slices.Clone(profile.LanguageStats)
+		for i := range cut.LanguageStats {
+			if p.HideActivity {
+				cut.LanguageStats[i].TestsCompleted, cut.LanguageStats[i].TestsStarted = 0, 0
+			}
+			if p.HideTimePlayed {
+				cut.LanguageStats[i].TimePlayed = 0
+			}
+		}
+	}
+
+	if p.HideTimePlayed {
+		cut.TimePlayed = 0
+	}
+	if p.HideActivity {
+		cut.StartedTests, cut.CompletedTests = 0, 0
+	}
+
+	return &cut
+}
+
+// ProfilePrivacyUpdate changes the fields that are not nil.
+type ProfilePrivacyUpdate struct {
+	Visibility        *ProfileVisibility
+	HidePersonalBests *bool
+	HideTimePlayed    *bool
+	HideLanguageStats *bool
+	HideActivity      *bool
+}
+
+func (u *ProfilePrivacyUpdate) Validate() error {
+	if u.Visibility != nil && !u.Visibility.IsValid() {
+		return ErrInvalidProfileVisibility
+	}
+
+	return nil
+}
+
+func IsProfilePrivacyValidationError(err error) bool {
+	return errors.Is(err, ErrInvalidProfileVisibility)
+}
@@ -0,0 +1,31 @@
+package models
+
+type Role string
+
+const (
+	RoleUser      Role = "user"
+	RoleModerator Role = "moderator"
+	RoleAdmin     Role = "admin"
+)
+
+func (r Role) rank() int {
+	switch r {
+	case RoleUser:
+		return 1
+	case RoleModerator:
+		return 2
+	case RoleAdmin:
+		return 3
+	default:
+		return 0
+	}
+}
+
+func (r Role) IsValid() bool {
+	return r.rank() > 0
+}
+
+// AtLeast reports whether r grants everything other grants.
+func (r Role) AtLeast(other Role) bool {
+	return r.IsValid() && r.rank() >= other.rank()
+}
@@ -0,0 +1,43 @@
+package models
+
+import "time"
+
+type SanctionKind string
+
+const (
+	// SanctionSuspension blocks login and revokes every session of the user.
+	SanctionSuspension SanctionKind = "suspension"
+	// SanctionShadowBan keeps accepting results of the user but hides them
+	// from leaderboards and public profiles.
+	SanctionShadowBan SanctionKind = "shadow_ban"
+)
+
+func (k SanctionKind) IsValid() bool {
+	switch k {
+	case SanctionSuspension, SanctionShadowBan:
+		return true
+	default:
+		return false
+	}
+}
+
+// Sanction is a moderation action applied to a user. It stays active until it
+// expires or a moderator lifts it.
+type Sanction struct {
+	ID        ID
+	UserID    ID
+	Kind      SanctionKind
+	Reason    string
+	ActorID   *ID
+	CreatedAt time.Time
+	ExpiresAt *time.Time
+	LiftedAt  *time.Time
+	LiftedBy  *ID
+}
+
+func (s *Sanction) IsActive(now time.Time) bool {
+	if s.LiftedAt != nil {
+		return false
+	}
+	return s.ExpiresAt == nil || now.Before(*s.ExpiresAt)
+}
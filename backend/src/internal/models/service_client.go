@@ -0,0 +1,20 @@
+package models
+
+import "time"
+
+// ServiceClient is an internal service allowed to call the service endpoints,
+// such as the antifroad module. It signs requests with its own secret, so a
+// single caller can be revoked without touching the others.
+type ServiceClient struct {
+	ID         ID
+	Name       string
+	Secret     string
+	CreatedBy  *ID
+	CreatedAt  time.Time
+	LastUsedAt *time.Time
+	RevokedAt  *time.Time
+}
+
+func (c *ServiceClient) IsRevoked() bool {
+	return c.RevokedAt != nil
+}
@@ -0,0 +1,22 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SessionFamilyMember is a refresh token issued within a single login.
+// All tokens obtained by rotating one another belong to the same family.
+type SessionFamilyMember struct {
+	RefreshToken string
+	FamilyID     uuid.UUID
+	UserID       ID
+	RotatedAt    *time.Time
+	ExpiresAt    time.Time
+}
+
+// IsRotated reports whether the token has already been exchanged for a new pair.
+func (m *SessionFamilyMember) IsRotated() bool {
+	return m.RotatedAt != nil
+}
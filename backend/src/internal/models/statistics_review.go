@@ -0,0 +1,71 @@
+package models
+
+import "time"
+
+type AnomalyReason string
+
+const (
+	AnomalyWPMSpike              AnomalyReason = "wpm_spike"
+	AnomalyDurationMismatch      AnomalyReason = "duration_mismatch"
+	AnomalyScriptedUncompleted   AnomalyReason = "scripted_uncompleted_tests"
+	AnomalyImplausibleKeystrokes AnomalyReason = "implausible_keystrokes"
+	AnomalyPastedInput           AnomalyReason = "pasted_input"
+	AnomalyUniformIntervals      AnomalyReason = "uniform_keystroke_intervals"
+	AnomalyUniformDwell          AnomalyReason = "uniform_dwell_times"
+	AnomalyRepeatedBigrams       AnomalyReason = "repeated_bigram_timings"
+	AnomalyProfileMismatch       AnomalyReason = "keystroke_profile_mismatch"
+)
+
+type ReviewStatus string
+
+const (
+	ReviewPending  ReviewStatus = "pending"
+	ReviewApproved ReviewStatus = "approved"
+	ReviewRejected ReviewStatus = "rejected"
+)
+
+func (s ReviewStatus) IsValid() bool {
+	switch s {
+	case ReviewPending, ReviewApproved, ReviewRejected:
+		return true
+	default:
+		return false
+	}
+}
+
+// HeldResult is a result held for review after its signature was verified.
+// The field names match the payload of reviews queued before it existed.
+// Durations are kept in nanoseconds, the unit of the statistics table.
+type HeldResult struct {
+	UserID                   ID
+	WPM                      float64
+	CPM                      float64
+	Accuracy                 float64
+	Duration                 time.Duration
+	Language                 string
+	Mode                     string
+	SubMode                  string
+	IsPunctuation            bool
+	UncompletedTestsCount    uint64
+	UncompletedTestsDuration time.Duration `json:"UncompletedTestsDurationMs"`
+	UID                      string
+	CreatedAt                time.Time
+	StartedAt                time.Time
+	FinishedAt               time.Time
+}
+
+// StatisticsReview is a submitted result held back for moderation because the
+// anomaly scorer flagged it. Payload is the verified HeldResult; it is stored
+// as is when a moderator approves the review.
+type StatisticsReview struct {
+	ID         ID
+	UserID     ID
+	UID        string
+	Payload    []byte
+	Score      float64
+	Reasons    []AnomalyReason
+	Status     ReviewStatus
+	CreatedAt  time.Time
+	ReviewedBy *ID
+	ReviewedAt *time.Time
+}
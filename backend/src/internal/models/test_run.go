@@ -0,0 +1,13 @@
+package models
+
+import "time"
+
+// TestRun is a test the user has started and not finished yet. It is removed
+// when the result is submitted, when the user starts another test or when it
+// times out.
+type TestRun struct {
+	ID         string
+	UserID     ID
+	Properties map[string]any
+	StartedAt  time.Time
+}
@@ -0,0 +1,31 @@
+package models
+
+import "time"
+
+// UserAccount is the administrative view of a user.
+type UserAccount struct {
+	ID        ID
+	Email     string
+	Nickname  string
+	Provider  string
+	Role      Role
+	CreatedAt time.Time
+	BannedAt  *time.Time
+
+	DeletionScheduledAt *time.Time // The account is deleted for good after this moment
+}
+
+func (a *UserAccount) IsBanned() bool {
+	return a.BannedAt != nil
+}
+
+func (a *UserAccount) IsDeletionScheduled() bool {
+	return a.DeletionScheduledAt != nil
+}
+
+// DeletedAccount is what is left to clean up outside the database after an
+// account is deleted.
+type DeletedAccount struct {
+	ID        ID
+	AvatarKey string
+}
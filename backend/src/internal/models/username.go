@@ -0,0 +1,43 @@
+package models
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Usernames that look alike share a skeleton: letters are lowercased, and
+// Cyrillic letters, digits and symbols that resemble Latin ones are replaced
+// with them, so ffh255 and ffh2SS both become ffh2ss. Postgres builds
+// the same skeleton with translate(lower(nickname), from, to), which is what
+// users_nickname_skeleton_idx is built on. Uppercase Cyrillic is listed too
+// because lower() leaves it as is under the C locale.
+const (
+	UsernameConfusablesFrom = "авеёкмнорстух015i-.АВЕЁКМНОРСТУХБГДЖЗИЙЛПФЦЧШЩЪЫЬЭЮЯ"
+	UsernameConfusablesTo   = "abeekmhopctyxolsl__abeekmhopctyxбгджзийлпфцчшщъыьэюя"
+)
+
+var usernameConfusables = newUsernameConfusables()
+
+func newUsernameConfusables() map[rune]rune {
+	from, to := []rune(UsernameConfusablesFrom), []rune(UsernameConfusablesTo)
+
+	confusables := make(map[rune]rune, len(from))
+	for i, r := range from {
+		confusables[r] = to[i]
+	}
+
+	return confusables
+}
+
+func UsernameSkeleton(username string) string {
+	var b strings.Builder
+	for _, r := range username {
+		r = unicode.ToLower(r)
+		if skeleton, ok := usernameConfusables[r]; ok {
+			r = skeleton
+		}
+		b.WriteRune(r)
+	}
+
+	return b.String()
+}
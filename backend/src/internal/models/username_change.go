@@ -0,0 +1,13 @@
+package models
+
+import "time"
+
+// UsernameChange is a username the user had before. Until ReservedUntil nobody
+// else can take it, so links to the old name keep leading to the same user.
+type UsernameChange struct {
+	ID            ID
+	UserID        ID
+	Username      string
+	ChangedAt     time.Time
+	ReservedUntil time.Time
+}
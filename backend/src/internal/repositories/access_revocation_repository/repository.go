@@ -0,0 +1,62 @@
+package access_revocation_repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/ruslanonly/blindtyping/src/internal/models"
+)
+
+const keyPrefix = "access_revoked_before"
+
+type redisClient interface {
+	Get(ctx context.Context, key string) *redis.StringCmd
+	Set(ctx context.Context, key string, value any, expiration time.Duration) *redis.StatusCmd
+}
+
+type Repository struct {
+	client redisClient
+	ttl    time.Duration
+}
+
+// New creates the repository. ttl must not be shorter than the access token lifetime,
+// otherwise the revocation mark expires before the revoked tokens do.
+func New(client redisClient, ttl time.Duration) *Repository {
+	return &Repository{
+		client: client,
+		ttl:    ttl,
+	}
+}
+
+func (r *Repository) key(userID models.ID) string {
+	return fmt.Sprintf("%s:%d", keyPrefix, userID)
+}
+
+// RevokeBefore revokes every access token of the user issued before revokedAt.
+func (r *Repository) RevokeBefore(ctx context.Context, userID models.ID, revokedAt time.Time) error {
+	return r.client.Set(ctx, r.key(userID), revokedAt.Unix(), r.ttl).Err()
+}
+
+// RevokedBefore returns the moment the user's tokens were revoked or nil if they never were.
+func (r *Repository) RevokedBefore(ctx context.Context, userID models.ID) (*time.Time, error) {
+	value, err := r.client.Get(ctx, r.key(userID)).Result()
+	if errors.Is(err, redis.Nil) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	unix, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return nil, err
+	}
+
+	revokedAt := time.Unix(unix, 0)
+	return &revokedAt, nil
+}
@@ -0,0 +1,90 @@
+package account_export_repository
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/ruslanonly/blindtyping/src/internal/models"
+)
+
+type database interface {
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+}
+
+// Repository reads the user's data that has no repository of its own in a
+// form suitable for the export.
+type Repository struct {
+	db database
+}
+
+func New(db database) *Repository {
+	return &Repository{db: db}
+}
+
+func (r *Repository) GetSessions(ctx context.Context, userID models.ID) ([]*models.AccountSession, error) {
+	sql := `
+		SELECT expires_at
+		FROM sessions
+		WHERE user_id = $1
+		ORDER BY expires_at`
+
+	rows, err := r.db.Query(ctx, sql, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	sessions := make([]*models.AccountSession, 0)
+	for rows.Next() {
+		var session models.AccountSession
+		if err = rows.Scan(&session.ExpiresAt); err != nil {
+			return nil, err
+		}
+		sessions = append(sessions, &session)
+	}
+
+	return sessions, rows.Err()
+}
+
+func (r *Repository) GetStatistics(ctx context.Context, userID models.ID) ([]*models.ExportedResult, error) {
+	sql := `
+		SELECT id, wpm, cpm, accuracy, duration, played_at, language, mode, sub_mode, is_punctuation,
+			uncompleted_tests_count, uncompleted_tests_total_duration, is_deleted
+		FROM statistics
+		WHERE user_id = $1
+		ORDER BY played_at`
+
+	rows, err := r.db.Query(ctx, sql, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	results := make([]*models.ExportedResult, 0)
+	for rows.Next() {
+		var result models.ExportedResult
+
+		err = rows.Scan(
+			&result.ID,
+			&result.WPM,
+			&result.CPM,
+			&result.Accuracy,
+			&result.Duration,
+			&result.PlayedAt,
+			&result.Language,
+			&result.Mode,
+			&result.SubMode,
+			&result.IsPunctuation,
+			&result.UncompletedTestsCount,
+			&result.UncompletedTestsTotalDuration,
+			&result.IsDeleted,
+		)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, &result)
+	}
+
+	return results, rows.Err()
+}
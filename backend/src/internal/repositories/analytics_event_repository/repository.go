@@ -0,0 +1,186 @@
+package analytics_event_repository
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"github.com/ruslanonly/blindtyping/src/internal/models"
+)
+
+type database interface {
+	Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
+type Repository struct {
+	db database
+}
+
+func New(db database) *Repository {
+	return &Repository{db: db}
+}
+
+// Append stores a batch of events with a single statement.
+func (r *Repository) Append(ctx context.Context, events []*models.AnalyticsEvent) error {
+	var (
+		names       = make([]string, 0, len(events))
+		userIDs     = make([]*models.ID, 0, len(events))
+		properties  = make([]string, 0, len(events))
+		occurredAts = make([]time.Time, 0, len(events))
+	)
+
+	for _, event := range events {
+		props := event.Properties
+		if props == nil {
+			props = map[string]any{}
+		}
+
+		encoded, err := json.Marshal(props)
+		if err != nil {
+			return err
+		}
+
+		names = append(names, string(event.Name))
+		userIDs = append(userIDs, event.UserID)
+		properties = append(properties, string(encoded))
+		occurredAts = append(occurredAts, event.OccurredAt)
+	}
+
+	query := `
+		INSERT INTO analytics_events (name, user_id, properties, occurred_at)
+		SELECT * FROM unnest($1::varchar[], $2::integer[], $3::text[]::jsonb[], $4::timestamptz[])`
+
+	_, err := r.db.Exec(ctx, query, names, userIDs, properties, occurredAts)
+	return err
+}
+
+// Funnel counts users that went through the steps in order. A user enters the
+// funnel with the first step made within [from, to); every next step has to
+// happen after the previous one. Steps nobody reached are left out.
+func (r *Repository) Funnel(
+	ctx context.Context,
+	steps []models.AnalyticsEventName,
+	from, to time.Time,
+) (map[int]int64, error) {
+	names := make([]string, 0, len(steps))
+	for _, step := range steps {
+		names = append(names, string(step))
+	}
+
+	query := `
+		WITH RECURSIVE reached AS (
+			SELECT user_id, 1 AS position, MIN(occurred_at) AS at
+			FROM analytics_events
+			WHERE name = ($1::varchar[])[1]
+				AND user_id IS NOT NULL
+				AND occurred_at >= $2
+				AND occurred_at < $3
+			GROUP BY user_id
+			UNION ALL
+			SELECT r.user_id, r.position + 1, next.at
+			FROM reached r
+			CROSS JOIN LATERAL (
+				SELECT MIN(e.occurred_at) AS at
+				FROM analytics_events e
+				WHERE e.user_id = r.user_id
+					AND e.name = ($1::varchar[])[r.position + 1]
+					AND e.occurred_at >= r.at
+			) next
+			WHERE r.position < cardinality($1::varchar[]) AND next.at IS NOT NULL
+		)
+		SELECT position, COUNT(*)
+		FROM reached
+		GROUP BY position`
+
+	rows, err := r.db.Query(ctx, query, names, from, to)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	users := make(map[int]int64, len(steps))
+	for rows.Next() {
+		var (
+			position int
+			count    int64
+		)
+		if err = rows.Scan(&position, &count); err != nil {
+			return nil, err
+		}
+
+		users[position] = count
+	}
+
+	return users, rows.Err()
+}
+
+// Retention groups users by the week of their cohortEvent within [from, to) and
+// counts how many of them made activeEvent in each of the following weeks.
+func (r *Repository) Retention(
+	ctx context.Context,
+	cohortEvent, activeEvent models.AnalyticsEventName,
+	from, to time.Time,
+	weeks int,
+) ([]*models.AnalyticsCohort, error) {
+	query := `
+		WITH cohorts AS (
+			SELECT user_id, date_trunc('week', MIN(occurred_at)) AS week
+			FROM analytics_events
+			WHERE name = $1 AND user_id IS NOT NULL
+			GROUP BY user_id
+			HAVING MIN(occurred_at) >= $3 AND MIN(occurred_at) < $4
+		), activity AS (
+			SELECT DISTINCT c.week, c.user_id, (date_trunc('week', e.occurred_at)::date - c.week::date) / 7 AS week_offset
+			FROM cohorts c
+			JOIN analytics_events e ON e.user_id = c.user_id AND e.name = $2 AND e.occurred_at >= c.week
+		)
+		SELECT c.week, c.users, a.week_offset, COALESCE(a.users, 0)
+		FROM (
+			SELECT week, COUNT(*) AS users FROM cohorts GROUP BY week
+		) c
+		LEFT JOIN (
+			SELECT week, week_offset, COUNT(*) AS users
+			FROM activity
+			WHERE week_offset < $5
+			GROUP BY week, week_offset
+		) a ON a.week = c.week
+		ORDER BY c.week`
+
+	rows, err := r.db.Query(ctx, query, cohortEvent, activeEvent, from, to, weeks)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	cohorts := make([]*models.AnalyticsCohort, 0)
+	for rows.Next() {
+		var (
+			week       time.Time
+			users      int64
+			weekOffset *int
+			active     int64
+		)
+		if err = rows.Scan(&week, &users, &weekOffset, &active); err != nil {
+			return nil, err
+		}
+
+		if len(cohorts) == 0 || !cohorts[len(cohorts)-1].Week.Equal(week) {
+			cohorts = append(cohorts, &models.AnalyticsCohort{
+				Week:   week,
+				Users:  users,
+				Active: make([]int64, weeks),
+			})
+		}
+
+		if weekOffset != nil {
+			cohorts[len(cohorts)-1].Active[*weekOffset] = active
+		}
+	}
+
+	return cohorts, rows.Err()
+}
@@ -0,0 +1,94 @@
+package antifroad_key_version_repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"github.com/ruslanonly/blindtyping/src/internal/models"
+)
+
+type database interface {
+	Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
+type Repository struct {
+	db database
+}
+
+func New(db database) *Repository {
+	return &Repository{db: db}
+}
+
+// GetValid returns keys that are still valid at the given time, newest first.
+func (r *Repository) GetValid(ctx context.Context, at time.Time) ([]*models.AntifroadKeyVersion, error) {
+	query := `
+		SELECT kid, key, not_before, not_after
+		FROM antifroad_keys
+		WHERE not_after IS NULL OR not_after > $1
+		ORDER BY not_before DESC`
+
+	rows, err := r.db.Query(ctx, query, at)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	keys := make([]*models.AntifroadKeyVersion, 0)
+	for rows.Next() {
+		var key models.AntifroadKeyVersion
+		if err = rows.Scan(&key.KID, &key.Value, &key.NotBefore, &key.NotAfter); err != nil {
+			return nil, err
+		}
+
+		keys = append(keys, &key)
+	}
+
+	return keys, rows.Err()
+}
+
+// Rotate stores the new key, makes every other active key expire at retireAt,
+// deletes expired keys and records the rotation. All of it happens in a single
+// statement, so concurrent rotations never leave two active keys behind.
+func (r *Repository) Rotate(
+	ctx context.Context,
+	key *models.AntifroadKeyVersion,
+	trigger models.AntifroadRotationTrigger,
+	retireAt time.Time,
+) (*models.AntifroadKeyRotation, error) {
+	query := `
+		WITH retired AS (
+			UPDATE antifroad_keys
+			SET not_after = $4
+			WHERE not_after IS NULL
+			RETURNING kid
+		), deleted AS (
+			DELETE FROM antifroad_keys
+			WHERE not_after < $3
+		), created AS (
+			INSERT INTO antifroad_keys (kid, key, created_at, not_before)
+			VALUES ($1, $2, $3, $3)
+		)
+		INSERT INTO antifroad_key_rotations (kid, retired_kids, trigger, rotated_at)
+		SELECT $1, COALESCE(array_agg(kid), '{}'), $5, $3
+		FROM retired
+		RETURNING id, kid, retired_kids, trigger, rotated_at`
+
+	var rotation models.AntifroadKeyRotation
+	err := r.db.QueryRow(ctx, query, key.KID, key.Value, key.NotBefore, retireAt, trigger).Scan(
+		&rotation.ID,
+		&rotation.KID,
+		&rotation.RetiredKIDs,
+		&rotation.Trigger,
+		&rotation.RotatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &rotation, nil
+}
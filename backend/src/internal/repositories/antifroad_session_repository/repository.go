@@ -0,0 +1,60 @@
+package antifroad_session_repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/ruslanonly/blindtyping/src/internal/models"
+)
+
+const keyPrefix = "antifroad_session"
+
+type redisClient interface {
+	Get(ctx context.Context, key string) *redis.StringCmd
+	Set(ctx context.Context, key string, value any, expiration time.Duration) *redis.StatusCmd
+}
+
+type Repository struct {
+	client redisClient
+}
+
+func New(client redisClient) *Repository {
+	return &Repository{client: client}
+}
+
+func (r *Repository) key(id string) string {
+	return fmt.Sprintf("%s:%s", keyPrefix, id)
+}
+
+// Save stores the session until it expires.
+func (r *Repository) Save(ctx context.Context, session *models.AntifroadSession) error {
+	value, err := json.Marshal(session)
+	if err != nil {
+		return err
+	}
+
+	return r.client.Set(ctx, r.key(session.ID), value, time.Until(session.ExpiresAt)).Err()
+}
+
+// Get returns the session or nil if it does not exist or has expired.
+func (r *Repository) Get(ctx context.Context, id string) (*models.AntifroadSession, error) {
+	value, err := r.client.Get(ctx, r.key(id)).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var session models.AntifroadSession
+	if err = json.Unmarshal(value, &session); err != nil {
+		return nil, err
+	}
+
+	return &session, nil
+}
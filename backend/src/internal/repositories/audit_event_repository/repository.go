@@ -0,0 +1,145 @@
+package audit_event_repository
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"github.com/ruslanonly/blindtyping/src/internal/models"
+)
+
+const eventColumns = `id, action, user_id, actor_id, actor_service, ip, user_agent, request_id, details, created_at`
+
+type database interface {
+	Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+}
+
+type Repository struct {
+	db database
+}
+
+func New(db database) *Repository {
+	return &Repository{db: db}
+}
+
+func (r *Repository) Append(ctx context.Context, event *models.AuditEvent) error {
+	details := event.Details
+	if details == nil {
+		details = map[string]any{}
+	}
+
+	encoded, err := json.Marshal(details)
+	if err != nil {
+		return err
+	}
+
+	var actorService *string
+	if event.ActorService != "" {
+		actorService = &event.ActorService
+	}
+
+	sql := `
+		INSERT INTO audit_events (action, user_id, actor_id, actor_service, ip, user_agent, request_id, details, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`
+
+	_, err = r.db.Exec(ctx, sql,
+		event.Action,
+		event.UserID,
+		event.ActorID,
+		actorService,
+		event.IP,
+		event.UserAgent,
+		event.RequestID,
+		encoded,
+		event.CreatedAt,
+	)
+	return err
+}
+
+// Search returns events matching the filter, newest first.
+func (r *Repository) Search(ctx context.Context, filter *models.AuditFilter) ([]*models.AuditEvent, error) {
+	var action, ip *string
+	if filter.Action != "" {
+		value := string(filter.Action)
+		action = &value
+	}
+	if filter.IP != "" {
+		ip = &filter.IP
+	}
+
+	sql := `
+		SELECT ` + eventColumns + `
+		FROM audit_events
+		WHERE ($1::integer IS NULL OR user_id = $1)
+			AND ($2::integer IS NULL OR actor_id = $2)
+			AND ($3::varchar IS NULL OR action = $3)
+			AND ($4::varchar IS NULL OR ip = $4)
+			AND ($5::timestamptz IS NULL OR created_at >= $5)
+			AND ($6::timestamptz IS NULL OR created_at < $6)
+			AND ($7::bigint IS NULL OR id < $7)
+		ORDER BY id DESC
+		LIMIT $8`
+
+	rows, err := r.db.Query(ctx, sql,
+		filter.UserID,
+		filter.ActorID,
+		action,
+		ip,
+		filter.From,
+		filter.To,
+		filter.BeforeID,
+		filter.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	events := make([]*models.AuditEvent, 0)
+	for rows.Next() {
+		event, err := scanEvent(rows)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+
+	return events, rows.Err()
+}
+
+func scanEvent(row pgx.Row) (*models.AuditEvent, error) {
+	var (
+		event        models.AuditEvent
+		actorService *string
+		details      []byte
+	)
+
+	err := row.Scan(
+		&event.ID,
+		&event.Action,
+		&event.UserID,
+		&event.ActorID,
+		&actorService,
+		&event.IP,
+		&event.UserAgent,
+		&event.RequestID,
+		&details,
+		&event.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if actorService != nil {
+		event.ActorService = *actorService
+	}
+
+	if err = json.Unmarshal(details, &event.Details); err != nil {
+		return nil, err
+	}
+
+	return &event, nil
+}
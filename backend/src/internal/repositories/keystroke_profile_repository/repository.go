@@ -0,0 +1,75 @@
+package keystroke_profile_repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"github.com/ruslanonly/blindtyping/src/internal/models"
+)
+
+type database interface {
+	Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
+type Repository struct {
+	db database
+}
+
+func New(db database) *Repository {
+	return &Repository{db: db}
+}
+
+// Get returns the profile or nil if the user has none yet.
+func (r *Repository) Get(ctx context.Context, userID models.ID) (*models.KeystrokeProfile, error) {
+	sql := `
+		SELECT user_id, samples, interval_mean, interval_cv, dwell_mean, updated_at
+		FROM keystroke_profiles
+		WHERE user_id = $1`
+
+	var profile models.KeystrokeProfile
+
+	err := r.db.QueryRow(ctx, sql, userID).Scan(
+		&profile.UserID,
+		&profile.Samples,
+		&profile.IntervalMean,
+		&profile.IntervalCV,
+		&profile.DwellMean,
+		&profile.UpdatedAt,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &profile, nil
+}
+
+func (r *Repository) Save(ctx context.Context, profile *models.KeystrokeProfile) error {
+	sql := `
+		INSERT INTO keystroke_profiles (user_id, samples, interval_mean, interval_cv, dwell_mean, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (user_id) DO UPDATE
+		SET samples = EXCLUDED.samples,
+			interval_mean = EXCLUDED.interval_mean,
+			interval_cv = EXCLUDED.interval_cv,
+			dwell_mean = EXCLUDED.dwell_mean,
+			updated_at = EXCLUDED.updated_at`
+
+	_, err := r.db.Exec(
+		ctx,
+		sql,
+		profile.UserID,
+		profile.Samples,
+		profile.IntervalMean,
+		profile.IntervalCV,
+		profile.DwellMean,
+		profile.UpdatedAt,
+	)
+	return err
+}
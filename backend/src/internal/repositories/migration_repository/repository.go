@@ -0,0 +1,73 @@
+package migration_repository
+
+import (
+	"context"
+	"errors"
+	"os"
+
+	"github.com/golang-migrate/migrate/v4/source"
+	_ "github.com/golang-migrate/migrate/v4/source/file"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/ruslanonly/blindtyping/src/internal/models"
+)
+
+type database interface {
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
+// Repository compares the schema version of the database with the migrations
+// shipped with the binary.
+type Repository struct {
+	db     database
+	source string
+}
+
+func New(db database, source string) *Repository {
+	return &Repository{
+		db:     db,
+		source: source,
+	}
+}
+
+// Applied returns the version the database is migrated to, or nil if no
+// migration has run yet.
+func (r *Repository) Applied(ctx context.Context) (*models.MigrationVersion, error) {
+	query := `SELECT version, dirty FROM schema_migrations LIMIT 1`
+
+	var version models.MigrationVersion
+	err := r.db.QueryRow(ctx, query).Scan(&version.Version, &version.Dirty)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &version, nil
+}
+
+// Latest returns the version of the last migration in the source.
+func (r *Repository) Latest() (uint, error) {
+	driver, err := source.Open(r.source)
+	if err != nil {
+		return 0, err
+	}
+	defer driver.Close()
+
+	version, err := driver.First()
+	if err != nil {
+		return 0, err
+	}
+
+	for {
+		next, err := driver.Next(version)
+		if errors.Is(err, os.ErrNotExist) {
+			return version, nil
+		}
+		if err != nil {
+			return 0, err
+		}
+		version = next
+	}
+}
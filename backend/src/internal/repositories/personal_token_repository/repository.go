@@ -0,0 +1,159 @@
+package personal_token_repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"github.com/ruslanonly/blindtyping/src/internal/models"
+)
+
+type database interface {
+	Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
+type Repository struct {
+	db database
+}
+
+func New(db database) *Repository {
+	return &Repository{db: db}
+}
+
+func scanToken(row pgx.Row) (*models.PersonalToken, error) {
+	var (
+		token  models.PersonalToken
+		scopes []string
+	)
+
+	err := row.Scan(
+		&token.ID,
+		&token.UserID,
+		&token.Name,
+		&scopes,
+		&token.CreatedAt,
+		&token.ExpiresAt,
+		&token.LastUsedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	token.Scopes = make([]models.Scope, 0, len(scopes))
+	for _, scope := range scopes {
+		token.Scopes = append(token.Scopes, models.Scope(scope))
+	}
+
+	return &token, nil
+}
+
+// Create stores the token in the lowest free of the user's maxPerUser slots
+// and returns nil if every slot is taken. The unique slot index keeps
+// concurrent inserts from going over the limit: the one that loses the race
+// for a slot gets nil as well.
+func (r *Repository) Create(ctx context.Context, token *models.PersonalToken, tokenHash string, maxPerUser int) (*models.PersonalToken, error) {
+	scopes := make([]string, 0, len(token.Scopes))
+	for _, scope := range token.Scopes {
+		scopes = append(scopes, string(scope))
+	}
+
+	query := `
+		INSERT INTO personal_tokens (user_id, slot, name, token_hash, scopes, created_at, expires_at)
+		SELECT $1, free.slot, $2, $3, $4, $5, $6
+		FROM generate_series(1, $7::int) AS free(slot)
+		WHERE NOT EXISTS (
+			SELECT 1 FROM personal_tokens AS t WHERE t.user_id = $1 AND t.slot = free.slot
+		)
+		ORDER BY free.slot
+		LIMIT 1
+		ON CONFLICT (user_id, slot) DO NOTHING
+		RETURNING id, user_id, name, scopes, created_at, expires_at, last_used_at`
+
+	row := r.db.QueryRow(ctx, query, token.UserID, token.Name, tokenHash, scopes, token.CreatedAt, token.ExpiresAt, maxPerUser)
+	created, err := scanToken(row)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+
+	return created, err
+}
+
+func (r *Repository) GetByUserID(ctx context.Context, userID models.ID) ([]*models.PersonalToken, error) {
+	query := `
+		SELECT id, user_id, name, scopes, created_at, expires_at, last_used_at
+		FROM personal_tokens
+		WHERE user_id = $1
+		ORDER BY created_at DESC`
+
+	rows, err := r.db.Query(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	tokens := make([]*models.PersonalToken, 0)
+	for rows.Next() {
+		token, err := scanToken(rows)
+		if err != nil {
+			return nil, err
+		}
+		tokens = append(tokens, token)
+	}
+
+	return tokens, rows.Err()
+}
+
+// GetByHash returns the token or nil if no token has this hash or its owner
+// is banned or suspended at now.
+func (r *Repository) GetByHash(ctx context.Context, tokenHash string, now time.Time) (*models.PersonalToken, error) {
+	query := `
+		SELECT t.id, t.user_id, t.name, t.scopes, t.created_at, t.expires_at, t.last_used_at
+		FROM personal_tokens AS t
+		JOIN users AS u ON u.id = t.user_id
+		WHERE t.token_hash = $1
+			AND u.banned_at IS NULL
+			AND NOT EXISTS (
+				SELECT 1
+				FROM user_sanctions AS s
+				WHERE s.user_id = t.user_id
+					AND s.kind = $2
+					AND s.lifted_at IS NULL
+					AND (s.expires_at IS NULL OR s.expires_at > $3)
+			)`
+
+	token, err := scanToken(r.db.QueryRow(ctx, query, tokenHash, models.SanctionSuspension, now))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+
+	return token, err
+}
+
+// Touch records token usage unless it was already recorded after usedBefore,
+// so concurrent requests with the same token write it once.
+func (r *Repository) Touch(ctx context.Context, id models.ID, usedAt, usedBefore time.Time) error {
+	query := `
+		UPDATE personal_tokens
+		SET last_used_at = $2
+		WHERE id = $1 AND (last_used_at IS NULL OR last_used_at < $3)`
+
+	_, err := r.db.Exec(ctx, query, id, usedAt, usedBefore)
+	return err
+}
+
+// Delete removes the token and reports whether the user owned a token with this id.
+func (r *Repository) Delete(ctx context.Context, userID, id models.ID) (bool, error) {
+	query := `DELETE FROM personal_tokens WHERE id = $1 AND user_id = $2`
+
+	tag, err := r.db.Exec(ctx, query, id, userID)
+	if err != nil {
+		return false, err
+	}
+
+	return tag.RowsAffected() > 0, nil
+}
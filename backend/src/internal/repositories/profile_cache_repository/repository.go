@@ -0,0 +1,86 @@
+package profile_cache_repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/ruslanonly/blindtyping/src/internal/models"
+)
+
+const keyPrefix = "profile"
+
+type redisClient interface {
+	Get(ctx context.Context, key string) *redis.StringCmd
+	Set(ctx context.Context, key string, value any, expiration time.Duration) *redis.StatusCmd
+	Del(ctx context.Context, keys ...string) *redis.IntCmd
+}
+
+// Repository keeps profiles by username and viewer class for the profile cache
+// lifetime.
+type Repository struct {
+	client redisClient
+	ttl    atomic.Int64
+}
+
+func New(client redisClient, ttl time.Duration) *Repository {
+	r := &Repository{client: client}
+	r.SetTTL(ttl)
+	return r
+}
+
+// SetTTL changes the lifetime of profiles cached from now on. Cached profiles
+// keep theirs.
+func (r *Repository) SetTTL(ttl time.Duration) {
+	r.ttl.Store(int64(ttl))
+}
+
+func (r *Repository) key(username, viewerClass string) string {
+	return fmt.Sprintf("%s:%s:%s", keyPrefix, username, viewerClass)
+}
+
+// Get returns the cached profile or nil if there is none.
+func (r *Repository) Get(ctx context.Context, username, viewerClass string) (*models.Profile, error) {
+	value, err := r.client.Get(ctx, r.key(username, viewerClass)).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var profile models.Profile
+	if err = json.Unmarshal(value, &profile); err != nil {
+		return nil, err
+	}
+
+	return &profile, nil
+}
+
+func (r *Repository) Set(ctx context.Context, username, viewerClass string, profile *models.Profile) error {
+	value, err := json.Marshal(profile)
+	if err != nil {
+		return err
+	}
+
+	return r.client.Set(ctx, r.key(username, viewerClass), value, time.Duration(r.ttl.Load())).Err()
+}
+
+// Delete drops the cached profiles of the usernames for every viewer class.
+func (r *Repository) Delete(ctx context.Context, usernames ...string) error {
+	classes := models.ProfileViewerClasses()
+
+	keys := make([]string, 0, len(usernames)*len(classes))
+	for _, username := range usernames {
+		for _, class := range classes {
+			keys = append(keys, r.key(username, class))
+		}
+	}
+
+	return r.client.Del(ctx, keys...).Err()
+}
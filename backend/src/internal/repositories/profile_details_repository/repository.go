@@ -0,0 +1,123 @@
+package profile_details_repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"github.com/ruslanonly/blindtyping/src/internal/models"
+)
+
+const detailsColumns = `COALESCE(u.avatar_key, ''), COALESCE(pa.avatar_url, ''), u.bio, u.country, u.keyboard, u.layout`
+
+type database interface {
+	Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
+// Repository stores the profile fields filled in by the user in the users
+// table. Provider avatars are kept by email, because the provider hands one
+// out before the user has finished registration.
+type Repository struct {
+	db database
+}
+
+func New(db database) *Repository {
+	return &Repository{db: db}
+}
+
+func scanDetails(row pgx.Row) (*models.ProfileDetails, error) {
+	var details models.ProfileDetails
+
+	err := row.Scan(
+		&details.AvatarKey,
+		&details.ProviderAvatarURL,
+		&details.Bio,
+		&details.Country,
+		&details.Keyboard,
+		&details.Layout,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &details, nil
+}
+
+// GetByUserID returns the details or nil if there is no such user.
+func (r *Repository) GetByUserID(ctx context.Context, userID models.ID) (*models.ProfileDetails, error) {
+	sql := `
+		SELECT ` + detailsColumns + `
+		FROM users u
+		LEFT JOIN provider_avatars pa ON pa.email = u.email
+		WHERE u.id = $1`
+
+	return scanDetails(r.db.QueryRow(ctx, sql, userID))
+}
+
+// GetByNickname returns the details or nil if there is no such user.
+func (r *Repository) GetByNickname(ctx context.Context, nickname string) (*models.ProfileDetails, error) {
+	sql := `
+		SELECT ` + detailsColumns + `
+		FROM users u
+		LEFT JOIN provider_avatars pa ON pa.email = u.email
+		WHERE u.nickname = $1`
+
+	return scanDetails(r.db.QueryRow(ctx, sql, nickname))
+}
+
+// Update changes the fields that are set and returns the details after the
+// change, or nil if there is no such user.
+func (r *Repository) Update(ctx context.Context, userID models.ID, update *models.ProfileDetailsUpdate) (*models.ProfileDetails, error) {
+	sql := `
+		WITH u AS (
+			UPDATE users
+			SET bio = COALESCE($2, bio),
+				country = COALESCE($3, country),
+				keyboard = COALESCE($4, keyboard),
+				layout = COALESCE($5, layout)
+			WHERE id = $1
+			RETURNING email, avatar_key, bio, country, keyboard, layout
+		)
+		SELECT ` + detailsColumns + `
+		FROM u
+		LEFT JOIN provider_avatars pa ON pa.email = u.email`
+
+	return scanDetails(r.db.QueryRow(ctx, sql, userID, update.Bio, update.Country, update.Keyboard, update.Layout))
+}
+
+// SetAvatarKey stores the key of the uploaded avatar and returns the key of
+// the one it replaced, empty if there was none.
+func (r *Repository) SetAvatarKey(ctx context.Context, userID models.ID, key string) (string, error) {
+	sql := `
+		UPDATE users u
+		SET avatar_key = $2
+		FROM (SELECT id, avatar_key FROM users WHERE id = $1 FOR UPDATE) previous
+		WHERE u.id = previous.id
+		RETURNING COALESCE(previous.avatar_key, '')`
+
+	var previous string
+	err := r.db.QueryRow(ctx, sql, userID, key).Scan(&previous)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return "", nil
+	}
+
+	return previous, err
+}
+
+func (r *Repository) SetProviderAvatar(ctx context.Context, email, avatarURL string, updatedAt time.Time) error {
+	sql := `
+		INSERT INTO provider_avatars (email, avatar_url, updated_at)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (email) DO UPDATE
+		SET avatar_url = EXCLUDED.avatar_url, updated_at = EXCLUDED.updated_at`
+
+	_, err := r.db.Exec(ctx, sql, email, avatarURL, updatedAt)
+	return err
+}
@@ -0,0 +1,89 @@
+package profile_privacy_repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/ruslanonly/blindtyping/src/internal/models"
+)
+
+const privacyColumns = `id, profile_visibility, hide_personal_bests, hide_time_played, hide_language_stats, hide_activity`
+
+type database interface {
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
+// Repository stores the profile privacy settings in the users table.
+type Repository struct {
+	db database
+}
+
+func New(db database) *Repository {
+	return &Repository{db: db}
+}
+
+func scanPrivacy(row pgx.Row) (*models.ProfilePrivacy, error) {
+	var (
+		privacy    models.ProfilePrivacy
+		visibility string
+	)
+
+	err := row.Scan(
+		&privacy.UserID,
+		&visibility,
+		&privacy.HidePersonalBests,
+		&privacy.HideTimePlayed,
+		&privacy.HideLanguageStats,
+		&privacy.HideActivity,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	privacy.Visibility = models.ProfileVisibility(visibility)
+	return &privacy, nil
+}
+
+// GetByUserID returns the settings or nil if there is no such user.
+func (r *Repository) GetByUserID(ctx context.Context, userID models.ID) (*models.ProfilePrivacy, error) {
+	sql := `SELECT ` + privacyColumns + ` FROM users WHERE id = $1`
+
+	return scanPrivacy(r.db.QueryRow(ctx, sql, userID))
+}
+
+// GetByNickname returns the settings or nil if there is no such user.
+func (r *Repository) GetByNickname(ctx context.Context, nickname string) (*models.ProfilePrivacy, error) {
+	sql := `SELECT ` + privacyColumns + ` FROM users WHERE nickname = $1`
+
+	return scanPrivacy(r.db.QueryRow(ctx, sql, nickname))
+}
+
+// Update changes the fields that are set and returns the settings after the
+// change, or nil if there is no such user.
+func (r *Repository) Update(ctx context.Context, userID models.ID, update *models.ProfilePrivacyUpdate) (*models.ProfilePrivacy, error) {
+	sql := `
+		UPDATE users
+		SET profile_visibility = COALESCE($2, profile_visibility),
+			hide_personal_bests = COALESCE($3, hide_personal_bests),
+			hide_time_played = COALESCE($4, hide_time_played),
+			hide_language_stats = COALESCE($5, hide_language_stats),
+			hide_activity = COALESCE($6, hide_activity)
+		WHERE id = $1
+		RETURNING ` + privacyColumns
+
+	var visibility *string
+	if update.Visibility != nil {
+		value := string(*update.Visibility)
+		visibility = &value
+	}
+
+	return scanPrivacy(r.db.QueryRow(
+		ctx, sql, userID, visibility,
+		update.HidePersonalBests, update.HideTimePlayed, update.HideLanguageStats, update.HideActivity,
+	))
+}
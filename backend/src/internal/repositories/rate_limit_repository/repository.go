@@ -0,0 +1,50 @@
+package rate_limit_repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const keyPrefix = "rate_limit"
+
+// hitScript counts a hit in a fixed window. The window starts with the first
+// hit and expires on its own, so counters are shared by every instance and
+// survive restarts.
+var hitScript = redis.NewScript(`
+local count = redis.call('INCR', KEYS[1])
+if count == 1 then
+	redis.call('PEXPIRE', KEYS[1], ARGV[1])
+end
+return {count, redis.call('PTTL', KEYS[1])}
+`)
+
+type redisClient interface {
+	redis.Scripter
+}
+
+type Repository struct {
+	client redisClient
+}
+
+func New(client redisClient) *Repository {
+	return &Repository{client: client}
+}
+
+// Hit counts a request for the key and returns how many requests were made in
+// the current window and when the window resets.
+func (r *Repository) Hit(ctx context.Context, key string, period time.Duration) (int64, time.Duration, error) {
+	values, err := hitScript.Run(
+		ctx,
+		r.client,
+		[]string{fmt.Sprintf("%s:%s", keyPrefix, key)},
+		period.Milliseconds(),
+	).Int64Slice()
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return values[0], time.Duration(values[1]) * time.Millisecond, nil
+}
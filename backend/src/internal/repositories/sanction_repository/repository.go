@@ -0,0 +1,160 @@
+package sanction_repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"github.com/ruslanonly/blindtyping/src/internal/models"
+)
+
+const sanctionColumns = `id, user_id, kind, reason, actor_id, created_at, expires_at, lifted_at, lifted_by`
+
+type database interface {
+	Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
+type Repository struct {
+	db database
+}
+
+func New(db database) *Repository {
+	return &Repository{db: db}
+}
+
+func scanSanction(row pgx.Row) (*models.Sanction, error) {
+	var sanction models.Sanction
+
+	err := row.Scan(
+		&sanction.ID,
+		&sanction.UserID,
+		&sanction.Kind,
+		&sanction.Reason,
+		&sanction.ActorID,
+		&sanction.CreatedAt,
+		&sanction.ExpiresAt,
+		&sanction.LiftedAt,
+		&sanction.LiftedBy,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &sanction, nil
+}
+
+// Create stores the sanction. A suspension also deletes all sessions and
+// personal tokens of the user.
+func (r *Repository) Create(ctx context.Context, sanction *models.Sanction) (*models.Sanction, error) {
+	sql := `
+		WITH sessions_deleted AS (
+			DELETE FROM sessions
+			WHERE user_id = $1 AND $7::boolean
+		), tokens_deleted AS (
+			DELETE FROM personal_tokens
+			WHERE user_id = $1 AND $7::boolean
+		)
+		INSERT INTO user_sanctions (user_id, kind, reason, actor_id, created_at, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING ` + sanctionColumns
+
+	row := r.db.QueryRow(
+		ctx,
+		sql,
+		sanction.UserID,
+		sanction.Kind,
+		sanction.Reason,
+		sanction.ActorID,
+		sanction.CreatedAt,
+		sanction.ExpiresAt,
+		sanction.Kind == models.SanctionSuspension,
+	)
+	return scanSanction(row)
+}
+
+// Get returns the sanction or nil if there is no such sanction.
+func (r *Repository) Get(ctx context.Context, id models.ID) (*models.Sanction, error) {
+	sql := `SELECT ` + sanctionColumns + ` FROM user_sanctions WHERE id = $1`
+
+	sanction, err := scanSanction(r.db.QueryRow(ctx, sql, id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+
+	return sanction, err
+}
+
+// GetByUserID returns every sanction of the user, newest first.
+func (r *Repository) GetByUserID(ctx context.Context, userID models.ID) ([]*models.Sanction, error) {
+	sql := `
+		SELECT ` + sanctionColumns + `
+		FROM user_sanctions
+		WHERE user_id = $1
+		ORDER BY created_at DESC`
+
+	rows, err := r.db.Query(ctx, sql, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	sanctions := make([]*models.Sanction, 0)
+	for rows.Next() {
+		sanction, err := scanSanction(rows)
+		if err != nil {
+			return nil, err
+		}
+		sanctions = append(sanctions, sanction)
+	}
+
+	return sanctions, rows.Err()
+}
+
+// HasActive reports whether the user has a sanction of the kind that is neither
+// lifted nor expired at now.
+func (r *Repository) HasActive(ctx context.Context, userID models.ID, kind models.SanctionKind, now time.Time) (bool, error) {
+	sql := `
+		SELECT EXISTS (
+			SELECT 1
+			FROM user_sanctions
+			WHERE user_id = $1
+				AND kind = $2
+				AND lifted_at IS NULL
+				AND (expires_at IS NULL OR expires_at > $3)
+		)`
+
+	var exists bool
+	err := r.db.QueryRow(ctx, sql, userID, kind, now).Scan(&exists)
+	return exists, err
+}
+
+// Lift marks the sanction lifted by the actor.
+func (r *Repository) Lift(ctx context.Context, id models.ID, actorID models.ID, liftedAt time.Time) error {
+	sql := `
+		UPDATE user_sanctions
+		SET lifted_at = $3, lifted_by = $2
+		WHERE id = $1 AND lifted_at IS NULL`
+
+	_, err := r.db.Exec(ctx, sql, id, actorID, liftedAt)
+	return err
+}
+
+// LiftExpired lifts every sanction whose expiry has passed and returns their count.
+func (r *Repository) LiftExpired(ctx context.Context, now time.Time) (int64, error) {
+	sql := `
+		UPDATE user_sanctions
+		SET lifted_at = expires_at
+		WHERE lifted_at IS NULL AND expires_at <= $1`
+
+	tag, err := r.db.Exec(ctx, sql, now)
+	if err != nil {
+		return 0, err
+	}
+
+	return tag.RowsAffected(), nil
+}
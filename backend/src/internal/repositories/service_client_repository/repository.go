@@ -0,0 +1,123 @@
+package service_client_repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"github.com/ruslanonly/blindtyping/src/internal/models"
+)
+
+const clientColumns = `id, name, secret, created_by, created_at, last_used_at, revoked_at`
+
+type database interface {
+	Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
+type Repository struct {
+	db database
+}
+
+func New(db database) *Repository {
+	return &Repository{db: db}
+}
+
+func scanClient(row pgx.Row) (*models.ServiceClient, error) {
+	var client models.ServiceClient
+
+	err := row.Scan(
+		&client.ID,
+		&client.Name,
+		&client.Secret,
+		&client.CreatedBy,
+		&client.CreatedAt,
+		&client.LastUsedAt,
+		&client.RevokedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &client, nil
+}
+
+func scanClientOrNil(row pgx.Row) (*models.ServiceClient, error) {
+	client, err := scanClient(row)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	return client, err
+}
+
+// Create stores the client. It returns nil if a client with the same name
+// already exists, revoked or not.
+func (r *Repository) Create(ctx context.Context, client *models.ServiceClient) (*models.ServiceClient, error) {
+	query := `
+		INSERT INTO service_clients (name, secret, created_by, created_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (name) DO NOTHING
+		RETURNING ` + clientColumns
+
+	row := r.db.QueryRow(ctx, query, client.Name, client.Secret, client.CreatedBy, client.CreatedAt)
+	return scanClientOrNil(row)
+}
+
+func (r *Repository) GetAll(ctx context.Context) ([]*models.ServiceClient, error) {
+	query := `SELECT ` + clientColumns + ` FROM service_clients ORDER BY created_at DESC`
+
+	rows, err := r.db.Query(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	clients := make([]*models.ServiceClient, 0)
+	for rows.Next() {
+		client, err := scanClient(rows)
+		if err != nil {
+			return nil, err
+		}
+		clients = append(clients, client)
+	}
+
+	return clients, rows.Err()
+}
+
+func (r *Repository) GetByID(ctx context.Context, id models.ID) (*models.ServiceClient, error) {
+	query := `SELECT ` + clientColumns + ` FROM service_clients WHERE id = $1`
+
+	return scanClientOrNil(r.db.QueryRow(ctx, query, id))
+}
+
+func (r *Repository) GetByName(ctx context.Context, name string) (*models.ServiceClient, error) {
+	query := `SELECT ` + clientColumns + ` FROM service_clients WHERE name = $1`
+
+	return scanClientOrNil(r.db.QueryRow(ctx, query, name))
+}
+
+func (r *Repository) Touch(ctx context.Context, id models.ID, usedAt, usedBefore time.Time) error {
+	query := `
+		UPDATE service_clients
+		SET last_used_at = $2
+		WHERE id = $1 AND (last_used_at IS NULL OR last_used_at < $3)`
+
+	_, err := r.db.Exec(ctx, query, id, usedAt, usedBefore)
+	return err
+}
+
+// Revoke revokes the client if it is not revoked yet. It returns nil if there
+// is no such active client.
+func (r *Repository) Revoke(ctx context.Context, id models.ID, revokedAt time.Time) (*models.ServiceClient, error) {
+	query := `
+		UPDATE service_clients
+		SET revoked_at = $2
+		WHERE id = $1 AND revoked_at IS NULL
+		RETURNING ` + clientColumns
+
+	return scanClientOrNil(r.db.QueryRow(ctx, query, id, revokedAt))
+}
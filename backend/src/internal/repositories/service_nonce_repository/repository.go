@@ -0,0 +1,31 @@
+package service_nonce_repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const keyPrefix = "service_nonce"
+
+type redisClient interface {
+	SetNX(ctx context.Context, key string, value any, expiration time.Duration) *redis.BoolCmd
+}
+
+type Repository struct {
+	client redisClient
+}
+
+func New(client redisClient) *Repository {
+	return &Repository{client: client}
+}
+
+// Use remembers the signature of a service request for ttl. It returns false if
+// the same signature was already used, which means the request is replayed.
+func (r *Repository) Use(ctx context.Context, clientName, signature string, ttl time.Duration) (bool, error) {
+	key := fmt.Sprintf("%s:%s:%s", keyPrefix, clientName, signature)
+
+	return r.client.SetNX(ctx, key, 1, ttl).Result()
+}
@@ -0,0 +1,141 @@
+package session_family_repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"github.com/ruslanonly/blindtyping/src/internal/models"
+)
+
+type database interface {
+	Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
+type Repository struct {
+	db database
+}
+
+func New(db database) *Repository {
+	return &Repository{db: db}
+}
+
+// Get returns the family member for refreshToken or nil if the token is not tracked.
+func (r *Repository) Get(ctx context.Context, refreshToken string) (*models.SessionFamilyMember, error) {
+	query := `
+		SELECT refresh_token, family_id, user_id, rotated_at, expires_at
+		FROM session_families
+		WHERE refresh_token = $1`
+
+	var member models.SessionFamilyMember
+	err := r.db.QueryRow(ctx, query, refreshToken).Scan(
+		&member.RefreshToken,
+		&member.FamilyID,
+		&member.UserID,
+		&member.RotatedAt,
+		&member.ExpiresAt,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &member, nil
+}
+
+// Start adds the token of the session owning refreshToken to the family,
+// a new family for a login or the family of the claimed token for a refresh.
+func (r *Repository) Start(ctx context.Context, refreshToken string, familyID uuid.UUID) error {
+	query := `
+		INSERT INTO session_families (refresh_token, family_id, user_id, expires_at)
+		SELECT refresh_token, $2, user_id, expires_at
+		FROM sessions
+		WHERE refresh_token = $1
+		ON CONFLICT (refresh_token) DO NOTHING`
+
+	_, err := r.db.Exec(ctx, query, refreshToken, familyID)
+	return err
+}
+
+// Claim marks the token as rotated if it was not rotated yet and returns it.
+// It returns nil if the token was already rotated or has no session. The
+// check and the update are one statement, so of concurrent claims of the same
+// token exactly one succeeds. Tokens issued before families were tracked join
+// the family familyID.
+func (r *Repository) Claim(
+	ctx context.Context,
+	refreshToken string,
+	familyID uuid.UUID,
+	rotatedAt time.Time,
+) (*models.SessionFamilyMember, error) {
+	query := `
+		INSERT INTO session_families (refresh_token, family_id, user_id, expires_at, rotated_at)
+		SELECT refresh_token, $2, user_id, expires_at, $3
+		FROM sessions
+		WHERE refresh_token = $1
+		ON CONFLICT (refresh_token) DO UPDATE
+		SET rotated_at = EXCLUDED.rotated_at
+		WHERE session_families.rotated_at IS NULL
+		RETURNING refresh_token, family_id, user_id, rotated_at, expires_at`
+
+	var member models.SessionFamilyMember
+	err := r.db.QueryRow(ctx, query, refreshToken, familyID, rotatedAt).Scan(
+		&member.RefreshToken,
+		&member.FamilyID,
+		&member.UserID,
+		&member.RotatedAt,
+		&member.ExpiresAt,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &member, nil
+}
+
+// Release undoes a claim made at rotatedAt, when the token was not exchanged
+// after all.
+func (r *Repository) Release(ctx context.Context, refreshToken string, rotatedAt time.Time) error {
+	query := `
+		UPDATE session_families
+		SET rotated_at = NULL
+		WHERE refresh_token = $1 AND rotated_at = $2`
+
+	_, err := r.db.Exec(ctx, query, refreshToken, rotatedAt)
+	return err
+}
+
+// Revoke deletes every session of the family. Family tokens stay in the table
+// marked as rotated, so presenting any of them again is treated as reuse too.
+func (r *Repository) Revoke(ctx context.Context, familyID uuid.UUID, revokedAt time.Time) error {
+	query := `
+		WITH revoked AS (
+			UPDATE session_families
+			SET rotated_at = COALESCE(rotated_at, $2)
+			WHERE family_id = $1
+			RETURNING refresh_token
+		)
+		DELETE FROM sessions
+		WHERE refresh_token IN (SELECT refresh_token FROM revoked)`
+
+	_, err := r.db.Exec(ctx, query, familyID, revokedAt)
+	return err
+}
+
+// DeleteExpired removes tokens that are past their expiration.
+func (r *Repository) DeleteExpired(ctx context.Context, now time.Time) error {
+	query := `DELETE FROM session_families WHERE expires_at < $1`
+
+	_, err := r.db.Exec(ctx, query, now)
+	return err
+}
@@ -0,0 +1,79 @@
+package statistics_deletion_repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"github.com/ruslanonly/blindtyping/src/internal/models"
+)
+
+type database interface {
+	Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error)
+}
+
+// Repository soft deletes and restores results. Deleted results stay in the
+// statistics table with is_deleted set; results removed by a wipe are marked
+// with is_wiped, so the wipe can be undone as a whole.
+type Repository struct {
+	db database
+}
+
+func New(db database) *Repository {
+	return &Repository{db: db}
+}
+
+// Wipe soft deletes every result of the user and stamps them with the wipe
+// time in one statement, so a wipe is either complete or not applied at all.
+func (r *Repository) Wipe(ctx context.Context, userID models.ID, wipedAt time.Time) error {
+	sql := `
+		UPDATE statistics
+		SET is_deleted = TRUE, deleted_at = $2, is_wiped = TRUE
+		WHERE user_id = $1 AND NOT is_deleted`
+
+	_, err := r.db.Exec(ctx, sql, userID, wipedAt)
+	return err
+}
+
+// Delete soft deletes a single result. It reports false if the user has no such
+// result or it is already deleted.
+func (r *Repository) Delete(ctx context.Context, userID, id models.ID, deletedAt time.Time) (bool, error) {
+	sql := `
+		UPDATE statistics
+		SET is_deleted = TRUE, deleted_at = $3, is_wiped = FALSE
+		WHERE id = $2 AND user_id = $1 AND NOT is_deleted`
+
+	tag, err := r.db.Exec(ctx, sql, userID, id, deletedAt)
+	if err != nil {
+		return false, err
+	}
+
+	return tag.RowsAffected() > 0, nil
+}
+
+// RestoreLastWipe brings back the results of the user's latest wipe if it
+// happened after since. It returns how many results were restored.
+func (r *Repository) RestoreLastWipe(ctx context.Context, userID models.ID, since time.Time) (int64, error) {
+	sql := `
+		WITH last_wipe AS (
+			SELECT MAX(deleted_at) AS wiped_at
+			FROM statistics
+			WHERE user_id = $1 AND is_deleted AND is_wiped
+		)
+		UPDATE statistics
+		SET is_deleted = FALSE, deleted_at = NULL, is_wiped = FALSE
+		FROM last_wipe
+		WHERE statistics.user_id = $1
+			AND statistics.is_deleted
+			AND statistics.is_wiped
+			AND statistics.deleted_at = last_wipe.wiped_at
+			AND last_wipe.wiped_at >= $2`
+
+	tag, err := r.db.Exec(ctx, sql, userID, since)
+	if err != nil {
+		return 0, err
+	}
+
+	return tag.RowsAffected(), nil
+}
@@ -0,0 +1,206 @@
+package statistics_review_repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"github.com/ruslanonly/blindtyping/src/internal/models"
+)
+
+const reviewColumns = `id, user_id, uid, payload, score, reasons, status, created_at, reviewed_by, reviewed_at`
+
+type database interface {
+	Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
+type Repository struct {
+	db database
+}
+
+func New(db database) *Repository {
+	return &Repository{db: db}
+}
+
+func scanReview(row pgx.Row) (*models.StatisticsReview, error) {
+	var (
+		review  models.StatisticsReview
+		reasons []string
+	)
+
+	err := row.Scan(
+		&review.ID,
+		&review.UserID,
+		&review.UID,
+		&review.Payload,
+		&review.Score,
+		&reasons,
+		&review.Status,
+		&review.CreatedAt,
+		&review.ReviewedBy,
+		&review.ReviewedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	review.Reasons = make([]models.AnomalyReason, 0, len(reasons))
+	for _, reason := range reasons {
+		review.Reasons = append(review.Reasons, models.AnomalyReason(reason))
+	}
+
+	return &review, nil
+}
+
+// Create queues the review. It returns nil if the user already has a review for
+// the same result UID.
+func (r *Repository) Create(ctx context.Context, review *models.StatisticsReview) (*models.StatisticsReview, error) {
+	reasons := make([]string, 0, len(review.Reasons))
+	for _, reason := range review.Reasons {
+		reasons = append(reasons, string(reason))
+	}
+
+	sql := `
+		INSERT INTO statistics_reviews (user_id, uid, payload, score, reasons, status, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (user_id, uid) DO NOTHING
+		RETURNING ` + reviewColumns
+
+	row := r.db.QueryRow(
+		ctx,
+		sql,
+		review.UserID,
+		review.UID,
+		review.Payload,
+		review.Score,
+		reasons,
+		review.Status,
+		review.CreatedAt,
+	)
+
+	created, err := scanReview(row)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+
+	return created, err
+}
+
+// Get returns the review or nil if there is no such review.
+func (r *Repository) Get(ctx context.Context, id models.ID) (*models.StatisticsReview, error) {
+	sql := `SELECT ` + reviewColumns + ` FROM statistics_reviews WHERE id = $1`
+
+	review, err := scanReview(r.db.QueryRow(ctx, sql, id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+
+	return review, err
+}
+
+// GetByStatus returns the oldest reviews with the status first.
+func (r *Repository) GetByStatus(ctx context.Context, status models.ReviewStatus, limit int) ([]*models.StatisticsReview, error) {
+	sql := `
+		SELECT ` + reviewColumns + `
+		FROM statistics_reviews
+		WHERE status = $1
+		ORDER BY created_at
+		LIMIT $2`
+
+	rows, err := r.db.Query(ctx, sql, status, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	reviews := make([]*models.StatisticsReview, 0)
+	for rows.Next() {
+		review, err := scanReview(rows)
+		if err != nil {
+			return nil, err
+		}
+		reviews = append(reviews, review)
+	}
+
+	return reviews, rows.Err()
+}
+
+// Approve moves a pending review to approved and stores its result in the same
+// statement, unless the user already has a result with the same UID. It reports
+// false if the review is no longer pending.
+func (r *Repository) Approve(
+	ctx context.Context,
+	id models.ID,
+	reviewerID models.ID,
+	reviewedAt time.Time,
+	result *models.HeldResult,
+) (bool, error) {
+	sql := `
+		WITH approved AS (
+			UPDATE statistics_reviews
+			SET status = '` + string(models.ReviewApproved) + `', reviewed_by = $2, reviewed_at = $3
+			WHERE id = $1 AND status = '` + string(models.ReviewPending) + `'
+			RETURNING user_id
+		), inserted AS (
+			INSERT INTO statistics (
+				user_id, wpm, cpm, accuracy, duration, played_at, language, mode, sub_mode,
+				is_punctuation, uncompleted_tests_count, uncompleted_tests_total_duration, idempotency_key
+			)
+			SELECT user_id, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15
+			FROM approved
+			WHERE NOT EXISTS (
+				SELECT 1 FROM statistics WHERE user_id = approved.user_id AND idempotency_key = $15
+			)
+		)
+		SELECT EXISTS (SELECT 1 FROM approved)`
+
+	var approved bool
+	err := r.db.QueryRow(
+		ctx,
+		sql,
+		id,
+		reviewerID,
+		reviewedAt,
+		result.WPM,
+		result.CPM,
+		result.Accuracy,
+		result.Duration,
+		result.CreatedAt,
+		result.Language,
+		result.Mode,
+		result.SubMode,
+		result.IsPunctuation,
+		result.UncompletedTestsCount,
+		result.UncompletedTestsDuration,
+		result.UID,
+	).Scan(&approved)
+
+	return approved, err
+}
+
+// Resolve moves a pending review to the status. It reports false if the review
+// is no longer pending.
+func (r *Repository) Resolve(
+	ctx context.Context,
+	id models.ID,
+	status models.ReviewStatus,
+	reviewerID models.ID,
+	reviewedAt time.Time,
+) (bool, error) {
+	sql := `
+		UPDATE statistics_reviews
+		SET status = $2, reviewed_by = $3, reviewed_at = $4
+		WHERE id = $1 AND status = '` + string(models.ReviewPending) + `'`
+
+	tag, err := r.db.Exec(ctx, sql, id, status, reviewerID, reviewedAt)
+	if err != nil {
+		return false, err
+	}
+
+	return tag.RowsAffected() > 0, nil
+}
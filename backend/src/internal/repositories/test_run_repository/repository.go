@@ -0,0 +1,117 @@
+package test_run_repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"github.com/ruslanonly/blindtyping/src/internal/models"
+)
+
+type database interface {
+	Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
+type Repository struct {
+	db database
+}
+
+func New(db database) *Repository {
+	return &Repository{db: db}
+}
+
+// Start saves the run and removes the runs the user has not finished, which
+// are returned. The client runs one test at a time, so starting a test
+// abandons the previous one.
+func (r *Repository) Start(ctx context.Context, run *models.TestRun) ([]*models.TestRun, error) {
+	properties, err := json.Marshal(run.Properties)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+		WITH abandoned AS (
+			DELETE FROM test_runs
+			WHERE user_id = $2
+			RETURNING id, user_id, properties, started_at
+		), started AS (
+			INSERT INTO test_runs (id, user_id, properties, started_at)
+			VALUES ($1, $2, $3, $4)
+		)
+		SELECT id, user_id, properties, started_at FROM abandoned`
+
+	rows, err := r.db.Query(ctx, query, run.ID, run.UserID, properties, run.StartedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return scanRuns(rows)
+}
+
+// Finish removes the run of the user. Returns nil when there is no such run.
+func (r *Repository) Finish(ctx context.Context, userID models.ID, id string) (*models.TestRun, error) {
+	query := `
+		DELETE FROM test_runs
+		WHERE id = $1 AND user_id = $2
+		RETURNING id, user_id, properties, started_at`
+
+	run, err := scanRun(r.db.QueryRow(ctx, query, id, userID))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+
+	return run, err
+}
+
+// DeleteStartedBefore removes and returns the runs started before the time.
+func (r *Repository) DeleteStartedBefore(ctx context.Context, before time.Time) ([]*models.TestRun, error) {
+	query := `
+		DELETE FROM test_runs
+		WHERE started_at < $1
+		RETURNING id, user_id, properties, started_at`
+
+	rows, err := r.db.Query(ctx, query, before)
+	if err != nil {
+		return nil, err
+	}
+
+	return scanRuns(rows)
+}
+
+func scanRuns(rows pgx.Rows) ([]*models.TestRun, error) {
+	defer rows.Close()
+
+	runs := make([]*models.TestRun, 0)
+	for rows.Next() {
+		run, err := scanRun(rows)
+		if err != nil {
+			return nil, err
+		}
+		runs = append(runs, run)
+	}
+
+	return runs, rows.Err()
+}
+
+func scanRun(row pgx.Row) (*models.TestRun, error) {
+	var (
+		run        models.TestRun
+		properties []byte
+	)
+
+	if err := row.Scan(&run.ID, &run.UserID, &properties, &run.StartedAt); err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(properties, &run.Properties); err != nil {
+		return nil, err
+	}
+
+	return &run, nil
+}
@@ -0,0 +1,275 @@
+package user_account_repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"github.com/ruslanonly/blindtyping/src/internal/models"
+)
+
+const accountColumns = `id, email, nickname, provider, role, created_at, banned_at, deletion_scheduled_at`
+
+// nicknameDetails are the audit details that hold a nickname of the user. They
+// match the keys audit_events_reject_change lets an update remove.
+var nicknameDetails = []string{"nickname", "username", "previous_username"}
+
+type database interface {
+	Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
+type Repository struct {
+	db database
+}
+
+func New(db database) *Repository {
+	return &Repository{db: db}
+}
+
+func scanAccount(row pgx.Row) (*models.UserAccount, error) {
+	var account models.UserAccount
+
+	err := row.Scan(
+		&account.ID,
+		&account.Email,
+		&account.Nickname,
+		&account.Provider,
+		&account.Role,
+		&account.CreatedAt,
+		&account.BannedAt,
+		&account.DeletionScheduledAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &account, nil
+}
+
+// Search finds users whose nickname or email contains query.
+func (r *Repository) Search(ctx context.Context, query string, limit int) ([]*models.UserAccount, error) {
+	sql := `
+		SELECT ` + accountColumns + `
+		FROM users
+		WHERE nickname ILIKE '%' || $1 || '%' OR email ILIKE '%' || $1 || '%'
+		ORDER BY id
+		LIMIT $2`
+
+	rows, err := r.db.Query(ctx, sql, query, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	accounts := make([]*models.UserAccount, 0)
+	for rows.Next() {
+		account, err := scanAccount(rows)
+		if err != nil {
+			return nil, err
+		}
+		accounts = append(accounts, account)
+	}
+
+	return accounts, rows.Err()
+}
+
+// GetByID returns the account or nil if there is no such user.
+func (r *Repository) GetByID(ctx context.Context, id models.ID) (*models.UserAccount, error) {
+	sql := `SELECT ` + accountColumns + ` FROM users WHERE id = $1`
+
+	account, err := scanAccount(r.db.QueryRow(ctx, sql, id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+
+	return account, err
+}
+
+// GetByEmail returns the account or nil if there is no such user.
+func (r *Repository) GetByEmail(ctx context.Context, email string) (*models.UserAccount, error) {
+	sql := `SELECT ` + accountColumns + ` FROM users WHERE email = $1`
+
+	account, err := scanAccount(r.db.QueryRow(ctx, sql, email))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+
+	return account, err
+}
+
+// GetByNickname returns the account or nil if there is no such user.
+func (r *Repository) GetByNickname(ctx context.Context, nickname string) (*models.UserAccount, error) {
+	sql := `SELECT ` + accountColumns + ` FROM users WHERE nickname = $1`
+
+	account, err := scanAccount(r.db.QueryRow(ctx, sql, nickname))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+
+	return account, err
+}
+
+// GetNicknameBySkeleton returns the nickname of a user other than exceptUserID
+// whose nickname has the given skeleton, or an empty string if there is none.
+func (r *Repository) GetNicknameBySkeleton(ctx context.Context, skeleton string, exceptUserID *models.ID) (string, error) {
+	sql := `
+		SELECT nickname
+		FROM users
+		WHERE translate(lower(nickname), '` + models.UsernameConfusablesFrom + `', '` + models.UsernameConfusablesTo + `') = $1
+			AND ($2::INTEGER IS NULL OR id <> $2)
+		LIMIT 1`
+
+	var nickname string
+	err := r.db.QueryRow(ctx, sql, skeleton, exceptUserID).Scan(&nickname)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return "", nil
+	}
+
+	return nickname, err
+}
+
+// ChangeNickname renames the user and keeps the previous nickname in the
+// username history in one statement, change.Username is set to the previous
+// nickname. The user is not renamed if their nickname was changed after
+// changedBefore, then false is returned.
+func (r *Repository) ChangeNickname(ctx context.Context, change *models.UsernameChange, nickname string, changedBefore time.Time) (bool, error) {
+	sql := `
+		WITH previous AS (
+			SELECT id, nickname
+			FROM users
+			WHERE id = $1
+			FOR UPDATE
+		), renamed AS (
+			UPDATE users u
+			SET nickname = $2, username_changed_at = $3
+			FROM previous p
+			WHERE u.id = p.id
+				AND (u.username_changed_at IS NULL OR u.username_changed_at <= $5)
+			RETURNING p.id, p.nickname
+		)
+		INSERT INTO username_history (user_id, username, changed_at, reserved_until)
+		SELECT id, nickname, $3, $4
+		FROM renamed
+		RETURNING id, username`
+
+	err := r.db.QueryRow(ctx, sql, change.UserID, nickname, change.ChangedAt, change.ReservedUntil, changedBefore).
+		Scan(&change.ID, &change.Username)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (r *Repository) SetRole(ctx context.Context, id models.ID, role models.Role) error {
+	sql := `UPDATE users SET role = $2 WHERE id = $1`
+
+	_, err := r.db.Exec(ctx, sql, id, role)
+	return err
+}
+
+// Ban marks the user banned and deletes all of their sessions and personal
+// tokens.
+func (r *Repository) Ban(ctx context.Context, id models.ID, bannedAt time.Time) error {
+	sql := `
+		WITH banned AS (
+			UPDATE users
+			SET banned_at = $2
+			WHERE id = $1
+		), revoked_tokens AS (
+			DELETE FROM personal_tokens
+			WHERE user_id = $1
+		)
+		DELETE FROM sessions
+		WHERE user_id = $1`
+
+	_, err := r.db.Exec(ctx, sql, id, bannedAt)
+	return err
+}
+
+func (r *Repository) Unban(ctx context.Context, id models.ID) error {
+	sql := `UPDATE users SET banned_at = NULL WHERE id = $1`
+
+	_, err := r.db.Exec(ctx, sql, id)
+	return err
+}
+
+// ScheduleDeletion marks the user to be deleted at deleteAt and deletes all of
+// their sessions and personal tokens.
+func (r *Repository) ScheduleDeletion(ctx context.Context, id models.ID, deleteAt time.Time) error {
+	sql := `
+		WITH scheduled AS (
+			UPDATE users
+			SET deletion_scheduled_at = $2
+			WHERE id = $1
+		), tokens_deleted AS (
+			DELETE FROM personal_tokens
+			WHERE user_id = $1
+		)
+		DELETE FROM sessions
+		WHERE user_id = $1`
+
+	_, err := r.db.Exec(ctx, sql, id, deleteAt)
+	return err
+}
+
+// DeleteScheduled deletes users whose deletion is due. Everything that
+// references a user is deleted along with it, including the avatar the
+// provider gave for the user's email. Audit events of the user are kept, but
+// their IP and user agent are cleared and the nicknames are removed from their
+// details. Analytics events lose their properties and, through the foreign key,
+// their user.
+func (r *Repository) DeleteScheduled(ctx context.Context, now time.Time) ([]*models.DeletedAccount, error) {
+	sql := `
+		WITH deleted AS (
+			DELETE FROM users
+			WHERE deletion_scheduled_at <= $1
+			RETURNING id, email, avatar_key
+		), deleted_provider_avatars AS (
+			DELETE FROM provider_avatars
+			WHERE email IN (SELECT email FROM deleted)
+		), anonymized_audit_events AS (
+			UPDATE audit_events
+			SET ip = '', user_agent = '', details = CASE
+				WHEN user_id IN (SELECT id FROM deleted) THEN details - $2::text[]
+				ELSE details
+			END
+			WHERE (user_id IN (SELECT id FROM deleted) OR actor_id IN (SELECT id FROM deleted))
+				AND (
+					ip <> '' OR user_agent <> ''
+					OR (user_id IN (SELECT id FROM deleted) AND details ?| $2::text[])
+				)
+		), anonymized_analytics_events AS (
+			UPDATE analytics_events
+			SET properties = '{}'
+			WHERE user_id IN (SELECT id FROM deleted)
+				AND properties <> '{}'
+		)
+		SELECT id, COALESCE(avatar_key, '')
+		FROM deleted`
+
+	rows, err := r.db.Query(ctx, sql, now, nicknameDetails)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	accounts := make([]*models.DeletedAccount, 0)
+	for rows.Next() {
+		var account models.DeletedAccount
+		if err = rows.Scan(&account.ID, &account.AvatarKey); err != nil {
+			return nil, err
+		}
+		accounts = append(accounts, &account)
+	}
+
+	return accounts, rows.Err()
+}
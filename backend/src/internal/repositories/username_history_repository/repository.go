@@ -0,0 +1,66 @@
+package username_history_repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"github.com/ruslanonly/blindtyping/src/internal/models"
+)
+
+type database interface {
+	Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
+type Repository struct {
+	db database
+}
+
+func New(db database) *Repository {
+	return &Repository{db: db}
+}
+
+// IsReserved reports whether a username with the given skeleton is reserved at
+// the moment by a user other than exceptUserID. A nil exceptUserID checks
+// against every user.
+func (r *Repository) IsReserved(ctx context.Context, skeleton string, exceptUserID *models.ID, now time.Time) (bool, error) {
+	sql := `
+		SELECT EXISTS (
+			SELECT 1
+			FROM username_history
+			WHERE translate(lower(username), '` + models.UsernameConfusablesFrom + `', '` + models.UsernameConfusablesTo + `') = $1
+				AND reserved_until > $2
+				AND ($3::INTEGER IS NULL OR user_id <> $3)
+		)`
+
+	var reserved bool
+	err := r.db.QueryRow(ctx, sql, skeleton, now, exceptUserID).Scan(&reserved)
+
+	return reserved, err
+}
+
+// GetCurrentUsername returns the current username of the last user who had the
+// given one. It returns an empty string if nobody had it or someone uses it now.
+func (r *Repository) GetCurrentUsername(ctx context.Context, previous string) (string, error) {
+	sql := `
+		SELECT u.nickname
+		FROM username_history h
+		JOIN users u ON u.id = h.user_id
+		WHERE h.username = $1
+			AND u.nickname <> $1
+			AND NOT EXISTS (SELECT 1 FROM users WHERE nickname = $1)
+		ORDER BY h.changed_at DESC
+		LIMIT 1`
+
+	var current string
+	err := r.db.QueryRow(ctx, sql, previous).Scan(&current)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return "", nil
+	}
+
+	return current, err
+}
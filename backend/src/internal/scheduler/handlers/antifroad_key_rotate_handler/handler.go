@@ -0,0 +1,45 @@
+package antifroad_key_rotate_handler
+
+import (
+	"context"
+	"time"
+
+	"github.com/ruslanonly/blindtyping/src/internal"
+	"github.com/ruslanonly/blindtyping/src/internal/models"
+)
+
+const handlerName = "antifroad_key_rotate_handler"
+
+type antifroadKeyService interface {
+	Rotate(ctx context.Context, trigger models.AntifroadRotationTrigger) error
+}
+
+type jobMetrics interface {
+	ObserveJob(job string, startedAt time.Time, err error)
+}
+
+type Handler struct {
+	antifroadKeyService antifroadKeyService
+	jobMetrics          jobMetrics
+	logger              internal.Logger
+}
+
+func (h *Handler) Run() {
+	ctx := h.logger.WithHandlerName(context.Background(), handlerName)
+
+	startedAt := time.Now()
+	err := h.antifroadKeyService.Rotate(ctx, models.AntifroadRotationScheduler)
+	h.jobMetrics.ObserveJob(handlerName, startedAt, err)
+	if err != nil {
+		h.logger.Error(h.logger.WithError(ctx, err))
+		return
+	}
+}
+
+func New(antifroadKeyService antifroadKeyService, jobMetrics jobMetrics, logger internal.Logger) *Handler {
+	return &Handler{
+		antifroadKeyService: antifroadKeyService,
+		jobMetrics:          jobMetrics,
+		logger:              logger,
+	}
+}
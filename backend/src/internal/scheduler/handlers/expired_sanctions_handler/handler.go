@@ -0,0 +1,46 @@
+package expired_sanctions_handler
+
+import (
+	"context"
+	"time"
+
+	"github.com/ruslanonly/blindtyping/src/internal"
+)
+
+const handlerName = "expired_sanctions_handler"
+
+type adminService interface {
+	LiftExpiredSanctions(ctx context.Context) (int64, error)
+}
+
+type jobMetrics interface {
+	ObserveJob(job string, startedAt time.Time, err error)
+}
+
+type Handler struct {
+	adminService adminService
+	jobMetrics   jobMetrics
+	logger       internal.Logger
+}
+
+func (h *Handler) Run() {
+	ctx := h.logger.WithHandlerName(context.Background(), handlerName)
+
+	startedAt := time.Now()
+	lifted, err := h.adminService.LiftExpiredSanctions(ctx)
+	h.jobMetrics.ObserveJob(handlerName, startedAt, err)
+	if err != nil {
+		h.logger.Error(h.logger.WithError(ctx, err))
+		return
+	}
+
+	h.logger.Info(h.logger.WithMsg(h.logger.WithField(ctx, "lifted", lifted), "expired sanctions lifted"))
+}
+
+func New(adminService adminService, jobMetrics jobMetrics, logger internal.Logger) *Handler {
+	return &Handler{
+		adminService: adminService,
+		jobMetrics:   jobMetrics,
+		logger:       logger,
+	}
+}
@@ -0,0 +1,46 @@
+package expired_session_families_handler
+
+import (
+	"context"
+	"time"
+
+	"github.com/ruslanonly/blindtyping/src/internal"
+)
+
+const handlerName = "expired_session_families_handler"
+
+type tokenFamilyService interface {
+	DeleteExpired(ctx context.Context) error
+}
+
+type jobMetrics interface {
+	ObserveJob(job string, startedAt time.Time, err error)
+}
+
+type Handler struct {
+	tokenFamilyService tokenFamilyService
+	jobMetrics         jobMetrics
+	logger             internal.Logger
+}
+
+func (h *Handler) Run() {
+	ctx := h.logger.WithHandlerName(context.Background(), handlerName)
+
+	startedAt := time.Now()
+	err := h.tokenFamilyService.DeleteExpired(ctx)
+	h.jobMetrics.ObserveJob(handlerName, startedAt, err)
+	if err != nil {
+		h.logger.Error(h.logger.WithError(ctx, err))
+		return
+	}
+
+	h.logger.Info(h.logger.WithMsg(ctx, "expired session families deleted"))
+}
+
+func New(tokenFamilyService tokenFamilyService, jobMetrics jobMetrics, logger internal.Logger) *Handler {
+	return &Handler{
+		tokenFamilyService: tokenFamilyService,
+		jobMetrics:         jobMetrics,
+		logger:             logger,
+	}
+}
@@ -0,0 +1,51 @@
+package scheduled_accounts_deletion_handler
+
+import (
+	"context"
+	"time"
+
+	"github.com/ruslanonly/blindtyping/src/internal"
+	"github.com/ruslanonly/blindtyping/src/internal/services/audit_service"
+)
+
+const (
+	handlerName = "scheduled_accounts_deletion_handler"
+	actorName   = "scheduler"
+)
+
+type accountService interface {
+	DeleteScheduled(ctx context.Context) (int64, error)
+}
+
+type jobMetrics interface {
+	ObserveJob(job string, startedAt time.Time, err error)
+}
+
+type Handler struct {
+	accountService accountService
+	jobMetrics     jobMetrics
+	logger         internal.Logger
+}
+
+func (h *Handler) Run() {
+	ctx := h.logger.WithHandlerName(context.Background(), handlerName)
+	ctx = audit_service.WithActorService(ctx, actorName)
+
+	startedAt := time.Now()
+	deleted, err := h.accountService.DeleteScheduled(ctx)
+	h.jobMetrics.ObserveJob(handlerName, startedAt, err)
+	if err != nil {
+		h.logger.Error(h.logger.WithError(ctx, err))
+		return
+	}
+
+	h.logger.Info(h.logger.WithMsg(h.logger.WithField(ctx, "deleted", deleted), "scheduled accounts deleted"))
+}
+
+func New(accountService accountService, jobMetrics jobMetrics, logger internal.Logger) *Handler {
+	return &Handler{
+		accountService: accountService,
+		jobMetrics:     jobMetrics,
+		logger:         logger,
+	}
+}
@@ -0,0 +1,46 @@
+package stale_tests_handler
+
+import (
+	"context"
+	"time"
+
+	"github.com/ruslanonly/blindtyping/src/internal"
+)
+
+const handlerName = "stale_tests_handler"
+
+type analyticsService interface {
+	AbandonStaleTests(ctx context.Context) (int64, error)
+}
+
+type jobMetrics interface {
+	ObserveJob(job string, startedAt time.Time, err error)
+}
+
+type Handler struct {
+	analyticsService analyticsService
+	jobMetrics       jobMetrics
+	logger           internal.Logger
+}
+
+func (h *Handler) Run() {
+	ctx := h.logger.WithHandlerName(context.Background(), handlerName)
+
+	startedAt := time.Now()
+	abandoned, err := h.analyticsService.AbandonStaleTests(ctx)
+	h.jobMetrics.ObserveJob(handlerName, startedAt, err)
+	if err != nil {
+		h.logger.Error(h.logger.WithError(ctx, err))
+		return
+	}
+
+	h.logger.Info(h.logger.WithMsg(h.logger.WithField(ctx, "abandoned", abandoned), "stale tests abandoned"))
+}
+
+func New(analyticsService analyticsService, jobMetrics jobMetrics, logger internal.Logger) *Handler {
+	return &Handler{
+		analyticsService: analyticsService,
+		jobMetrics:       jobMetrics,
+		logger:           logger,
+	}
+}
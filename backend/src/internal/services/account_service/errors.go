@@ -0,0 +1,16 @@
+package account_service
+
+import "errors"
+
+var (
+	ErrUserNotFound             = errors.New("user not found")
+	ErrDeletionAlreadyScheduled = errors.New("account deletion is already scheduled")
+)
+
+func IsUserNotFoundError(err error) bool {
+	return errors.Is(err, ErrUserNotFound)
+}
+
+func IsDeletionAlreadyScheduledError(err error) bool {
+	return errors.Is(err, ErrDeletionAlreadyScheduled)
+}
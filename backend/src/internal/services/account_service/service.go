@@ -0,0 +1,195 @@
+package account_service
+
+import (
+	"context"
+	"time"
+
+	"github.com/ruslanonly/blindtyping/src/internal"
+	"github.com/ruslanonly/blindtyping/src/internal/models"
+	"github.com/ruslanonly/blindtyping/src/internal/shared/tracing"
+)
+
+const reasonAccountDeletion = "account_deletion"
+
+type accountRepository interface {
+	GetByID(ctx context.Context, id models.ID) (*models.UserAccount, error)
+	GetByEmail(ctx context.Context, email string) (*models.UserAccount, error)
+	ScheduleDeletion(ctx context.Context, id models.ID, deleteAt time.Time) error
+	DeleteScheduled(ctx context.Context, now time.Time) ([]*models.DeletedAccount, error)
+}
+
+type profileDetailsService interface {
+	Get(ctx context.Context, userID models.ID) (*models.ProfileDetails, error)
+	DeleteAvatar(ctx context.Context, key string)
+}
+
+type exportRepository interface {
+	GetSessions(ctx context.Context, userID models.ID) ([]*models.AccountSession, error)
+	GetStatistics(ctx context.Context, userID models.ID) ([]*models.ExportedResult, error)
+}
+
+type personalTokenRepository interface {
+	GetByUserID(ctx context.Context, userID models.ID) ([]*models.PersonalToken, error)
+}
+
+type revocationRepository interface {
+	RevokeBefore(ctx context.Context, userID models.ID, revokedAt time.Time) error
+}
+
+type auditRecorder interface {
+	Record(ctx context.Context, event *models.AuditEvent)
+	RecordUserAction(ctx context.Context, action models.AuditAction, userID models.ID, details map[string]any)
+}
+
+// Service handles the user's own account as a whole: exporting everything
+// stored about it and deleting it. Deletion is not immediate: the user is
+// logged out everywhere right away and the account with everything that
+// references it is deleted once the grace period is over.
+type Service struct {
+	accountRepository       accountRepository
+	exportRepository        exportRepository
+	personalTokenRepository personalTokenRepository
+	revocationRepository    revocationRepository
+	profileDetailsService   profileDetailsService
+	auditRecorder           auditRecorder
+	logger                  internal.Logger
+	gracePeriod             time.Duration
+}
+
+func New(
+	accountRepository accountRepository,
+	exportRepository exportRepository,
+	personalTokenRepository personalTokenRepository,
+	revocationRepository revocationRepository,
+	profileDetailsService profileDetailsService,
+	auditRecorder auditRecorder,
+	logger internal.Logger,
+	gracePeriod time.Duration,
+) *Service {
+	return &Service{
+		accountRepository:       accountRepository,
+		exportRepository:        exportRepository,
+		personalTokenRepository: personalTokenRepository,
+		revocationRepository:    revocationRepository,
+		profileDetailsService:   profileDetailsService,
+		auditRecorder:           auditRecorder,
+		logger:                  logger,
+		gracePeriod:             gracePeriod,
+	}
+}
+
+func (s *Service) Export(ctx context.Context, userID models.ID) (_ *models.AccountExport, err error) {
+	ctx, span := tracing.Start(ctx, "account_service.Export")
+	defer func() { tracing.End(span, err) }()
+
+	account, err := s.accountRepository.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if account == nil {
+		return nil, ErrUserNotFound
+	}
+
+	details, err := s.profileDetailsService.Get(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	sessions, err := s.exportRepository.GetSessions(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	tokens, err := s.personalTokenRepository.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	statistics, err := s.exportRepository.GetStatistics(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.AccountExport{
+		Account:        account,
+		Details:        details,
+		Sessions:       sessions,
+		PersonalTokens: tokens,
+		Statistics:     statistics,
+		ExportedAt:     time.Now(),
+	}, nil
+}
+
+// ScheduleDeletion logs the user out everywhere and schedules the account to be
+// deleted after the grace period. It returns when the account will be deleted.
+func (s *Service) ScheduleDeletion(ctx context.Context, userID models.ID) (_ time.Time, err error) {
+	ctx, span := tracing.Start(ctx, "account_service.ScheduleDeletion")
+	defer func() { tracing.End(span, err) }()
+
+	account, err := s.accountRepository.GetByID(ctx, userID)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if account == nil {
+		return time.Time{}, ErrUserNotFound
+	}
+	if account.IsDeletionScheduled() {
+		return time.Time{}, ErrDeletionAlreadyScheduled
+	}
+
+	now := time.Now()
+	deleteAt := now.Add(s.gracePeriod)
+
+	if err = s.accountRepository.ScheduleDeletion(ctx, userID, deleteAt); err != nil {
+		return time.Time{}, err
+	}
+
+	if err = s.revocationRepository.RevokeBefore(ctx, userID, now); err != nil {
+		return time.Time{}, err
+	}
+
+	s.auditRecorder.RecordUserAction(ctx, models.AuditDeletionScheduled, userID, map[string]any{
+		"delete_at": deleteAt,
+	})
+	s.auditRecorder.RecordUserAction(ctx, models.AuditSessionRevoked, userID, map[string]any{
+		"reason": reasonAccountDeletion,
+	})
+
+	return deleteAt, nil
+}
+
+// IsDeletionScheduledEmail reports whether the account with the email waits for
+// deletion. Such an account can not be logged into during the grace period.
+func (s *Service) IsDeletionScheduledEmail(ctx context.Context, email string) (bool, error) {
+	account, err := s.accountRepository.GetByEmail(ctx, email)
+	if err != nil {
+		return false, err
+	}
+
+	return account != nil && account.IsDeletionScheduled(), nil
+}
+
+// DeleteScheduled deletes accounts whose grace period is over and returns how
+// many were deleted.
+func (s *Service) DeleteScheduled(ctx context.Context) (_ int64, err error) {
+	ctx, span := tracing.Start(ctx, "account_service.DeleteScheduled")
+	defer func() { tracing.End(span, err) }()
+
+	accounts, err := s.accountRepository.DeleteScheduled(ctx, time.Now())
+	if err != nil {
+		return 0, err
+	}
+
+	for _, account := range accounts {
+		if account.AvatarKey != "" {
+			s.profileDetailsService.DeleteAvatar(ctx, account.AvatarKey)
+		}
+
+		s.auditRecorder.Record(ctx, &models.AuditEvent{
+			Action: models.AuditAccountDeleted,
+			UserID: &account.ID,
+		})
+	}
+
+	return int64(len(accounts)), nil
+}
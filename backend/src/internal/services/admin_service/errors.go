@@ -0,0 +1,67 @@
+package admin_service
+
+import "errors"
+
+var (
+	ErrUserNotFound      = errors.New("user not found")
+	ErrInvalidRole       = errors.New("invalid role")
+	ErrActionOnSelf      = errors.New("action can not be applied to yourself")
+	ErrInsufficientRole  = errors.New("insufficient role for this action")
+	ErrUserAlreadyBanned = errors.New("user already banned")
+	ErrUserNotBanned     = errors.New("user is not banned")
+
+	ErrSanctionNotFound      = errors.New("sanction not found")
+	ErrInvalidSanctionKind   = errors.New("invalid sanction kind")
+	ErrInvalidSanctionReason = errors.New("sanction reason must not be empty")
+	ErrInvalidSanctionExpiry = errors.New("sanction expiry must be in the future")
+	ErrUserAlreadySanctioned = errors.New("user already has an active sanction of this kind")
+	ErrSanctionAlreadyLifted = errors.New("sanction is already lifted or expired")
+)
+
+func IsUserNotFoundError(err error) bool {
+	return errors.Is(err, ErrUserNotFound)
+}
+
+func IsInvalidRoleError(err error) bool {
+	return errors.Is(err, ErrInvalidRole)
+}
+
+func IsActionOnSelfError(err error) bool {
+	return errors.Is(err, ErrActionOnSelf)
+}
+
+func IsInsufficientRoleError(err error) bool {
+	return errors.Is(err, ErrInsufficientRole)
+}
+
+func IsUserAlreadyBannedError(err error) bool {
+	return errors.Is(err, ErrUserAlreadyBanned)
+}
+
+func IsUserNotBannedError(err error) bool {
+	return errors.Is(err, ErrUserNotBanned)
+}
+
+func IsSanctionNotFoundError(err error) bool {
+	return errors.Is(err, ErrSanctionNotFound)
+}
+
+func IsInvalidSanctionKindError(err error) bool {
+	return errors.Is(err, ErrInvalidSanctionKind)
+}
+
+func IsInvalidSanctionReasonError(err error) bool {
+	return errors.Is(err, ErrInvalidSanctionReason)
+}
+
+func IsInvalidSanctionExpiryError(err error) bool {
+	return errors.Is(err, ErrInvalidSanctionExpiry)
+}
+
+func IsUserAlreadySanctionedError(err error) bool {
+	return errors.Is(err, ErrUserAlreadySanctioned)
+}
+
+func IsSanctionAlreadyLiftedError(err error) bool {
+	return errors.Is(err, ErrSanctionAlreadyLifted)
+}
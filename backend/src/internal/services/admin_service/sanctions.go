@@ -0,0 +1,164 @@
+package admin_service
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/ruslanonly/blindtyping/src/internal/models"
+	"github.com/ruslanonly/blindtyping/src/internal/shared/tracing"
+)
+
+const maxSanctionReasonLength = 512
+
+type SanctionIn struct {
+	ActionIn
+	Kind      models.SanctionKind
+	Reason    string
+	ExpiresAt *time.Time
+}
+
+func (in *SanctionIn) validate(now time.Time) error {
+	if !in.Kind.IsValid() {
+		return ErrInvalidSanctionKind
+	}
+
+	reason := strings.TrimSpace(in.Reason)
+	if reason == "" || len(reason) > maxSanctionReasonLength {
+		return ErrInvalidSanctionReason
+	}
+
+	if in.ExpiresAt != nil && !in.ExpiresAt.After(now) {
+		return ErrInvalidSanctionExpiry
+	}
+
+	return nil
+}
+
+// Sanction suspends or shadow-bans the user. A suspension also revokes all
+// sessions and access tokens of the user.
+func (s *Service) Sanction(ctx context.Context, in *SanctionIn) (_ *models.Sanction, err error) {
+	ctx, span := tracing.Start(ctx, "admin_service.Sanction")
+	defer func() { tracing.End(span, err) }()
+
+	now := time.Now()
+
+	if err := in.validate(now); err != nil {
+		return nil, err
+	}
+
+	_, target, err := s.accounts(ctx, &in.ActionIn)
+	if err != nil {
+		return nil, err
+	}
+
+	active, err := s.sanctionRepository.HasActive(ctx, target.ID, in.Kind, now)
+	if err != nil {
+		return nil, err
+	}
+	if active {
+		return nil, ErrUserAlreadySanctioned
+	}
+
+	actorID := in.ActorID
+	sanction, err := s.sanctionRepository.Create(ctx, &models.Sanction{
+		UserID:    target.ID,
+		Kind:      in.Kind,
+		Reason:    strings.TrimSpace(in.Reason),
+		ActorID:   &actorID,
+		CreatedAt: now,
+		ExpiresAt: in.ExpiresAt,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if in.Kind == models.SanctionSuspension {
+		if err = s.revocationRepository.RevokeBefore(ctx, target.ID, now); err != nil {
+			return nil, err
+		}
+
+		s.recordRevocation(ctx, &in.ActionIn, map[string]any{"reason": reasonSuspension, "sanction_id": sanction.ID})
+	}
+
+	s.logAction(s.logger.WithField(ctx, "sanction_id", sanction.ID), &in.ActionIn, string(in.Kind))
+
+	return sanction, nil
+}
+
+// Sanctions returns the whole sanction history of the user, newest first.
+func (s *Service) Sanctions(ctx context.Context, userID models.ID) ([]*models.Sanction, error) {
+	if _, err := s.GetUser(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	return s.sanctionRepository.GetByUserID(ctx, userID)
+}
+
+type LiftSanctionIn struct {
+	ActionIn
+	SanctionID models.ID
+}
+
+func (s *Service) LiftSanction(ctx context.Context, in *LiftSanctionIn) (err error) {
+	ctx, span := tracing.Start(ctx, "admin_service.LiftSanction")
+	defer func() { tracing.End(span, err) }()
+
+	_, target, err := s.accounts(ctx, &in.ActionIn)
+	if err != nil {
+		return err
+	}
+
+	sanction, err := s.sanctionRepository.Get(ctx, in.SanctionID)
+	if err != nil {
+		return err
+	}
+	if sanction == nil || sanction.UserID != target.ID {
+		return ErrSanctionNotFound
+	}
+
+	now := time.Now()
+	if !sanction.IsActive(now) {
+		return ErrSanctionAlreadyLifted
+	}
+
+	if err = s.sanctionRepository.Lift(ctx, sanction.ID, in.ActorID, now); err != nil {
+		return err
+	}
+
+	s.logAction(s.logger.WithField(ctx, "sanction_id", sanction.ID), &in.ActionIn, "lift_"+string(sanction.Kind))
+
+	return nil
+}
+
+// LiftExpiredSanctions lifts every sanction whose expiry has passed and returns
+// how many were lifted.
+func (s *Service) LiftExpiredSanctions(ctx context.Context) (int64, error) {
+	return s.sanctionRepository.LiftExpired(ctx, time.Now())
+}
+
+// IsSuspendedEmail reports whether the account registered with the email has an
+// active suspension.
+func (s *Service) IsSuspendedEmail(ctx context.Context, email string) (bool, error) {
+	account, err := s.accountRepository.GetByEmail(ctx, email)
+	if err != nil || account == nil {
+		return false, err
+	}
+
+	return s.sanctionRepository.HasActive(ctx, account.ID, models.SanctionSuspension, time.Now())
+}
+
+// IsShadowBannedFor reports whether the user with the nickname is hidden from
+// the viewer by an active shadow ban. The user always sees their own profile,
+// viewerID is nil for anonymous viewers.
+func (s *Service) IsShadowBannedFor(ctx context.Context, nickname string, viewerID *models.ID) (bool, error) {
+	account, err := s.accountRepository.GetByNickname(ctx, nickname)
+	if err != nil || account == nil {
+		return false, err
+	}
+	if viewerID != nil && *viewerID == account.ID {
+		return false, nil
+	}
+
+	return s.sanctionRepository.HasActive(ctx, account.ID, models.SanctionShadowBan, time.Now())
+}
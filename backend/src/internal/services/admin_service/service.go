@@ -0,0 +1,260 @@
+package admin_service
+
+import (
+	"context"
+	"time"
+
+	"github.com/ruslanonly/blindtyping/src/internal"
+	"github.com/ruslanonly/blindtyping/src/internal/models"
+	"github.com/ruslanonly/blindtyping/src/internal/shared/tracing"
+)
+
+const (
+	defaultSearchLimit = 20
+	maxSearchLimit     = 100
+)
+
+// Reasons of the session revocations recorded in the audit trail
+const (
+	reasonBan        = "ban"
+	reasonSuspension = "suspension"
+	reasonRoleChange = "role_change"
+)
+
+type accountRepository interface {
+	Search(ctx context.Context, query string, limit int) ([]*models.UserAccount, error)
+	GetByID(ctx context.Context, id models.ID) (*models.UserAccount, error)
+	GetByEmail(ctx context.Context, email string) (*models.UserAccount, error)
+	GetByNickname(ctx context.Context, nickname string) (*models.UserAccount, error)
+	SetRole(ctx context.Context, id models.ID, role models.Role) error
+	Ban(ctx context.Context, id models.ID, bannedAt time.Time) error
+	Unban(ctx context.Context, id models.ID) error
+}
+
+type revocationRepository interface {
+	RevokeBefore(ctx context.Context, userID models.ID, revokedAt time.Time) error
+}
+
+type sanctionRepository interface {
+	Create(ctx context.Context, sanction *models.Sanction) (*models.Sanction, error)
+	Get(ctx context.Context, id models.ID) (*models.Sanction, error)
+	GetByUserID(ctx context.Context, userID models.ID) ([]*models.Sanction, error)
+	HasActive(ctx context.Context, userID models.ID, kind models.SanctionKind, now time.Time) (bool, error)
+	Lift(ctx context.Context, id models.ID, actorID models.ID, liftedAt time.Time) error
+	LiftExpired(ctx context.Context, now time.Time) (int64, error)
+}
+
+type auditRecorder interface {
+	Record(ctx context.Context, event *models.AuditEvent)
+}
+
+type Service struct {
+	accountRepository    accountRepository
+	revocationRepository revocationRepository
+	sanctionRepository   sanctionRepository
+	auditRecorder        auditRecorder
+	logger               internal.Logger
+}
+
+func New(
+	accountRepository accountRepository,
+	revocationRepository revocationRepository,
+	sanctionRepository sanctionRepository,
+	auditRecorder auditRecorder,
+	logger internal.Logger,
+) *Service {
+	return &Service{
+		accountRepository:    accountRepository,
+		revocationRepository: revocationRepository,
+		sanctionRepository:   sanctionRepository,
+		auditRecorder:        auditRecorder,
+		logger:               logger,
+	}
+}
+
+type SearchIn struct {
+	Query string
+	Limit int
+}
+
+func (s *Service) Search(ctx context.Context, in *SearchIn) ([]*models.UserAccount, error) {
+	limit := in.Limit
+	if limit <= 0 {
+		limit = defaultSearchLimit
+	}
+	if limit > maxSearchLimit {
+		limit = maxSearchLimit
+	}
+
+	return s.accountRepository.Search(ctx, in.Query, limit)
+}
+
+func (s *Service) GetUser(ctx context.Context, id models.ID) (*models.UserAccount, error) {
+	account, err := s.accountRepository.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if account == nil {
+		return nil, ErrUserNotFound
+	}
+
+	return account, nil
+}
+
+// GetRole returns the stored role of the user.
+func (s *Service) GetRole(ctx context.Context, id models.ID) (models.Role, error) {
+	account, err := s.GetUser(ctx, id)
+	if err != nil {
+		return "", err
+	}
+
+	return account.Role, nil
+}
+
+type ActionIn struct {
+	ActorID models.ID
+	UserID  models.ID
+}
+
+// accounts loads the actor and the user they want to act upon. Nobody can act
+// upon themselves or upon a user with the same or a higher role.
+func (s *Service) accounts(ctx context.Context, in *ActionIn) (actor, target *models.UserAccount, err error) {
+	if in.ActorID == in.UserID {
+		return nil, nil, ErrActionOnSelf
+	}
+
+	actor, err = s.GetUser(ctx, in.ActorID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	target, err = s.GetUser(ctx, in.UserID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if target.Role.AtLeast(actor.Role) {
+		return nil, nil, ErrInsufficientRole
+	}
+
+	return actor, target, nil
+}
+
+func (s *Service) logAction(ctx context.Context, in *ActionIn, action string) {
+	ctx = s.logger.WithUserID(ctx, int64(in.ActorID))
+	ctx = s.logger.WithFields(ctx, map[string]any{
+		"admin_action":   action,
+		"target_user_id": in.UserID,
+	})
+	s.logger.Info(s.logger.WithMsg(ctx, "admin action applied"))
+}
+
+// recordRevocation audits the sessions of the user revoked by an admin action.
+func (s *Service) recordRevocation(ctx context.Context, in *ActionIn, details map[string]any) {
+	userID, actorID := in.UserID, in.ActorID
+	s.auditRecorder.Record(ctx, &models.AuditEvent{
+		Action:  models.AuditSessionRevoked,
+		UserID:  &userID,
+		ActorID: &actorID,
+		Details: details,
+	})
+}
+
+// Ban blocks the account and revokes all its sessions, access tokens and
+// personal tokens.
+func (s *Service) Ban(ctx context.Context, in *ActionIn) (err error) {
+	ctx, span := tracing.Start(ctx, "admin_service.Ban")
+	defer func() { tracing.End(span, err) }()
+
+	_, target, err := s.accounts(ctx, in)
+	if err != nil {
+		return err
+	}
+	if target.IsBanned() {
+		return ErrUserAlreadyBanned
+	}
+
+	now := time.Now()
+
+	if err = s.accountRepository.Ban(ctx, target.ID, now); err != nil {
+		return err
+	}
+
+	if err = s.revocationRepository.RevokeBefore(ctx, target.ID, now); err != nil {
+		return err
+	}
+
+	s.recordRevocation(ctx, in, map[string]any{"reason": reasonBan})
+	s.logAction(ctx, in, "ban")
+
+	return nil
+}
+
+func (s *Service) Unban(ctx context.Context, in *ActionIn) (err error) {
+	ctx, span := tracing.Start(ctx, "admin_service.Unban")
+	defer func() { tracing.End(span, err) }()
+
+	_, target, err := s.accounts(ctx, in)
+	if err != nil {
+		return err
+	}
+	if !target.IsBanned() {
+		return ErrUserNotBanned
+	}
+
+	if err = s.accountRepository.Unban(ctx, target.ID); err != nil {
+		return err
+	}
+
+	s.logAction(ctx, in, "unban")
+
+	return nil
+}
+
+type SetRoleIn struct {
+	ActionIn
+	Role models.Role
+}
+
+func (s *Service) SetRole(ctx context.Context, in *SetRoleIn) (err error) {
+	ctx, span := tracing.Start(ctx, "admin_service.SetRole")
+	defer func() { tracing.End(span, err) }()
+
+	if !in.Role.IsValid() {
+		return ErrInvalidRole
+	}
+
+	actor, target, err := s.accounts(ctx, &in.ActionIn)
+	if err != nil {
+		return err
+	}
+	if !actor.Role.AtLeast(in.Role) {
+		return ErrInsufficientRole
+	}
+
+	if err = s.accountRepository.SetRole(ctx, target.ID, in.Role); err != nil {
+		return err
+	}
+
+	// The new role applies at once, the sessions started under the old one
+	// are ended as well.
+	if err = s.revocationRepository.RevokeBefore(ctx, target.ID, time.Now()); err != nil {
+		return err
+	}
+
+	s.recordRevocation(ctx, &in.ActionIn, map[string]any{"reason": reasonRoleChange, "role": string(in.Role)})
+
+	s.logAction(s.logger.WithField(ctx, "role", string(in.Role)), &in.ActionIn, "set_role")
+
+	return nil
+}
+
+// IsBannedEmail reports whether the account registered with the email is banned.
+func (s *Service) IsBannedEmail(ctx context.Context, email string) (bool, error) {
+	account, err := s.accountRepository.GetByEmail(ctx, email)
+	if err != nil {
+		return false, err
+	}
+
+	return account != nil && account.IsBanned(), nil
+}
@@ -0,0 +1,21 @@
+package analytics_service
+
+import "errors"
+
+var (
+	ErrInvalidPeriod = errors.New("period start must be before its end")
+	ErrInvalidWeeks  = errors.New("weeks must be between 1 and 26")
+	ErrInvalidTest   = errors.New("mode, submode and language are required and at most 64 bytes long")
+)
+
+func IsInvalidPeriodError(err error) bool {
+	return errors.Is(err, ErrInvalidPeriod)
+}
+
+func IsInvalidWeeksError(err error) bool {
+	return errors.Is(err, ErrInvalidWeeks)
+}
+
+func IsInvalidTestError(err error) bool {
+	return errors.Is(err, ErrInvalidTest)
+}
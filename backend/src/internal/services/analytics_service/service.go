@@ -0,0 +1,233 @@
+package analytics_service
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/ruslanonly/blindtyping/src/internal"
+	"github.com/ruslanonly/blindtyping/src/internal/models"
+)
+
+const (
+	serviceName = "analytics_service"
+	maxWeeks    = 26
+)
+
+type eventRepository interface {
+	Append(ctx context.Context, events []*models.AnalyticsEvent) error
+	Funnel(ctx context.Context, steps []models.AnalyticsEventName, from, to time.Time) (map[int]int64, error)
+	Retention(
+		ctx context.Context,
+		cohortEvent, activeEvent models.AnalyticsEventName,
+		from, to time.Time,
+		weeks int,
+	) ([]*models.AnalyticsCohort, error)
+}
+
+type testRunRepository interface {
+	Start(ctx context.Context, run *models.TestRun) ([]*models.TestRun, error)
+	Finish(ctx context.Context, userID models.ID, id string) (*models.TestRun, error)
+	DeleteStartedBefore(ctx context.Context, before time.Time) ([]*models.TestRun, error)
+}
+
+type userRepository interface {
+	GetByNickname(ctx context.Context, nickname string) (*models.UserAccount, error)
+}
+
+// Service collects product events. Tracking only puts an event into an
+// in-memory buffer, which is written to the database in batches, so requests
+// never wait for analytics. When the buffer is full new events are dropped.
+type Service struct {
+	eventRepository   eventRepository
+	testRunRepository testRunRepository
+	userRepository    userRepository
+	logger            internal.Logger
+	batchSize         int
+	bufferSize        int
+	flushInterval     time.Duration
+	testTimeout       time.Duration // After it an unfinished test is abandoned
+
+	mu     sync.Mutex
+	buffer []*models.AnalyticsEvent
+	flush  chan struct{}
+	stop   chan struct{}
+	done   chan struct{}
+}
+
+func New(
+	eventRepository eventRepository,
+	testRunRepository testRunRepository,
+	userRepository userRepository,
+	logger internal.Logger,
+	batchSize int,
+	bufferSize int,
+	flushInterval time.Duration,
+	testTimeout time.Duration,
+) *Service {
+	return &Service{
+		eventRepository:   eventRepository,
+		testRunRepository: testRunRepository,
+		userRepository:    userRepository,
+		logger:            logger,
+		batchSize:         batchSize,
+		bufferSize:        bufferSize,
+		flushInterval:     flushInterval,
+		testTimeout:       testTimeout,
+		buffer:            make([]*models.AnalyticsEvent, 0, batchSize),
+		flush:             make(chan struct{}, 1),
+		stop:              make(chan struct{}),
+		done:              make(chan struct{}),
+	}
+}
+
+// Track buffers the event. Events without a time happened right now.
+func (s *Service) Track(ctx context.Context, event *models.AnalyticsEvent) {
+	if event.OccurredAt.IsZero() {
+		event.OccurredAt = time.Now()
+	}
+
+	s.mu.Lock()
+	if len(s.buffer) >= s.bufferSize {
+		s.mu.Unlock()
+		ctx = s.logger.WithField(ctx, "event", string(event.Name))
+		s.logger.Warning(s.logger.WithMsg(ctx, "analytics buffer is full, event dropped"))
+		return
+	}
+	s.buffer = append(s.buffer, event)
+	isBatchReady := len(s.buffer) >= s.batchSize
+	s.mu.Unlock()
+
+	if isBatchReady {
+		select {
+		case s.flush <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// TrackRegistration tracks a finished registration. Registration does not
+// return the new user, so it is looked up by the nickname.
+func (s *Service) TrackRegistration(ctx context.Context, nickname string) {
+	account, err := s.userRepository.GetByNickname(ctx, nickname)
+	if err != nil {
+		s.logger.Error(s.logger.WithError(ctx, err))
+		return
+	}
+	if account == nil {
+		return
+	}
+
+	s.Track(ctx, &models.AnalyticsEvent{
+		Name:   models.AnalyticsRegistrationCompleted,
+		UserID: &account.ID,
+	})
+}
+
+// Start writes buffered events every flushInterval or as soon as a batch is
+// full, until Stop is called.
+func (s *Service) Start() {
+	go func() {
+		defer close(s.done)
+
+		ticker := time.NewTicker(s.flushInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				s.flushBuffer()
+			case <-s.flush:
+				s.flushBuffer()
+			case <-s.stop:
+				s.flushBuffer()
+				return
+			}
+		}
+	}()
+}
+
+// Stop writes what is left in the buffer and waits for it.
+func (s *Service) Stop() {
+	close(s.stop)
+	<-s.done
+}
+
+func (s *Service) nextBatch() []*models.AnalyticsEvent {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	n := min(len(s.buffer), s.batchSize)
+	if n == 0 {
+		return nil
+	}
+
+	batch := make([]*models.AnalyticsEvent, n)
+	copy(batch, s.buffer)
+	s.buffer = append(s.buffer[:0], s.buffer[n:]...)
+
+	return batch
+}
+
+func (s *Service) flushBuffer() {
+	ctx := s.logger.WithHandlerName(context.Background(), serviceName)
+
+	for batch := s.nextBatch(); batch != nil; batch = s.nextBatch() {
+		// A failed batch is dropped, retrying it could grow the buffer without bound
+		if err := s.eventRepository.Append(ctx, batch); err != nil {
+			s.logger.Error(s.logger.WithError(s.logger.WithField(ctx, "dropped", len(batch)), err))
+		}
+	}
+}
+
+type ReportIn struct {
+	From  time.Time
+	To    time.Time
+	Weeks int // How many weeks after registration retention is tracked for
+}
+
+type ReportOut struct {
+	Funnel  []*models.AnalyticsFunnelStep
+	Cohorts []*models.AnalyticsCohort
+}
+
+// Report builds the funnel of the key user scenario and weekly retention of
+// users registered within the period.
+func (s *Service) Report(ctx context.Context, in *ReportIn) (*ReportOut, error) {
+	if !in.From.Before(in.To) {
+		return nil, ErrInvalidPeriod
+	}
+	if in.Weeks < 1 || in.Weeks > maxWeeks {
+		return nil, ErrInvalidWeeks
+	}
+
+	users, err := s.eventRepository.Funnel(ctx, models.AnalyticsFunnelSteps, in.From, in.To)
+	if err != nil {
+		return nil, err
+	}
+
+	funnel := make([]*models.AnalyticsFunnelStep, 0, len(models.AnalyticsFunnelSteps))
+	for i, step := range models.AnalyticsFunnelSteps {
+		funnel = append(funnel, &models.AnalyticsFunnelStep{
+			Name:  step,
+			Users: users[i+1],
+		})
+	}
+
+	cohorts, err := s.eventRepository.Retention(
+		ctx,
+		models.AnalyticsRegistrationCompleted,
+		models.AnalyticsTestCompleted,
+		in.From,
+		in.To,
+		in.Weeks,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ReportOut{
+		Funnel:  funnel,
+		Cohorts: cohorts,
+	}, nil
+}
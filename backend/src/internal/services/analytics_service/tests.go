@@ -0,0 +1,108 @@
+package analytics_service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"maps"
+	"time"
+
+	"github.com/ruslanonly/blindtyping/src/internal/models"
+)
+
+const (
+	testRunIDBytes = 16
+	maxTestField   = 64
+
+	abandonedRestarted = "restarted"
+	abandonedTimedOut  = "timed_out"
+)
+
+type StartTestIn struct {
+	UserID   models.ID
+	Mode     string
+	SubMode  string
+	Language string
+}
+
+// StartTest records that the user has started a test. A test the user has
+// not finished is abandoned right away, since the client runs one at a time.
+func (s *Service) StartTest(ctx context.Context, in *StartTestIn) (*models.TestRun, error) {
+	for _, field := range []string{in.Mode, in.SubMode, in.Language} {
+		if field == "" || len(field) > maxTestField {
+			return nil, ErrInvalidTest
+		}
+	}
+
+	id := make([]byte, testRunIDBytes)
+	if _, err := rand.Read(id); err != nil {
+		return nil, err
+	}
+
+	run := &models.TestRun{
+		ID:         hex.EncodeToString(id),
+		UserID:     in.UserID,
+		Properties: map[string]any{"mode": in.Mode, "submode": in.SubMode, "language": in.Language},
+		StartedAt:  time.Now(),
+	}
+
+	abandoned, err := s.testRunRepository.Start(ctx, run)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, previous := range abandoned {
+		s.trackAbandoned(ctx, previous, abandonedRestarted)
+	}
+
+	s.Track(ctx, &models.AnalyticsEvent{
+		Name:       models.AnalyticsTestStarted,
+		UserID:     &run.UserID,
+		Properties: run.Properties,
+		OccurredAt: run.StartedAt,
+	})
+
+	return run, nil
+}
+
+// FinishTest closes the run the result was submitted for, so it is not
+// reported as abandoned. Unknown runs are ignored: they have timed out or
+// were replaced by a newer test.
+func (s *Service) FinishTest(ctx context.Context, userID models.ID, id string) error {
+	if id == "" {
+		return nil
+	}
+
+	_, err := s.testRunRepository.Finish(ctx, userID, id)
+	return err
+}
+
+// AbandonStaleTests reports the tests not finished within the test timeout
+// as abandoned.
+func (s *Service) AbandonStaleTests(ctx context.Context) (int64, error) {
+	runs, err := s.testRunRepository.DeleteStartedBefore(ctx, time.Now().Add(-s.testTimeout))
+	if err != nil {
+		return 0, err
+	}
+
+	for _, run := range runs {
+		s.trackAbandoned(ctx, run, abandonedTimedOut)
+	}
+
+	return int64(len(runs)), nil
+}
+
+func (s *Service) trackAbandoned(ctx context.Context, run *models.TestRun, reason string) {
+	properties := maps.Clone(run.Properties)
+	if properties == nil {
+		properties = map[string]any{}
+	}
+	properties["reason"] = reason
+	properties["startedAt"] = run.StartedAt
+
+	s.Track(ctx, &models.AnalyticsEvent{
+		Name:       models.AnalyticsTestAbandoned,
+		UserID:     &run.UserID,
+		Properties: properties,
+	})
+}
@@ -0,0 +1,42 @@
+package antifroad_key_service
+
+import "errors"
+
+var (
+	ErrNoActiveKey = errors.New("no active antifroad key")
+	ErrUnknownKey  = errors.New("unknown antifroad key")
+	ErrKeyRequired = errors.New("antifroad key id is required")
+	ErrKeyExpired  = errors.New("antifroad key expired")
+
+	ErrUnknownSession   = errors.New("unknown antifroad session")
+	ErrSessionRequired  = errors.New("result must be signed with an antifroad session secret")
+	ErrInvalidSignature = errors.New("invalid result signature")
+)
+
+func IsNoActiveKeyError(err error) bool {
+	return errors.Is(err, ErrNoActiveKey)
+}
+
+func IsUnknownKeyError(err error) bool {
+	return errors.Is(err, ErrUnknownKey)
+}
+
+func IsKeyRequiredError(err error) bool {
+	return errors.Is(err, ErrKeyRequired)
+}
+
+func IsKeyExpiredError(err error) bool {
+	return errors.Is(err, ErrKeyExpired)
+}
+
+func IsUnknownSessionError(err error) bool {
+	return errors.Is(err, ErrUnknownSession)
+}
+
+func IsSessionRequiredError(err error) bool {
+	return errors.Is(err, ErrSessionRequired)
+}
+
+func IsInvalidSignatureError(err error) bool {
+	return errors.Is(err, ErrInvalidSignature)
+}
@@ -0,0 +1,265 @@
+package antifroad_key_service
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ruslanonly/blindtyping/src/internal"
+	"github.com/ruslanonly/blindtyping/src/internal/models"
+	"github.com/ruslanonly/blindtyping/src/internal/shared/metrics"
+	"github.com/ruslanonly/blindtyping/src/internal/shared/tracing"
+)
+
+const (
+	keyBytes = 32
+	kidBytes = 16
+)
+
+type keyRepository interface {
+	GetValid(ctx context.Context, at time.Time) ([]*models.AntifroadKeyVersion, error)
+	Rotate(
+		ctx context.Context,
+		key *models.AntifroadKeyVersion,
+		trigger models.AntifroadRotationTrigger,
+		retireAt time.Time,
+	) (*models.AntifroadKeyRotation, error)
+}
+
+type sessionRepository interface {
+	Save(ctx context.Context, session *models.AntifroadSession) error
+	Get(ctx context.Context, id string) (*models.AntifroadSession, error)
+}
+
+type auditRecorder interface {
+	Record(ctx context.Context, event *models.AuditEvent)
+}
+
+type fraudMetrics interface {
+	FraudRejected(reason metrics.FraudReason)
+}
+
+// Service keeps versioned antifroad keys. Clients sign results with the current
+// key and send its id along, and results are verified against exactly that key
+// while it is within its validity window. A rotated key stays valid for
+// gracePeriod, so results signed just before the rotation are still accepted.
+type Service struct {
+	keyRepository            keyRepository
+	sessionRepository        sessionRepository
+	auditRecorder            auditRecorder
+	fraudMetrics             fraudMetrics
+	logger                   internal.Logger
+	gracePeriod              time.Duration
+	sessionTTL               time.Duration
+	requireSessionSignatures bool // Reject results that are signed with the global key only
+
+	mu      sync.RWMutex
+	current *models.AntifroadKeyVersion
+	keys    map[string]*models.AntifroadKeyVersion
+}
+
+func New(
+	keyRepository keyRepository,
+	sessionRepository sessionRepository,
+	auditRecorder auditRecorder,
+	fraudMetrics fraudMetrics,
+	logger internal.Logger,
+	gracePeriod time.Duration,
+	sessionTTL time.Duration,
+	requireSessionSignatures bool,
+) *Service {
+	return &Service{
+		keyRepository:            keyRepository,
+		sessionRepository:        sessionRepository,
+		auditRecorder:            auditRecorder,
+		fraudMetrics:             fraudMetrics,
+		logger:                   logger,
+		gracePeriod:              gracePeriod,
+		sessionTTL:               sessionTTL,
+		requireSessionSignatures: requireSessionSignatures,
+		keys:                     make(map[string]*models.AntifroadKeyVersion),
+	}
+}
+
+// Init loads keys and creates the first one if there is no active key yet.
+func (s *Service) Init(ctx context.Context) error {
+	if err := s.load(ctx); err != nil {
+		return err
+	}
+
+	s.mu.RLock()
+	current := s.current
+	s.mu.RUnlock()
+
+	if current != nil {
+		return nil
+	}
+
+	return s.Rotate(ctx, models.AntifroadRotationInit)
+}
+
+func randomHex(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// Rotate creates a new current key, starts the grace period of the previous
+// one and records who triggered the rotation.
+func (s *Service) Rotate(ctx context.Context, trigger models.AntifroadRotationTrigger) (err error) {
+	ctx, span := tracing.Start(ctx, "antifroad_key_service.Rotate")
+	defer func() { tracing.End(span, err) }()
+
+	value, err := randomHex(keyBytes)
+	if err != nil {
+		return err
+	}
+
+	kid, err := randomHex(kidBytes)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	key := &models.AntifroadKeyVersion{
+		KID:       kid,
+		Value:     value,
+		NotBefore: now,
+	}
+
+	rotation, err := s.keyRepository.Rotate(ctx, key, trigger, now.Add(s.gracePeriod))
+	if err != nil {
+		return err
+	}
+
+	ctx = s.logger.WithFields(ctx, map[string]any{
+		"kid":          rotation.KID,
+		"retired_kids": strings.Join(rotation.RetiredKIDs, ","),
+		"trigger":      rotation.Trigger,
+	})
+	s.logger.Info(s.logger.WithMsg(ctx, "antifroad key rotated"))
+
+	s.auditRecorder.Record(ctx, &models.AuditEvent{
+		Action: models.AuditAntifroadKeyRotated,
+		Details: map[string]any{
+			"kid":          rotation.KID,
+			"retired_kids": rotation.RetiredKIDs,
+			"trigger":      string(rotation.Trigger),
+		},
+	})
+
+	return s.load(ctx)
+}
+
+func (s *Service) load(ctx context.Context) error {
+	keys, err := s.keyRepository.GetValid(ctx, time.Now())
+	if err != nil {
+		return err
+	}
+
+	byKID := make(map[string]*models.AntifroadKeyVersion, len(keys))
+	var current *models.AntifroadKeyVersion
+	for _, key := range keys {
+		byKID[key.KID] = key
+		if current == nil && !key.IsRetired() {
+			current = key
+		}
+	}
+
+	s.mu.Lock()
+	s.current = current
+	s.keys = byKID
+	s.mu.Unlock()
+
+	return nil
+}
+
+// Current returns the key clients must sign new results with.
+func (s *Service) Current(ctx context.Context) (*models.AntifroadKeyVersion, error) {
+	// The key may have been rotated by another instance
+	if err := s.load(ctx); err != nil {
+		return nil, err
+	}
+
+	s.mu.RLock()
+	current := s.current
+	s.mu.RUnlock()
+
+	if current == nil {
+		return nil, ErrNoActiveKey
+	}
+
+	return current, nil
+}
+
+// key returns the key with the given id if it is valid right now.
+func (s *Service) key(ctx context.Context, kid string) (*models.AntifroadKeyVersion, error) {
+	if kid == "" {
+		return nil, ErrKeyRequired
+	}
+
+	s.mu.RLock()
+	key, ok := s.keys[kid]
+	s.mu.RUnlock()
+
+	if !ok {
+		if err := s.load(ctx); err != nil {
+			return nil, err
+		}
+
+		s.mu.RLock()
+		key, ok = s.keys[kid]
+		s.mu.RUnlock()
+		if !ok {
+			return nil, ErrUnknownKey
+		}
+	}
+
+	if !key.IsValidAt(time.Now()) {
+		return nil, ErrKeyExpired
+	}
+
+	return key, nil
+}
+
+// signed reports whether sign is the hex encoded HMAC-SHA256 of the fields
+// joined by "\n".
+func signed(secret []byte, sign string, fields []string) bool {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(strings.Join(fields, "\n")))
+
+	decoded, err := hex.DecodeString(sign)
+	return err == nil && hmac.Equal(mac.Sum(nil), decoded)
+}
+
+type VerifyIn struct {
+	KID    string
+	Sign   string   // Hex encoded HMAC-SHA256 of the fields joined by "\n"
+	Fields []string // Signed fields of the result in the documented order
+}
+
+// Verify checks the signature of a result against the key it names and no
+// other. The key must be valid right now.
+func (s *Service) Verify(ctx context.Context, in *VerifyIn) (err error) {
+	ctx, span := tracing.Start(ctx, "antifroad_key_service.Verify")
+	defer func() { tracing.End(span, err) }()
+
+	key, err := s.key(ctx, in.KID)
+	if err != nil {
+		return err
+	}
+
+	if !signed([]byte(key.Value), in.Sign, in.Fields) {
+		s.fraudMetrics.FraudRejected(metrics.FraudSignature)
+		return ErrInvalidSignature
+	}
+
+	return nil
+}
@@ -0,0 +1,117 @@
+package antifroad_key_service
+
+import (
+	"context"
+	"crypto/hkdf"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/ruslanonly/blindtyping/src/internal/models"
+	"github.com/ruslanonly/blindtyping/src/internal/shared/metrics"
+	"github.com/ruslanonly/blindtyping/src/internal/shared/tracing"
+)
+
+const (
+	sessionIDBytes = 16
+	secretBytes    = 32
+)
+
+type StartSessionOut struct {
+	Session *models.AntifroadSession
+	Secret  string // Hex encoded, returned to the client only
+}
+
+// deriveSecret binds the secret to the key, the session and the user, so it is
+// useless for signing results of anyone else.
+func deriveSecret(key *models.AntifroadKeyVersion, sessionID string, userID models.ID) ([]byte, error) {
+	info := fmt.Sprintf("blindtyping antifroad result signing, user %d", userID)
+	return hkdf.Key(sha256.New, []byte(key.Value), []byte(sessionID), info, secretBytes)
+}
+
+// StartSession issues a signing secret for the user derived from the current
+// key. The session ends when it expires or when its key leaves the validity
+// window after a rotation; the client then starts a new one.
+func (s *Service) StartSession(ctx context.Context, userID models.ID) (_ *StartSessionOut, err error) {
+	ctx, span := tracing.Start(ctx, "antifroad_key_service.StartSession")
+	defer func() { tracing.End(span, err) }()
+
+	key, err := s.Current(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := randomHex(sessionIDBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	session := &models.AntifroadSession{
+		ID:        id,
+		UserID:    userID,
+		KID:       key.KID,
+		ExpiresAt: time.Now().Add(s.sessionTTL),
+	}
+
+	secret, err := deriveSecret(key, session.ID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = s.sessionRepository.Save(ctx, session); err != nil {
+		return nil, err
+	}
+
+	return &StartSessionOut{
+		Session: session,
+		Secret:  hex.EncodeToString(secret),
+	}, nil
+}
+
+type VerifyResultIn struct {
+	UserID    models.ID
+	SessionID string
+	Sign      string   // Hex encoded HMAC-SHA256 of the fields joined by "\n"
+	Fields    []string // Signed fields of the result in the documented order
+}
+
+// VerifyResult checks the session signature of a result submitted by the user.
+// Results without a session pass unless session signatures are required.
+func (s *Service) VerifyResult(ctx context.Context, in *VerifyResultIn) (err error) {
+	ctx, span := tracing.Start(ctx, "antifroad_key_service.VerifyResult")
+	defer func() { tracing.End(span, err) }()
+
+	if in.SessionID == "" {
+		if s.requireSessionSignatures {
+			return ErrSessionRequired
+		}
+		return nil
+	}
+
+	session, err := s.sessionRepository.Get(ctx, in.SessionID)
+	if err != nil {
+		return err
+	}
+	// A session of another user is reported as unknown to not reveal it exists
+	if session == nil || session.UserID != in.UserID {
+		return ErrUnknownSession
+	}
+
+	key, err := s.key(ctx, session.KID)
+	if err != nil {
+		return err
+	}
+
+	secret, err := deriveSecret(key, session.ID, session.UserID)
+	if err != nil {
+		return err
+	}
+
+	if !signed(secret, in.Sign, in.Fields) {
+		s.fraudMetrics.FraudRejected(metrics.FraudSessionSignature)
+		return ErrInvalidSignature
+	}
+
+	return nil
+}
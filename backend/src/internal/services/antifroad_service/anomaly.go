@@ -0,0 +1,166 @@
+package antifroad_service
+
+import (
+	"math"
+	"time"
+
+	"github.com/ruslanonly/blindtyping/src/internal/models"
+)
+
+type AnomalyConfig struct {
+	MinHistory                 int           // Results in the same mode needed before WPM spikes are detected
+	WPMZScore                  float64       // How many standard deviations above the mean a WPM spike starts
+	DurationTolerance          time.Duration // Allowed gap between the duration and finishedAt - startedAt
+	MinUncompletedTestDuration time.Duration // Shorter abandoned tests on average look like scripted restarts
+	MinKeystrokeInterval       time.Duration // Shorter intervals between key presses are not humanly possible
+	MaxFastKeystrokeShare      float64       // Share of intervals below MinKeystrokeInterval tolerated as noise
+	Threshold                  float64       // Results scoring at least this much are flagged
+}
+
+// AnomalyIn is a submitted result together with the user's recent results in
+// the same language and mode.
+type AnomalyIn struct {
+	WPM                      float64
+	Duration                 time.Duration
+	StartedAt                time.Time
+	FinishedAt               time.Time
+	UncompletedTestsCount    uint64
+	UncompletedTestsDuration time.Duration
+	Keystrokes               []models.Keystroke
+	History                  []models.Statistics
+}
+
+type AnomalyReport struct {
+	Score   float64
+	Reasons []models.AnomalyReason
+	Flagged bool
+}
+
+func (r *AnomalyReport) add(reason models.AnomalyReason, score float64) {
+	r.Score += score
+	r.Reasons = append(r.Reasons, reason)
+}
+
+// AnomalyScorer looks for results that are statistically unlikely for a human
+// or for this particular user. It complements the signature check: a correctly
+// signed result can still be produced by a bot.
+type AnomalyScorer struct {
+	cfg AnomalyConfig
+}
+
+func NewAnomalyScorer(cfg AnomalyConfig) *AnomalyScorer {
+	return &AnomalyScorer{cfg: cfg}
+}
+
+func (s *AnomalyScorer) Score(in *AnomalyIn) *AnomalyReport {
+	report := &AnomalyReport{}
+
+	s.scoreWPM(in, report)
+	s.scoreDuration(in, report)
+	s.scoreUncompleted(in, report)
+	s.scoreKeystrokes(in, report)
+
+	report.Flagged = report.Score >= s.cfg.Threshold
+
+	return report
+}
+
+// scoreWPM flags results far above the user's usual speed. The further above
+// the threshold, the higher the score.
+func (s *AnomalyScorer) scoreWPM(in *AnomalyIn, report *AnomalyReport) {
+	if len(in.History) < s.cfg.MinHistory {
+		return
+	}
+
+	var sum, squares float64
+	for _, stat := range in.History {
+		wpm := float64(stat.WPM)
+		sum += wpm
+		squares += wpm * wpm
+	}
+
+	n := float64(len(in.History))
+	mean := sum / n
+	std := math.Sqrt(math.Max(squares/n-mean*mean, 0))
+
+	// Very consistent typists have a tiny deviation, so a few WPM above the
+	// mean must not count as a spike.
+	std = math.Max(std, mean*0.05)
+	if std == 0 {
+		return
+	}
+
+	z := (in.WPM - mean) / std
+	if z >= s.cfg.WPMZScore {
+		report.add(models.AnomalyWPMSpike, z/s.cfg.WPMZScore)
+	}
+}
+
+func (s *AnomalyScorer) scoreDuration(in *AnomalyIn, report *AnomalyReport) {
+	elapsed := in.FinishedAt.Sub(in.StartedAt)
+
+	diff := elapsed - in.Duration
+	if diff < 0 {
+		diff = -diff
+	}
+
+	if elapsed <= 0 || diff > s.cfg.DurationTolerance {
+		report.add(models.AnomalyDurationMismatch, 1)
+	}
+}
+
+// scoreUncompleted flags abandoned tests that are too short to be typed by a
+// human, and histories where every result reports the same non-zero number of
+// abandoned tests.
+func (s *AnomalyScorer) scoreUncompleted(in *AnomalyIn, report *AnomalyReport) {
+	if in.UncompletedTestsCount > 0 {
+		average := in.UncompletedTestsDuration / time.Duration(in.UncompletedTestsCount)
+		if average < s.cfg.MinUncompletedTestDuration {
+			report.add(models.AnomalyScriptedUncompleted, 1)
+			return
+		}
+	}
+
+	if in.UncompletedTestsCount == 0 || len(in.History) < s.cfg.MinHistory {
+		return
+	}
+
+	for _, stat := range in.History {
+		if uint64(stat.UncompletedTestsCount) != in.UncompletedTestsCount {
+			return
+		}
+	}
+
+	report.add(models.AnomalyScriptedUncompleted, 0.5)
+}
+
+// scoreKeystrokes flags key presses recorded out of order, released before they
+// were pressed, or following each other faster than fingers can move.
+func (s *AnomalyScorer) scoreKeystrokes(in *AnomalyIn, report *AnomalyReport) {
+	intervals := models.KeystrokeIntervals(in.Keystrokes)
+	if len(intervals) == 0 {
+		return
+	}
+
+	for _, keystroke := range in.Keystrokes {
+		if keystroke.Dwell() < 0 {
+			report.add(models.AnomalyImplausibleKeystrokes, 1)
+			return
+		}
+	}
+
+	fast := 0
+	for _, interval := range intervals {
+		if interval < 0 {
+			report.add(models.AnomalyImplausibleKeystrokes, 1)
+			return
+		}
+		if interval < s.cfg.MinKeystrokeInterval {
+			fast++
+		}
+	}
+
+	if float64(fast)/float64(len(intervals)) > s.cfg.MaxFastKeystrokeShare {
+		report.add(models.AnomalyImplausibleKeystrokes, 1)
+	}
+}
@@ -0,0 +1,301 @@
+package antifroad_service
+
+import (
+	"math"
+	"time"
+
+	"github.com/ruslanonly/blindtyping/src/internal/models"
+)
+
+type KeystrokeAction int
+
+const (
+	KeystrokePass KeystrokeAction = iota
+	KeystrokeFlag
+	KeystrokeReject
+)
+
+type BiometricsConfig struct {
+	MinKeystrokes         int     // Shorter runs carry too little timing data to judge
+	MinIntervalCV         float64 // Lower variation of intervals between key presses looks machine-generated
+	MinDwellStdDev        float64 // Lower deviation of key hold times in milliseconds looks machine-generated
+	MinBigramRepeats      int     // Bigrams typed fewer times are not checked for consistency
+	MaxUniformBigramShare float64 // Higher share of bigrams typed with identical timing looks replayed
+	MinTypedShare         float64 // Lower ratio of keystrokes to typed characters looks pasted
+	MinProfileSamples     int     // Runs needed before the user's profile is compared against
+	MaxProfileDeviation   float64 // Relative deviation from the profile that is flagged
+}
+
+// BigramTiming describes how consistently the user moves between two keys.
+type BigramTiming struct {
+	Count  int
+	Mean   float64
+	StdDev float64
+}
+
+// KeystrokeFeatures summarizes the timing of a run. All times are in
+// milliseconds.
+type KeystrokeFeatures struct {
+	Count          int
+	IntervalMean   float64
+	IntervalStdDev float64
+	DwellMean      float64
+	DwellStdDev    float64
+	Bigrams        map[string]BigramTiming
+}
+
+// IntervalCV is the coefficient of variation of intervals between key presses.
+// Humans rarely go below 0.2; scripted input is close to zero.
+func (f *KeystrokeFeatures) IntervalCV() float64 {
+	if f.IntervalMean == 0 {
+		return 0
+	}
+	return f.IntervalStdDev / f.IntervalMean
+}
+
+func milliseconds(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
+
+func meanStdDev(values []float64) (float64, float64) {
+	if len(values) == 0 {
+		return 0, 0
+	}
+
+	var sum float64
+	for _, v := range values {
+		sum += v
+	}
+	mean := sum / float64(len(values))
+
+	var squares float64
+	for _, v := range values {
+		squares += (v - mean) * (v - mean)
+	}
+
+	return mean, math.Sqrt(squares / float64(len(values)))
+}
+
+// ExtractKeystrokeFeatures computes timing features of the keystrokes, which
+// must be in the order they were pressed.
+func ExtractKeystrokeFeatures(keystrokes []models.Keystroke) *KeystrokeFeatures {
+	features := &KeystrokeFeatures{
+		Count:   len(keystrokes),
+		Bigrams: make(map[string]BigramTiming),
+	}
+
+	intervals := models.KeystrokeIntervals(keystrokes)
+	values := make([]float64, 0, len(intervals))
+	for _, interval := range intervals {
+		values = append(values, milliseconds(interval))
+	}
+	features.IntervalMean, features.IntervalStdDev = meanStdDev(values)
+
+	dwells := make([]float64, 0, len(keystrokes))
+	for _, keystroke := range keystrokes {
+		dwells = append(dwells, milliseconds(keystroke.Dwell()))
+	}
+	features.DwellMean, features.DwellStdDev = meanStdDev(dwells)
+
+	bigrams := make(map[string][]float64)
+	for i := 1; i < len(keystrokes); i++ {
+		bigram := keystrokes[i-1].Key + keystrokes[i].Key
+		bigrams[bigram] = append(bigrams[bigram], values[i-1])
+	}
+	for bigram, timings := range bigrams {
+		mean, stdDev := meanStdDev(timings)
+		features.Bigrams[bigram] = BigramTiming{
+			Count:  len(timings),
+			Mean:   mean,
+			StdDev: stdDev,
+		}
+	}
+
+	return features
+}
+
+// KeystrokeIn is a run to judge. Profile is nil until the user has one.
+type KeystrokeIn struct {
+	Features *KeystrokeFeatures
+	Profile  *models.KeystrokeProfile
+	CPM      float64
+	Duration time.Duration
+}
+
+// KeystrokeRule checks a single property of a run. It returns KeystrokePass
+// when the run looks human in that respect.
+type KeystrokeRule interface {
+	Check(in *KeystrokeIn) (models.AnomalyReason, KeystrokeAction)
+}
+
+type KeystrokeVerdict struct {
+	Action  KeystrokeAction
+	Reasons []models.AnomalyReason
+}
+
+// KeystrokeAnalyzer decides whether a run was typed by a human.
+type KeystrokeAnalyzer interface {
+	Analyze(in *KeystrokeIn) *KeystrokeVerdict
+}
+
+// KeystrokeRuleSet is a KeystrokeAnalyzer that applies every rule and takes the
+// strictest action among them.
+type KeystrokeRuleSet struct {
+	minKeystrokes int
+	rules         []KeystrokeRule
+}
+
+func NewKeystrokeRuleSet(minKeystrokes int, rules ...KeystrokeRule) *KeystrokeRuleSet {
+	return &KeystrokeRuleSet{
+		minKeystrokes: minKeystrokes,
+		rules:         rules,
+	}
+}
+
+// NewDefaultKeystrokeRuleSet builds the rule set used in production.
+func NewDefaultKeystrokeRuleSet(cfg BiometricsConfig) *KeystrokeRuleSet {
+	return NewKeystrokeRuleSet(
+		cfg.MinKeystrokes,
+		&PastedInputRule{MinTypedShare: cfg.MinTypedShare},
+		&UniformIntervalsRule{MinIntervalCV: cfg.MinIntervalCV},
+		&UniformDwellRule{MinDwellStdDev: cfg.MinDwellStdDev},
+		&RepeatedBigramsRule{MinRepeats: cfg.MinBigramRepeats, MaxUniformShare: cfg.MaxUniformBigramShare},
+		&ProfileMismatchRule{MinSamples: cfg.MinProfileSamples, MaxDeviation: cfg.MaxProfileDeviation},
+	)
+}
+
+func (r *KeystrokeRuleSet) Analyze(in *KeystrokeIn) *KeystrokeVerdict {
+	verdict := &KeystrokeVerdict{Action: KeystrokePass}
+
+	// Runs without keystrokes or with too few of them cannot be judged, so they
+	// are left to the anomaly scorer.
+	if in.Features == nil || in.Features.Count < r.minKeystrokes {
+		return verdict
+	}
+
+	for _, rule := range r.rules {
+		reason, action := rule.Check(in)
+		if action == KeystrokePass {
+			continue
+		}
+
+		verdict.Reasons = append(verdict.Reasons, reason)
+		if action > verdict.Action {
+			verdict.Action = action
+		}
+	}
+
+	return verdict
+}
+
+// PastedInputRule rejects runs with far fewer keystrokes than typed characters.
+type PastedInputRule struct {
+	MinTypedShare float64
+}
+
+func (r *PastedInputRule) Check(in *KeystrokeIn) (models.AnomalyReason, KeystrokeAction) {
+	typed := in.CPM * in.Duration.Minutes()
+	if typed > 0 && float64(in.Features.Count) < typed*r.MinTypedShare {
+		return models.AnomalyPastedInput, KeystrokeReject
+	}
+	return "", KeystrokePass
+}
+
+// UniformIntervalsRule rejects runs with (nearly) constant time between presses.
+type UniformIntervalsRule struct {
+	MinIntervalCV float64
+}
+
+func (r *UniformIntervalsRule) Check(in *KeystrokeIn) (models.AnomalyReason, KeystrokeAction) {
+	if in.Features.IntervalCV() < r.MinIntervalCV {
+		return models.AnomalyUniformIntervals, KeystrokeReject
+	}
+	return "", KeystrokePass
+}
+
+// UniformDwellRule rejects runs where every key is held for the same time.
+type UniformDwellRule struct {
+	MinDwellStdDev float64
+}
+
+func (r *UniformDwellRule) Check(in *KeystrokeIn) (models.AnomalyReason, KeystrokeAction) {
+	if in.Features.DwellStdDev < r.MinDwellStdDev {
+		return models.AnomalyUniformDwell, KeystrokeReject
+	}
+	return "", KeystrokePass
+}
+
+// RepeatedBigramsRule flags runs where repeated bigrams are typed with the exact
+// same timing, which happens when recorded input is replayed.
+type RepeatedBigramsRule struct {
+	MinRepeats      int
+	MaxUniformShare float64
+}
+
+func (r *RepeatedBigramsRule) Check(in *KeystrokeIn) (models.AnomalyReason, KeystrokeAction) {
+	var repeated, uniform int
+	for _, timing := range in.Features.Bigrams {
+		if timing.Count < r.MinRepeats {
+			continue
+		}
+
+		repeated++
+		if timing.StdDev < 1 {
+			uniform++
+		}
+	}
+
+	if repeated > 0 && float64(uniform)/float64(repeated) > r.MaxUniformShare {
+		return models.AnomalyRepeatedBigrams, KeystrokeFlag
+	}
+	return "", KeystrokePass
+}
+
+// ProfileMismatchRule flags runs whose rhythm does not match the user's history.
+// It compares hold times and interval variation, which stay stable as a typist
+// gets faster.
+type ProfileMismatchRule struct {
+	MinSamples   int
+	MaxDeviation float64
+}
+
+func deviation(value, expected float64) float64 {
+	if expected == 0 {
+		return 0
+	}
+	return math.Abs(value-expected) / expected
+}
+
+func (r *ProfileMismatchRule) Check(in *KeystrokeIn) (models.AnomalyReason, KeystrokeAction) {
+	if in.Profile == nil || in.Profile.Samples < r.MinSamples {
+		return "", KeystrokePass
+	}
+
+	if deviation(in.Features.DwellMean, in.Profile.DwellMean) > r.MaxDeviation &&
+		deviation(in.Features.IntervalCV(), in.Profile.IntervalCV) > r.MaxDeviation {
+		return models.AnomalyProfileMismatch, KeystrokeFlag
+	}
+	return "", KeystrokePass
+}
+
+// minProfileWeight keeps the profile following slow changes of the user's
+// rhythm once it has many samples.
+const minProfileWeight = 0.1
+
+// LearnKeystrokeProfile folds the features of an accepted run into the profile.
+// A nil profile starts a new one.
+func LearnKeystrokeProfile(profile *models.KeystrokeProfile, userID models.ID, features *KeystrokeFeatures, now time.Time) *models.KeystrokeProfile {
+	if profile == nil {
+		profile = &models.KeystrokeProfile{UserID: userID}
+	}
+
+	weight := math.Max(1/float64(profile.Samples+1), minProfileWeight)
+
+	profile.IntervalMean += (features.IntervalMean - profile.IntervalMean) * weight
+	profile.IntervalCV += (features.IntervalCV() - profile.IntervalCV) * weight
+	profile.DwellMean += (features.DwellMean - profile.DwellMean) * weight
+	profile.Samples++
+	profile.UpdatedAt = now
+
+	return profile
+}
@@ -0,0 +1,133 @@
+package antifroad_service
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/ruslanonly/blindtyping/src/internal/models"
+)
+
+// testBiometricsConfig matches the defaults of antifroad.biometrics.
+var testBiometricsConfig = BiometricsConfig{
+	MinKeystrokes:         30,
+	MinIntervalCV:         0.05,
+	MinDwellStdDev:        2,
+	MinBigramRepeats:      3,
+	MaxUniformBigramShare: 0.5,
+	MinTypedShare:         0.5,
+	MinProfileSamples:     10,
+	MaxProfileDeviation:   0.6,
+}
+
+// keystrokeFixture is a recorded run in testdata. Times are in milliseconds.
+type keystrokeFixture struct {
+	CPM        float64 `json:"cpm"`
+	DurationMs int64   `json:"durationMs"`
+	Keystrokes []struct {
+		Key        string `json:"key"`
+		PressedAt  int64  `json:"pressedAtMs"`
+		ReleasedAt int64  `json:"releasedAtMs"`
+	} `json:"keystrokes"`
+}
+
+func loadKeystrokeFixture(t *testing.T, name string) *KeystrokeIn {
+	t.Helper()
+
+	data, err := os.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var fixture keystrokeFixture
+	if err = json.Unmarshal(data, &fixture); err != nil {
+		t.Fatal(err)
+	}
+
+	keystrokes := make([]models.Keystroke, 0, len(fixture.Keystrokes))
+	for _, keystroke := range fixture.Keystrokes {
+		keystrokes = append(keystrokes, models.Keystroke{
+			Key:        keystroke.Key,
+			PressedAt:  time.Duration(keystroke.PressedAt) * time.Millisecond,
+			ReleasedAt: time.Duration(keystroke.ReleasedAt) * time.Millisecond,
+		})
+	}
+
+	return &KeystrokeIn{
+		Features: ExtractKeystrokeFeatures(keystrokes),
+		CPM:      fixture.CPM,
+		Duration: time.Duration(fixture.DurationMs) * time.Millisecond,
+	}
+}
+
+func TestKeystrokeRuleSetFixtures(t *testing.T) {
+	tests := []struct {
+		fixture string
+		action  KeystrokeAction
+		reasons []models.AnomalyReason
+	}{
+		{
+			fixture: "human.json",
+			action:  KeystrokePass,
+		},
+		{
+			fixture: "bot.json",
+			action:  KeystrokeReject,
+			reasons: []models.AnomalyReason{models.AnomalyUniformIntervals, models.AnomalyUniformDwell},
+		},
+		{
+			fixture: "paste.json",
+			action:  KeystrokeReject,
+			reasons: []models.AnomalyReason{models.AnomalyPastedInput},
+		},
+	}
+
+	analyzer := NewDefaultKeystrokeRuleSet(testBiometricsConfig)
+
+	for _, tt := range tests {
+		t.Run(tt.fixture, func(t *testing.T) {
+			verdict := analyzer.Analyze(loadKeystrokeFixture(t, tt.fixture))
+
+			if verdict.Action != tt.action {
+				t.Errorf("action = %d, want %d (reasons %v)", verdict.Action, tt.action, verdict.Reasons)
+			}
+			for _, reason := range tt.reasons {
+				if !slices.Contains(verdict.Reasons, reason) {
+					t.Errorf("reasons = %v, want %s among them", verdict.Reasons, reason)
+				}
+			}
+		})
+	}
+}
+
+func TestKeystrokeRuleSetPassesShortRuns(t *testing.T) {
+	analyzer := NewDefaultKeystrokeRuleSet(testBiometricsConfig)
+
+	human := loadKeystrokeFixture(t, "human.json")
+	short := &KeystrokeIn{
+		Features: &KeystrokeFeatures{Count: testBiometricsConfig.MinKeystrokes - 1},
+		CPM:      human.CPM,
+		Duration: human.Duration,
+	}
+	missing := &KeystrokeIn{
+		Features: ExtractKeystrokeFeatures(nil),
+		CPM:      human.CPM,
+		Duration: human.Duration,
+	}
+
+	for name, in := range map[string]*KeystrokeIn{"short": short, "missing": missing} {
+		t.Run(name, func(t *testing.T) {
+			verdict := analyzer.Analyze(in)
+
+			if verdict.Action != KeystrokePass {
+				t.Errorf("action = %d, want pass", verdict.Action)
+			}
+			if len(verdict.Reasons) > 0 {
+				t.Errorf("reasons = %v, want none", verdict.Reasons)
+			}
+		})
+	}
+}
@@ -0,0 +1,16 @@
+package audit_service
+
+import "errors"
+
+var (
+	ErrInvalidAction = errors.New("invalid audit action")
+	ErrInvalidPeriod = errors.New("from must be before to")
+)
+
+func IsInvalidActionError(err error) bool {
+	return errors.Is(err, ErrInvalidAction)
+}
+
+func IsInvalidPeriodError(err error) bool {
+	return errors.Is(err, ErrInvalidPeriod)
+}
@@ -0,0 +1,37 @@
+package audit_service
+
+import "context"
+
+// Request describes where an audited action came from. It is put into the
+// request context by the audit middleware.
+type Request struct {
+	IP        string
+	UserAgent string
+	RequestID string
+}
+
+type requestKey struct{}
+
+type actorServiceKey struct{}
+
+func WithRequest(ctx context.Context, request *Request) context.Context {
+	return context.WithValue(ctx, requestKey{}, request)
+}
+
+func requestFrom(ctx context.Context) *Request {
+	if request, ok := ctx.Value(requestKey{}).(*Request); ok {
+		return request
+	}
+	return &Request{}
+}
+
+// WithActorService marks actions recorded with the context as made by the
+// internal service with the given name.
+func WithActorService(ctx context.Context, name string) context.Context {
+	return context.WithValue(ctx, actorServiceKey{}, name)
+}
+
+func actorServiceFrom(ctx context.Context) string {
+	name, _ := ctx.Value(actorServiceKey{}).(string)
+	return name
+}
@@ -0,0 +1,124 @@
+package audit_service
+
+import (
+	"context"
+	"time"
+
+	"github.com/ruslanonly/blindtyping/src/internal"
+	"github.com/ruslanonly/blindtyping/src/internal/models"
+)
+
+const (
+	defaultSearchLimit = 50
+	maxSearchLimit     = 200
+)
+
+type eventRepository interface {
+	Append(ctx context.Context, event *models.AuditEvent) error
+	Search(ctx context.Context, filter *models.AuditFilter) ([]*models.AuditEvent, error)
+}
+
+type userRepository interface {
+	GetByEmail(ctx context.Context, email string) (*models.UserAccount, error)
+	GetByNickname(ctx context.Context, nickname string) (*models.UserAccount, error)
+}
+
+// Service keeps the audit trail of security-relevant account actions. Events
+// are written right away, but a failed write is only logged: the action itself
+// has already happened and must not fail because of the audit.
+type Service struct {
+	eventRepository eventRepository
+	userRepository  userRepository
+	logger          internal.Logger
+}
+
+func New(eventRepository eventRepository, userRepository userRepository, logger internal.Logger) *Service {
+	return &Service{
+		eventRepository: eventRepository,
+		userRepository:  userRepository,
+		logger:          logger,
+	}
+}
+
+// Record appends the event. IP, user agent, request id and the calling service
+// are taken from the context.
+func (s *Service) Record(ctx context.Context, event *models.AuditEvent) {
+	request := requestFrom(ctx)
+	event.IP = request.IP
+	event.UserAgent = request.UserAgent
+	event.RequestID = request.RequestID
+
+	if event.ActorService == "" {
+		event.ActorService = actorServiceFrom(ctx)
+	}
+	if event.CreatedAt.IsZero() {
+		event.CreatedAt = time.Now()
+	}
+
+	if err := s.eventRepository.Append(ctx, event); err != nil {
+		ctx = s.logger.WithField(ctx, "audit_action", string(event.Action))
+		s.logger.Error(s.logger.WithMsg(s.logger.WithError(ctx, err), "audit event is not recorded"))
+	}
+}
+
+// RecordUserAction records an action the user made on their own account.
+func (s *Service) RecordUserAction(ctx context.Context, action models.AuditAction, userID models.ID, details map[string]any) {
+	s.Record(ctx, &models.AuditEvent{
+		Action:  action,
+		UserID:  &userID,
+		ActorID: &userID,
+		Details: details,
+	})
+}
+
+// RecordLogin records a login through an OAuth provider. Login does not return
+// the user, so it is looked up by the email.
+func (s *Service) RecordLogin(ctx context.Context, email, provider string) {
+	account, err := s.userRepository.GetByEmail(ctx, email)
+	if err != nil {
+		s.logger.Error(s.logger.WithError(ctx, err))
+		return
+	}
+	if account == nil {
+		return
+	}
+
+	s.RecordUserAction(ctx, models.AuditLogin, account.ID, map[string]any{"provider": provider})
+}
+
+// RecordRegistration records a finished registration. Registration does not
+// return the new user, so it is looked up by the nickname.
+func (s *Service) RecordRegistration(ctx context.Context, nickname string) {
+	account, err := s.userRepository.GetByNickname(ctx, nickname)
+	if err != nil {
+		s.logger.Error(s.logger.WithError(ctx, err))
+		return
+	}
+	if account == nil {
+		return
+	}
+
+	s.RecordUserAction(ctx, models.AuditRegistration, account.ID, map[string]any{
+		"nickname": account.Nickname,
+		"provider": account.Provider,
+	})
+}
+
+// Search returns events matching the filter, newest first.
+func (s *Service) Search(ctx context.Context, filter *models.AuditFilter) ([]*models.AuditEvent, error) {
+	if filter.Action != "" && !filter.Action.IsValid() {
+		return nil, ErrInvalidAction
+	}
+	if filter.From != nil && filter.To != nil && !filter.From.Before(*filter.To) {
+		return nil, ErrInvalidPeriod
+	}
+
+	if filter.Limit <= 0 {
+		filter.Limit = defaultSearchLimit
+	}
+	if filter.Limit > maxSearchLimit {
+		filter.Limit = maxSearchLimit
+	}
+
+	return s.eventRepository.Search(ctx, filter)
+}
@@ -0,0 +1,16 @@
+package health_service
+
+import "errors"
+
+var (
+	ErrMigrationsPending = errors.New("database is not migrated to the latest version")
+	ErrMigrationDirty    = errors.New("last migration failed")
+)
+
+func IsMigrationsPendingError(err error) bool {
+	return errors.Is(err, ErrMigrationsPending)
+}
+
+func IsMigrationDirtyError(err error) bool {
+	return errors.Is(err, ErrMigrationDirty)
+}
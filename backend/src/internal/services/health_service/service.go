@@ -0,0 +1,170 @@
+package health_service
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	goredis "github.com/redis/go-redis/v9"
+
+	"github.com/ruslanonly/blindtyping/src/internal/models"
+)
+
+const checkTimeout = 2 * time.Second
+
+const (
+	CheckPostgres   = "postgres"
+	CheckRedis      = "redis"
+	CheckMigrations = "migrations"
+	CheckAntifroad  = "antifroad"
+)
+
+type postgres interface {
+	Ping(ctx context.Context) error
+}
+
+type redis interface {
+	Ping(ctx context.Context) *goredis.StatusCmd
+}
+
+type migrationRepository interface {
+	Applied(ctx context.Context) (*models.MigrationVersion, error)
+	Latest() (uint, error)
+}
+
+type antifroadKeyService interface {
+	Current(ctx context.Context) (*models.AntifroadKeyVersion, error)
+}
+
+// Service tells whether the instance can serve traffic. Once it starts
+// draining it stays not ready, so load balancers stop sending new requests
+// while the server finishes the current ones.
+type Service struct {
+	postgres            postgres
+	redis               redis
+	migrationRepository migrationRepository
+	antifroadKeyService antifroadKeyService
+	isAntifroadDisabled bool
+
+	draining atomic.Bool
+}
+
+func New(
+	postgres postgres,
+	redis redis,
+	migrationRepository migrationRepository,
+	antifroadKeyService antifroadKeyService,
+	isAntifroadDisabled bool,
+) *Service {
+	return &Service{
+		postgres:            postgres,
+		redis:               redis,
+		migrationRepository: migrationRepository,
+		antifroadKeyService: antifroadKeyService,
+		isAntifroadDisabled: isAntifroadDisabled,
+	}
+}
+
+// Drain marks the instance as shutting down.
+func (s *Service) Drain() {
+	s.draining.Store(true)
+}
+
+type Check struct {
+	Name string
+	Err  error // Nil if the dependency is available
+}
+
+type ReadinessOut struct {
+	IsDraining bool
+	Checks     []*Check // Empty while draining
+}
+
+func (o *ReadinessOut) IsReady() bool {
+	if o.IsDraining {
+		return false
+	}
+
+	for _, check := range o.Checks {
+		if check.Err != nil {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Readiness checks every dependency concurrently, each within checkTimeout.
+func (s *Service) Readiness(ctx context.Context) *ReadinessOut {
+	if s.draining.Load() {
+		return &ReadinessOut{IsDraining: true}
+	}
+
+	checks := map[string]func(ctx context.Context) error{
+		CheckPostgres:   s.postgres.Ping,
+		CheckRedis:      s.checkRedis,
+		CheckMigrations: s.checkMigrations,
+	}
+	if !s.isAntifroadDisabled {
+		checks[CheckAntifroad] = s.checkAntifroad
+	}
+
+	var (
+		out = &ReadinessOut{Checks: make([]*Check, 0, len(checks))}
+		mu  sync.Mutex
+		wg  sync.WaitGroup
+	)
+
+	for name, check := range checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			ctx, cancel := context.WithTimeout(ctx, checkTimeout)
+			defer cancel()
+
+			err := check(ctx)
+
+			mu.Lock()
+			out.Checks = append(out.Checks, &Check{Name: name, Err: err})
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+
+	return out
+}
+
+func (s *Service) checkRedis(ctx context.Context) error {
+	return s.redis.Ping(ctx).Err()
+}
+
+func (s *Service) checkMigrations(ctx context.Context) error {
+	latest, err := s.migrationRepository.Latest()
+	if err != nil {
+		return err
+	}
+
+	applied, err := s.migrationRepository.Applied(ctx)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case applied == nil:
+		return fmt.Errorf("%w: no migrations applied, latest is %d", ErrMigrationsPending, latest)
+	case applied.Dirty:
+		return fmt.Errorf("%w: version %d", ErrMigrationDirty, applied.Version)
+	case applied.Version < latest:
+		return fmt.Errorf("%w: applied %d, latest is %d", ErrMigrationsPending, applied.Version, latest)
+	}
+
+	return nil
+}
+
+func (s *Service) checkAntifroad(ctx context.Context) error {
+	_, err := s.antifroadKeyService.Current(ctx)
+	return err
+}
@@ -0,0 +1,46 @@
+package personal_token_service
+
+import "errors"
+
+var (
+	ErrInvalidScope  = errors.New("invalid token scope")
+	ErrEmptyScopes   = errors.New("token must have at least one scope")
+	ErrInvalidName   = errors.New("token name must be from 1 to 64 characters")
+	ErrTooManyTokens = errors.New("too many personal tokens")
+	ErrTokenNotFound = errors.New("personal token not found")
+	ErrInvalidToken  = errors.New("invalid personal token")
+	ErrTokenExpired  = errors.New("personal token expired")
+	ErrInvalidExpiry = errors.New("token expiration must be in the future")
+)
+
+func IsInvalidScopeError(err error) bool {
+	return errors.Is(err, ErrInvalidScope)
+}
+
+func IsEmptyScopesError(err error) bool {
+	return errors.Is(err, ErrEmptyScopes)
+}
+
+func IsInvalidNameError(err error) bool {
+	return errors.Is(err, ErrInvalidName)
+}
+
+func IsTooManyTokensError(err error) bool {
+	return errors.Is(err, ErrTooManyTokens)
+}
+
+func IsTokenNotFoundError(err error) bool {
+	return errors.Is(err, ErrTokenNotFound)
+}
+
+func IsInvalidTokenError(err error) bool {
+	return errors.Is(err, ErrInvalidToken)
+}
+
+func IsTokenExpiredError(err error) bool {
+	return errors.Is(err, ErrTokenExpired)
+}
+
+func IsInvalidExpiryError(err error) bool {
+	return errors.Is(err, ErrInvalidExpiry)
+}
@@ -0,0 +1,201 @@
+package personal_token_service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/ruslanonly/blindtyping/src/internal/models"
+	"github.com/ruslanonly/blindtyping/src/internal/shared/tracing"
+)
+
+const (
+	// TokenPrefix tells personal tokens apart from other bearer credentials.
+	TokenPrefix = "bt_pat_"
+
+	tokenBytes    = 32
+	maxNameLength = 64
+
+	// touchInterval is how precise last_used_at is: a token in active use
+	// writes it at most once per interval instead of on every request.
+	touchInterval = time.Minute
+
+	// createAttempts covers a concurrent create taking the same free slot.
+	createAttempts = 2
+
+	reasonTokenRevoked = "personal_token_revoked"
+)
+
+type tokenRepository interface {
+	Create(ctx context.Context, token *models.PersonalToken, tokenHash string, maxPerUser int) (*models.PersonalToken, error)
+	GetByUserID(ctx context.Context, userID models.ID) ([]*models.PersonalToken, error)
+	GetByHash(ctx context.Context, tokenHash string, now time.Time) (*models.PersonalToken, error)
+	Touch(ctx context.Context, id models.ID, usedAt, usedBefore time.Time) error
+	Delete(ctx context.Context, userID, id models.ID) (bool, error)
+}
+
+type auditRecorder interface {
+	RecordUserAction(ctx context.Context, action models.AuditAction, userID models.ID, details map[string]any)
+}
+
+type Service struct {
+	tokenRepository tokenRepository
+	auditRecorder   auditRecorder
+	maxPerUser      int
+}
+
+func New(tokenRepository tokenRepository, auditRecorder auditRecorder, maxPerUser int) *Service {
+	return &Service{
+		tokenRepository: tokenRepository,
+		auditRecorder:   auditRecorder,
+		maxPerUser:      maxPerUser,
+	}
+}
+
+type CreateIn struct {
+	UserID    models.ID
+	Name      string
+	Scopes    []models.Scope
+	ExpiresAt *time.Time
+}
+
+type CreateOut struct {
+	Token *models.PersonalToken
+	Value string // Plain token value, shown to the user only once
+}
+
+func hash(value string) string {
+	sum := sha256.Sum256([]byte(value))
+	return hex.EncodeToString(sum[:])
+}
+
+func generate() (string, error) {
+	b := make([]byte, tokenBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return TokenPrefix + base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+func (s *Service) validate(in *CreateIn, now time.Time) error {
+	name := strings.TrimSpace(in.Name)
+	if name == "" || utf8.RuneCountInString(name) > maxNameLength {
+		return ErrInvalidName
+	}
+
+	if len(in.Scopes) == 0 {
+		return ErrEmptyScopes
+	}
+
+	for _, scope := range in.Scopes {
+		if !scope.IsValid() {
+			return fmt.Errorf("%w: %s", ErrInvalidScope, scope)
+		}
+	}
+
+	if in.ExpiresAt != nil && !in.ExpiresAt.After(now) {
+		return ErrInvalidExpiry
+	}
+
+	return nil
+}
+
+func (s *Service) Create(ctx context.Context, in *CreateIn) (_ *CreateOut, err error) {
+	ctx, span := tracing.Start(ctx, "personal_token_service.Create")
+	defer func() { tracing.End(span, err) }()
+
+	now := time.Now()
+
+	if err := s.validate(in, now); err != nil {
+		return nil, err
+	}
+
+	value, err := generate()
+	if err != nil {
+		return nil, err
+	}
+
+	var token *models.PersonalToken
+	for attempt := 0; attempt < createAttempts && token == nil; attempt++ {
+		token, err = s.tokenRepository.Create(ctx, &models.PersonalToken{
+			UserID:    in.UserID,
+			Name:      strings.TrimSpace(in.Name),
+			Scopes:    in.Scopes,
+			CreatedAt: now,
+			ExpiresAt: in.ExpiresAt,
+		}, hash(value), s.maxPerUser)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if token == nil {
+		return nil, ErrTooManyTokens
+	}
+
+	return &CreateOut{
+		Token: token,
+		Value: value,
+	}, nil
+}
+
+func (s *Service) List(ctx context.Context, userID models.ID) ([]*models.PersonalToken, error) {
+	return s.tokenRepository.GetByUserID(ctx, userID)
+}
+
+func (s *Service) Delete(ctx context.Context, userID, id models.ID) error {
+	deleted, err := s.tokenRepository.Delete(ctx, userID, id)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return ErrTokenNotFound
+	}
+
+	s.auditRecorder.RecordUserAction(ctx, models.AuditSessionRevoked, userID, map[string]any{
+		"reason":   reasonTokenRevoked,
+		"token_id": id,
+	})
+
+	return nil
+}
+
+// Authenticate resolves a plain token value into the token it belongs to.
+// Usage of expired tokens is not recorded.
+func (s *Service) Authenticate(ctx context.Context, value string) (_ *models.PersonalToken, err error) {
+	ctx, span := tracing.Start(ctx, "personal_token_service.Authenticate")
+	defer func() { tracing.End(span, err) }()
+
+	if !strings.HasPrefix(value, TokenPrefix) {
+		return nil, ErrInvalidToken
+	}
+
+	now := time.Now()
+
+	token, err := s.tokenRepository.GetByHash(ctx, hash(value), now)
+	if err != nil {
+		return nil, err
+	}
+	if token == nil {
+		return nil, ErrInvalidToken
+	}
+	if token.IsExpired(now) {
+		return nil, ErrTokenExpired
+	}
+
+	usedBefore := now.Add(-touchInterval)
+	if token.LastUsedAt == nil || token.LastUsedAt.Before(usedBefore) {
+		if err = s.tokenRepository.Touch(ctx, token.ID, now, usedBefore); err != nil {
+			return nil, err
+		}
+		token.LastUsedAt = &now
+	}
+
+	return token, nil
+}
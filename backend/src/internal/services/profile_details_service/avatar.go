@@ -0,0 +1,91 @@
+package profile_details_service
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/draw"
+	_ "image/gif"
+	"image/jpeg"
+	_ "image/png"
+)
+
+const (
+	// maxSourcePixels guards against images that are small as files but
+	// decode into huge bitmaps. A 12 MP photo from a phone still fits, the
+	// decoded image and its square copy take about 100 MB at most.
+	maxSourcePixels = 4096 * 3072
+	avatarQuality   = 90
+)
+
+// processAvatar crops the image to a centered square, scales it down to size
+// and encodes it as JPEG. Transparent parts become white.
+func processAvatar(data []byte, size int) ([]byte, error) {
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, ErrUnsupportedImage
+	}
+	if cfg.Width*cfg.Height > maxSourcePixels {
+		return nil, ErrImageTooLarge
+	}
+
+	src, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, ErrUnsupportedImage
+	}
+
+	bounds := src.Bounds()
+	side := min(bounds.Dx(), bounds.Dy())
+	crop := image.Rect(0, 0, side, side).Add(image.Pt(
+		bounds.Min.X+(bounds.Dx()-side)/2,
+		bounds.Min.Y+(bounds.Dy()-side)/2,
+	))
+
+	square := image.NewRGBA(image.Rect(0, 0, side, side))
+	draw.Draw(square, square.Bounds(), image.NewUniform(color.White), image.Point{}, draw.Src)
+	draw.Draw(square, square.Bounds(), src, crop.Min, draw.Over)
+
+	var buf bytes.Buffer
+	if err = jpeg.Encode(&buf, scaleDown(square, size), &jpeg.Options{Quality: avatarQuality}); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// scaleDown resizes a square image to size by averaging the source pixels that
+// fall into every target pixel. Smaller images are left as they are.
+func scaleDown(src *image.RGBA, size int) *image.RGBA {
+	side := src.Bounds().Dx()
+	if side <= size {
+		return src
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, size, size))
+	for y := 0; y < size; y++ {
+		y0, y1 := y*side/size, (y+1)*side/size
+		for x := 0; x < size; x++ {
+			x0, x1 := x*side/size, (x+1)*side/size
+
+			var r, g, b, count int
+			for sy := y0; sy < y1; sy++ {
+				for sx := x0; sx < x1; sx++ {
+					offset := src.PixOffset(sx, sy)
+					r += int(src.Pix[offset])
+					g += int(src.Pix[offset+1])
+					b += int(src.Pix[offset+2])
+					count++
+				}
+			}
+
+			dst.SetRGBA(x, y, color.RGBA{
+				R: uint8(r / count),
+				G: uint8(g / count),
+				B: uint8(b / count),
+				A: 0xff,
+			})
+		}
+	}
+
+	return dst
+}
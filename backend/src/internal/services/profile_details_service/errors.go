@@ -0,0 +1,22 @@
+package profile_details_service
+
+import "errors"
+
+var (
+	ErrUserNotFound     = errors.New("user not found")
+	ErrUnsupportedImage = errors.New("avatar must be a JPEG, PNG or GIF image")
+	ErrImageTooLarge    = errors.New("avatar image dimensions are too large")
+	ErrAvatarNotFound   = errors.New("avatar not found")
+)
+
+func IsUserNotFoundError(err error) bool {
+	return errors.Is(err, ErrUserNotFound)
+}
+
+func IsInvalidAvatarError(err error) bool {
+	return errors.Is(err, ErrUnsupportedImage) || errors.Is(err, ErrImageTooLarge)
+}
+
+func IsAvatarNotFoundError(err error) bool {
+	return errors.Is(err, ErrAvatarNotFound)
+}
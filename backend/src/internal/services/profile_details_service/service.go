@@ -0,0 +1,196 @@
+package profile_details_service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/ruslanonly/blindtyping/src/internal"
+	"github.com/ruslanonly/blindtyping/src/internal/models"
+	"github.com/ruslanonly/blindtyping/src/internal/shared/storage"
+	"github.com/ruslanonly/blindtyping/src/internal/shared/tracing"
+)
+
+const (
+	avatarKeyPrefix   = "avatars/"
+	avatarContentType = "image/jpeg"
+)
+
+type detailsRepository interface {
+	GetByUserID(ctx context.Context, userID models.ID) (*models.ProfileDetails, error)
+	GetByNickname(ctx context.Context, nickname string) (*models.ProfileDetails, error)
+	Update(ctx context.Context, userID models.ID, update *models.ProfileDetailsUpdate) (*models.ProfileDetails, error)
+	SetAvatarKey(ctx context.Context, userID models.ID, key string) (string, error)
+	SetProviderAvatar(ctx context.Context, email, avatarURL string, updatedAt time.Time) error
+}
+
+type avatarStorage interface {
+	Put(ctx context.Context, key, contentType string, data []byte) error
+	Get(ctx context.Context, key string) ([]byte, string, error)
+	Delete(ctx context.Context, key string) error
+}
+
+// Service manages the profile fields the user fills in and the avatar.
+// Uploaded avatars are resized and kept in the storage under a new key every
+// time, so they can be cached forever; the replaced one is deleted.
+type Service struct {
+	detailsRepository detailsRepository
+	avatarStorage     avatarStorage
+	logger            internal.Logger
+	avatarSize        int
+	avatarsURL        string
+}
+
+func New(
+	detailsRepository detailsRepository,
+	avatarStorage avatarStorage,
+	logger internal.Logger,
+	avatarSize int,
+	avatarsURL string,
+) *Service {
+	return &Service{
+		detailsRepository: detailsRepository,
+		avatarStorage:     avatarStorage,
+		logger:            logger,
+		avatarSize:        avatarSize,
+		avatarsURL:        strings.TrimSuffix(avatarsURL, "/"),
+	}
+}
+
+func (s *Service) Get(ctx context.Context, userID models.ID) (*models.ProfileDetails, error) {
+	details, err := s.detailsRepository.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.withAvatarURL(details)
+}
+
+func (s *Service) GetByUsername(ctx context.Context, username string) (*models.ProfileDetails, error) {
+	details, err := s.detailsRepository.GetByNickname(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.withAvatarURL(details)
+}
+
+func (s *Service) Update(ctx context.Context, userID models.ID, update *models.ProfileDetailsUpdate) (_ *models.ProfileDetails, err error) {
+	ctx, span := tracing.Start(ctx, "profile_details_service.Update")
+	defer func() { tracing.End(span, err) }()
+
+	update = normalizeUpdate(update)
+	if err := update.Validate(); err != nil {
+		return nil, err
+	}
+
+	details, err := s.detailsRepository.Update(ctx, userID, update)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.withAvatarURL(details)
+}
+
+// UploadAvatar replaces the user's avatar with the image, cropped to a square
+// and scaled down.
+func (s *Service) UploadAvatar(ctx context.Context, userID models.ID, image []byte) (_ *models.ProfileDetails, err error) {
+	ctx, span := tracing.Start(ctx, "profile_details_service.UploadAvatar")
+	defer func() { tracing.End(span, err) }()
+
+	avatar, err := processAvatar(image, s.avatarSize)
+	if err != nil {
+		return nil, err
+	}
+
+	key := avatarKeyPrefix + uuid.NewString() + ".jpg"
+	if err = s.avatarStorage.Put(ctx, key, avatarContentType, avatar); err != nil {
+		return nil, err
+	}
+
+	previous, err := s.detailsRepository.SetAvatarKey(ctx, userID, key)
+	if err != nil {
+		s.deleteAvatar(ctx, key)
+		return nil, err
+	}
+	if previous != "" {
+		s.deleteAvatar(ctx, previous)
+	}
+
+	return s.Get(ctx, userID)
+}
+
+// GetAvatar returns an uploaded avatar by the name it is served under.
+func (s *Service) GetAvatar(ctx context.Context, name string) ([]byte, string, error) {
+	if name == "" || strings.ContainsAny(name, `/\`) {
+		return nil, "", ErrAvatarNotFound
+	}
+
+	data, contentType, err := s.avatarStorage.Get(ctx, avatarKeyPrefix+name)
+	if errors.Is(err, storage.ErrNotFound) {
+		return nil, "", ErrAvatarNotFound
+	}
+
+	return data, contentType, err
+}
+
+// SetProviderAvatar remembers the avatar the OAuth provider returned for the
+// email. It is shown until the user uploads their own.
+func (s *Service) SetProviderAvatar(ctx context.Context, email, avatarURL string) error {
+	if avatarURL == "" {
+		return nil
+	}
+
+	return s.detailsRepository.SetProviderAvatar(ctx, email, avatarURL, time.Now())
+}
+
+// DeleteAvatar deletes an uploaded avatar from the storage, e.g. after the
+// account is deleted. Failures are logged, a leftover file harms nobody.
+func (s *Service) DeleteAvatar(ctx context.Context, key string) {
+	s.deleteAvatar(ctx, key)
+}
+
+func (s *Service) deleteAvatar(ctx context.Context, key string) {
+	if err := s.avatarStorage.Delete(ctx, key); err != nil {
+		s.logger.Error(s.logger.WithError(s.logger.WithField(ctx, "avatar_key", key), err))
+	}
+}
+
+func (s *Service) withAvatarURL(details *models.ProfileDetails) (*models.ProfileDetails, error) {
+	if details == nil {
+		return nil, ErrUserNotFound
+	}
+
+	details.AvatarURL = details.ProviderAvatarURL
+	if details.AvatarKey != "" {
+		details.AvatarURL = s.avatarsURL + "/" + strings.TrimPrefix(details.AvatarKey, avatarKeyPrefix)
+	}
+
+	return details, nil
+}
+
+func normalizeUpdate(update *models.ProfileDetailsUpdate) *models.ProfileDetailsUpdate {
+	trim := func(value *string) *string {
+		if value == nil {
+			return nil
+		}
+		trimmed := strings.TrimSpace(*value)
+		return &trimmed
+	}
+
+	normalized := &models.ProfileDetailsUpdate{
+		Bio:      trim(update.Bio),
+		Country:  trim(update.Country),
+		Keyboard: trim(update.Keyboard),
+		Layout:   trim(update.Layout),
+	}
+	if normalized.Country != nil {
+		country := strings.ToUpper(*normalized.Country)
+		normalized.Country = &country
+	}
+
+	return normalized
+}
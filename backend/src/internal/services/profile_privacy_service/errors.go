@@ -0,0 +1,16 @@
+package profile_privacy_service
+
+import "errors"
+
+var (
+	ErrUserNotFound  = errors.New("user not found")
+	ErrProfileHidden = errors.New("profile is private")
+)
+
+func IsUserNotFoundError(err error) bool {
+	return errors.Is(err, ErrUserNotFound)
+}
+
+func IsProfileHiddenError(err error) bool {
+	return errors.Is(err, ErrProfileHidden)
+}
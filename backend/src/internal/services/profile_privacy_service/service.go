@@ -0,0 +1,143 @@
+package profile_privacy_service
+
+import (
+	"context"
+
+	"github.com/ruslanonly/blindtyping/src/internal"
+	"github.com/ruslanonly/blindtyping/src/internal/models"
+	"github.com/ruslanonly/blindtyping/src/internal/services/profile_service"
+)
+
+type privacyRepository interface {
+	GetByUserID(ctx context.Context, userID models.ID) (*models.ProfilePrivacy, error)
+	GetByNickname(ctx context.Context, nickname string) (*models.ProfilePrivacy, error)
+	Update(ctx context.Context, userID models.ID, update *models.ProfilePrivacyUpdate) (*models.ProfilePrivacy, error)
+}
+
+type shadowBanChecker interface {
+	IsShadowBannedFor(ctx context.Context, nickname string, viewerID *models.ID) (bool, error)
+}
+
+type profileGetter interface {
+	Get(ctx context.Context, in *profile_service.GetIn) (*models.Profile, error)
+}
+
+type profileCache interface {
+	Get(ctx context.Context, username, viewerClass string) (*models.Profile, error)
+	Set(ctx context.Context, username, viewerClass string, profile *models.Profile) error
+}
+
+// Service decides what a viewer may see of a profile. The privacy is checked
+// on every request and never cached. Profiles are cached already cut down,
+// one copy per viewer class, so a cached profile never holds a section the
+// viewer may not see, and a privacy change applies at once.
+type Service struct {
+	privacyRepository privacyRepository
+	shadowBanChecker  shadowBanChecker
+	profileGetter     profileGetter
+	profileCache      profileCache
+	logger            internal.Logger
+}
+
+func New(
+	privacyRepository privacyRepository,
+	shadowBanChecker shadowBanChecker,
+	profileGetter profileGetter,
+	profileCache profileCache,
+	logger internal.Logger,
+) *Service {
+	return &Service{
+		privacyRepository: privacyRepository,
+		shadowBanChecker:  shadowBanChecker,
+		profileGetter:     profileGetter,
+		profileCache:      profileCache,
+		logger:            logger,
+	}
+}
+
+func (s *Service) Get(ctx context.Context, userID models.ID) (*models.ProfilePrivacy, error) {
+	privacy, err := s.privacyRepository.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if privacy == nil {
+		return nil, ErrUserNotFound
+	}
+
+	return privacy, nil
+}
+
+func (s *Service) Update(ctx context.Context, userID models.ID, update *models.ProfilePrivacyUpdate) (*models.ProfilePrivacy, error) {
+	if err := update.Validate(); err != nil {
+		return nil, err
+	}
+
+	privacy, err := s.privacyRepository.Update(ctx, userID, update)
+	if err != nil {
+		return nil, err
+	}
+	if privacy == nil {
+		return nil, ErrUserNotFound
+	}
+
+	return privacy, nil
+}
+
+// Check returns the privacy of the profile as it applies to the viewer,
+// viewerID is nil for anonymous viewers. Private profiles of other users are
+// reported as hidden. Results of a shadow-banned user are hidden from everyone
+// but the user, the profile itself stays visible.
+func (s *Service) Check(ctx context.Context, username string, viewerID *models.ID) (*models.ProfilePrivacy, error) {
+	privacy, err := s.privacyRepository.GetByNickname(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+	if privacy == nil {
+		return nil, ErrUserNotFound
+	}
+
+	privacy = privacy.ForViewer(viewerID)
+	if privacy.Visibility == models.ProfilePrivate && (viewerID == nil || *viewerID != privacy.UserID) {
+		return nil, ErrProfileHidden
+	}
+
+	shadowBanned, err := s.shadowBanChecker.IsShadowBannedFor(ctx, username, viewerID)
+	if err != nil {
+		return nil, err
+	}
+	privacy.HideResults = shadowBanned
+
+	return privacy, nil
+}
+
+// GetProfile returns the profile as the viewer may see it along with the
+// privacy that applies to the viewer, viewerID is nil for anonymous viewers.
+func (s *Service) GetProfile(ctx context.Context, username string, viewerID *models.ID) (*models.Profile, *models.ProfilePrivacy, error) {
+	privacy, err := s.Check(ctx, username, viewerID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	viewerClass := privacy.ViewerClass()
+
+	profile, err := s.profileCache.Get(ctx, username, viewerClass)
+	if err != nil {
+		return nil, nil, err
+	}
+	if profile != nil {
+		return profile, privacy, nil
+	}
+
+	profile, err = s.profileGetter.Get(ctx, &profile_service.GetIn{Username: username})
+	if err != nil {
+		return nil, nil, err
+	}
+	profile = privacy.Cut(profile)
+
+	if err = s.profileCache.Set(ctx, username, viewerClass, profile); err != nil {
+		// The profile is read, it is just read from the database next time too
+		s.logger.Error(s.logger.WithError(ctx, err))
+	}
+
+	return profile, privacy, nil
+}
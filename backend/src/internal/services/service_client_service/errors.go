@@ -0,0 +1,41 @@
+package service_client_service
+
+import "errors"
+
+var (
+	ErrInvalidName          = errors.New("client name must be from 1 to 64 lowercase letters, digits, '-' or '_'")
+	ErrClientAlreadyExists  = errors.New("service client already exists")
+	ErrClientNotFound       = errors.New("service client not found")
+	ErrClientAlreadyRevoked = errors.New("service client already revoked")
+	ErrInvalidSignature     = errors.New("invalid request signature")
+	ErrRequestExpired       = errors.New("request timestamp is outside the allowed window")
+	ErrRequestReplayed      = errors.New("request already used")
+)
+
+func IsInvalidNameError(err error) bool {
+	return errors.Is(err, ErrInvalidName)
+}
+
+func IsClientAlreadyExistsError(err error) bool {
+	return errors.Is(err, ErrClientAlreadyExists)
+}
+
+func IsClientNotFoundError(err error) bool {
+	return errors.Is(err, ErrClientNotFound)
+}
+
+func IsClientAlreadyRevokedError(err error) bool {
+	return errors.Is(err, ErrClientAlreadyRevoked)
+}
+
+func IsInvalidSignatureError(err error) bool {
+	return errors.Is(err, ErrInvalidSignature)
+}
+
+func IsRequestExpiredError(err error) bool {
+	return errors.Is(err, ErrRequestExpired)
+}
+
+func IsRequestReplayedError(err error) bool {
+	return errors.Is(err, ErrRequestReplayed)
+}
@@ -0,0 +1,190 @@
+package service_client_service
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ruslanonly/blindtyping/src/internal/models"
+	"github.com/ruslanonly/blindtyping/src/internal/shared/tracing"
+)
+
+const (
+	secretBytes = 32
+
+	// touchInterval is how precise last_used_at is: a caller writes it at most
+	// once per interval instead of on every request.
+	touchInterval = time.Minute
+)
+
+var namePattern = regexp.MustCompile(`^[a-z0-9_-]{1,64}$`)
+
+type clientRepository interface {
+	Create(ctx context.Context, client *models.ServiceClient) (*models.ServiceClient, error)
+	GetAll(ctx context.Context) ([]*models.ServiceClient, error)
+	GetByID(ctx context.Context, id models.ID) (*models.ServiceClient, error)
+	GetByName(ctx context.Context, name string) (*models.ServiceClient, error)
+	Touch(ctx context.Context, id models.ID, usedAt, usedBefore time.Time) error
+	Revoke(ctx context.Context, id models.ID, revokedAt time.Time) (*models.ServiceClient, error)
+}
+
+type nonceRepository interface {
+	Use(ctx context.Context, clientName, signature string, ttl time.Duration) (bool, error)
+}
+
+// Service manages credentials of internal callers and authenticates their
+// requests. A request is signed with HMAC-SHA256 of the client secret over the
+// method, the path with the query, a unix timestamp and the body hash. Requests
+// outside maxClockSkew are rejected and every signature is accepted only once.
+type Service struct {
+	clientRepository clientRepository
+	nonceRepository  nonceRepository
+	maxClockSkew     time.Duration
+}
+
+func New(clientRepository clientRepository, nonceRepository nonceRepository, maxClockSkew time.Duration) *Service {
+	return &Service{
+		clientRepository: clientRepository,
+		nonceRepository:  nonceRepository,
+		maxClockSkew:     maxClockSkew,
+	}
+}
+
+type CreateIn struct {
+	ActorID models.ID
+	Name    string
+}
+
+// Create registers a new caller. The returned client holds the secret the
+// caller signs requests with.
+func (s *Service) Create(ctx context.Context, in *CreateIn) (*models.ServiceClient, error) {
+	name := strings.TrimSpace(in.Name)
+	if !namePattern.MatchString(name) {
+		return nil, ErrInvalidName
+	}
+
+	b := make([]byte, secretBytes)
+	if _, err := rand.Read(b); err != nil {
+		return nil, err
+	}
+
+	client, err := s.clientRepository.Create(ctx, &models.ServiceClient{
+		Name:      name,
+		Secret:    base64.RawURLEncoding.EncodeToString(b),
+		CreatedBy: &in.ActorID,
+		CreatedAt: time.Now(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if client == nil {
+		return nil, ErrClientAlreadyExists
+	}
+
+	return client, nil
+}
+
+func (s *Service) List(ctx context.Context) ([]*models.ServiceClient, error) {
+	return s.clientRepository.GetAll(ctx)
+}
+
+// Revoke stops accepting requests signed with the client secret.
+func (s *Service) Revoke(ctx context.Context, id models.ID) error {
+	client, err := s.clientRepository.Revoke(ctx, id, time.Now())
+	if err != nil {
+		return err
+	}
+	if client != nil {
+		return nil
+	}
+
+	client, err = s.clientRepository.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if client == nil {
+		return ErrClientNotFound
+	}
+
+	return ErrClientAlreadyRevoked
+}
+
+type AuthenticateIn struct {
+	ClientName string
+	Timestamp  string // Unix time in seconds
+	Signature  string // Hex encoded
+	Method     string
+	Path       string // Request URI with the query
+	Body       []byte
+}
+
+// Sign returns the signature of the request. Internal callers written in Go
+// may use it directly; other callers must reproduce the same message.
+func Sign(secret string, in *AuthenticateIn) string {
+	bodyHash := sha256.Sum256(in.Body)
+	message := strings.Join([]string{
+		in.Method,
+		in.Path,
+		in.Timestamp,
+		hex.EncodeToString(bodyHash[:]),
+	}, "\n")
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(message))
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func (s *Service) Authenticate(ctx context.Context, in *AuthenticateIn) (_ *models.ServiceClient, err error) {
+	ctx, span := tracing.Start(ctx, "service_client_service.Authenticate")
+	defer func() { tracing.End(span, err) }()
+
+	unix, err := strconv.ParseInt(in.Timestamp, 10, 64)
+	if err != nil {
+		return nil, ErrInvalidSignature
+	}
+
+	now := time.Now()
+	if skew := now.Sub(time.Unix(unix, 0)); skew > s.maxClockSkew || skew < -s.maxClockSkew {
+		return nil, ErrRequestExpired
+	}
+
+	client, err := s.clientRepository.GetByName(ctx, in.ClientName)
+	if err != nil {
+		return nil, err
+	}
+	// Unknown and revoked callers get the same error as a wrong signature
+	if client == nil || client.IsRevoked() {
+		return nil, ErrInvalidSignature
+	}
+
+	signature := strings.ToLower(in.Signature)
+	if !hmac.Equal([]byte(Sign(client.Secret, in)), []byte(signature)) {
+		return nil, ErrInvalidSignature
+	}
+
+	// A signature stays acceptable for maxClockSkew on either side of now
+	fresh, err := s.nonceRepository.Use(ctx, client.Name, signature, 2*s.maxClockSkew)
+	if err != nil {
+		return nil, err
+	}
+	if !fresh {
+		return nil, ErrRequestReplayed
+	}
+
+	usedBefore := now.Add(-touchInterval)
+	if client.LastUsedAt == nil || client.LastUsedAt.Before(usedBefore) {
+		if err = s.clientRepository.Touch(ctx, client.ID, now, usedBefore); err != nil {
+			return nil, err
+		}
+	}
+
+	return client, nil
+}
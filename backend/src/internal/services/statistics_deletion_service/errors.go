@@ -0,0 +1,16 @@
+package statistics_deletion_service
+
+import "errors"
+
+var (
+	ErrResultNotFound   = errors.New("result not found")
+	ErrNothingToRestore = errors.New("there is no wipe to restore within the restore window")
+)
+
+func IsResultNotFoundError(err error) bool {
+	return errors.Is(err, ErrResultNotFound)
+}
+
+func IsNothingToRestoreError(err error) bool {
+	return errors.Is(err, ErrNothingToRestore)
+}
@@ -0,0 +1,123 @@
+package statistics_deletion_service
+
+import (
+	"context"
+	"time"
+
+	"github.com/ruslanonly/blindtyping/src/internal"
+	"github.com/ruslanonly/blindtyping/src/internal/models"
+	"github.com/ruslanonly/blindtyping/src/internal/shared/tracing"
+)
+
+type deletionRepository interface {
+	Wipe(ctx context.Context, userID models.ID, wipedAt time.Time) error
+	Delete(ctx context.Context, userID, id models.ID, deletedAt time.Time) (bool, error)
+	RestoreLastWipe(ctx context.Context, userID models.ID, since time.Time) (int64, error)
+}
+
+type statisticsService interface {
+	DeleteAllForUser(ctx context.Context, userID uint64) error
+	RecomputeForUser(ctx context.Context, userID uint64) error
+}
+
+type auditRecorder interface {
+	RecordUserAction(ctx context.Context, action models.AuditAction, userID models.ID, details map[string]any)
+}
+
+// Service deletes the user's results one by one or all at once. A wipe can be
+// undone within the restore window. The rows are changed here; statistics_service
+// is then called because it owns the personal bests and profile caches.
+type Service struct {
+	deletionRepository deletionRepository
+	statisticsService  statisticsService
+	auditRecorder      auditRecorder
+	logger             internal.Logger
+	restoreWindow      time.Duration
+}
+
+func New(
+	deletionRepository deletionRepository,
+	statisticsService statisticsService,
+	auditRecorder auditRecorder,
+	logger internal.Logger,
+	restoreWindow time.Duration,
+) *Service {
+	return &Service{
+		deletionRepository: deletionRepository,
+		statisticsService:  statisticsService,
+		auditRecorder:      auditRecorder,
+		logger:             logger,
+		restoreWindow:      restoreWindow,
+	}
+}
+
+// Wipe deletes every result of the user.
+func (s *Service) Wipe(ctx context.Context, userID models.ID) (err error) {
+	ctx, span := tracing.Start(ctx, "statistics_deletion_service.Wipe")
+	defer func() { tracing.End(span, err) }()
+
+	if err = s.deletionRepository.Wipe(ctx, userID, time.Now()); err != nil {
+		return err
+	}
+
+	// The results are already deleted, what is left for statistics_service is
+	// dropping the caches built from them
+	if err = s.statisticsService.DeleteAllForUser(ctx, uint64(userID)); err != nil {
+		return err
+	}
+
+	s.auditRecorder.RecordUserAction(ctx, models.AuditStatisticsWiped, userID, nil)
+
+	return nil
+}
+
+// Delete deletes a single result. Deleted results can not be restored.
+func (s *Service) Delete(ctx context.Context, userID, id models.ID) (err error) {
+	ctx, span := tracing.Start(ctx, "statistics_deletion_service.Delete")
+	defer func() { tracing.End(span, err) }()
+
+	deleted, err := s.deletionRepository.Delete(ctx, userID, id, time.Now())
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return ErrResultNotFound
+	}
+
+	// The result may have been a personal best
+	if err = s.statisticsService.RecomputeForUser(ctx, uint64(userID)); err != nil {
+		return err
+	}
+
+	s.auditRecorder.RecordUserAction(ctx, models.AuditResultDeleted, userID, map[string]any{
+		"result_id": id,
+	})
+
+	return nil
+}
+
+// Restore undoes the latest wipe if it happened within the restore window and
+// returns how many results were restored.
+func (s *Service) Restore(ctx context.Context, userID models.ID) (_ int64, err error) {
+	ctx, span := tracing.Start(ctx, "statistics_deletion_service.Restore")
+	defer func() { tracing.End(span, err) }()
+
+	restored, err := s.deletionRepository.RestoreLastWipe(ctx, userID, time.Now().Add(-s.restoreWindow))
+	if err != nil {
+		return 0, err
+	}
+	if restored == 0 {
+		return 0, ErrNothingToRestore
+	}
+
+	// The wipe dropped the caches, they are built again from the restored results
+	if err = s.statisticsService.RecomputeForUser(ctx, uint64(userID)); err != nil {
+		return 0, err
+	}
+
+	s.auditRecorder.RecordUserAction(ctx, models.AuditStatisticsRestored, userID, map[string]any{
+		"restored": restored,
+	})
+
+	return restored, nil
+}
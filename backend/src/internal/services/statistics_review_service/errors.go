@@ -0,0 +1,36 @@
+package statistics_review_service
+
+import "errors"
+
+var (
+	ErrReviewNotFound       = errors.New("review not found")
+	ErrReviewAlreadyHandled = errors.New("review is already approved or rejected")
+	ErrResultAlreadyQueued  = errors.New("result is already waiting for review")
+	ErrInvalidStatus        = errors.New("invalid review status")
+	ErrMachineInput         = errors.New("keystrokes look machine-generated")
+	ErrUnverifiedResult     = errors.New("result held for review must be signed with an antifroad session")
+)
+
+func IsReviewNotFoundError(err error) bool {
+	return errors.Is(err, ErrReviewNotFound)
+}
+
+func IsReviewAlreadyHandledError(err error) bool {
+	return errors.Is(err, ErrReviewAlreadyHandled)
+}
+
+func IsResultAlreadyQueuedError(err error) bool {
+	return errors.Is(err, ErrResultAlreadyQueued)
+}
+
+func IsInvalidStatusError(err error) bool {
+	return errors.Is(err, ErrInvalidStatus)
+}
+
+func IsMachineInputError(err error) bool {
+	return errors.Is(err, ErrMachineInput)
+}
+
+func IsUnverifiedResultError(err error) bool {
+	return errors.Is(err, ErrUnverifiedResult)
+}
@@ -0,0 +1,371 @@
+package statistics_review_service
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/ruslanonly/blindtyping/src/internal"
+	"github.com/ruslanonly/blindtyping/src/internal/models"
+	"github.com/ruslanonly/blindtyping/src/internal/services/antifroad_service"
+	"github.com/ruslanonly/blindtyping/src/internal/services/statistics_service"
+	"github.com/ruslanonly/blindtyping/src/internal/shared/metrics"
+	"github.com/ruslanonly/blindtyping/src/internal/shared/tracing"
+)
+
+const (
+	defaultListLimit = 50
+	maxListLimit     = 200
+)
+
+type reviewRepository interface {
+	Create(ctx context.Context, review *models.StatisticsReview) (*models.StatisticsReview, error)
+	Get(ctx context.Context, id models.ID) (*models.StatisticsReview, error)
+	GetByStatus(ctx context.Context, status models.ReviewStatus, limit int) ([]*models.StatisticsReview, error)
+	Resolve(ctx context.Context, id models.ID, status models.ReviewStatus, reviewerID models.ID, reviewedAt time.Time) (bool, error)
+	Approve(ctx context.Context, id models.ID, reviewerID models.ID, reviewedAt time.Time, result *models.HeldResult) (bool, error)
+}
+
+type statisticsService interface {
+	GetByUser(ctx context.Context, in *statistics_service.GetByUserIn) ([]models.Statistics, error)
+	RecomputeForUser(ctx context.Context, userID uint64) error
+}
+
+type anomalyScorer interface {
+	Score(in *antifroad_service.AnomalyIn) *antifroad_service.AnomalyReport
+}
+
+type fraudMetrics interface {
+	FraudRejected(reason metrics.FraudReason)
+}
+
+type profileRepository interface {
+	Get(ctx context.Context, userID models.ID) (*models.KeystrokeProfile, error)
+	Save(ctx context.Context, profile *models.KeystrokeProfile) error
+}
+
+// Service holds back results the anomaly scorer or the keystroke analyzer
+// flags. A held result does not reach statistics and personal bests until a
+// moderator approves it. Results the keystroke analyzer rejects are dropped.
+type Service struct {
+	reviewRepository  reviewRepository
+	profileRepository profileRepository
+	statisticsService statisticsService
+	anomalyScorer     anomalyScorer
+	keystrokeAnalyzer antifroad_service.KeystrokeAnalyzer
+	fraudMetrics      fraudMetrics
+	logger            internal.Logger
+	historyWindow     time.Duration
+	minKeystrokes     int
+}
+
+func New(
+	reviewRepository reviewRepository,
+	profileRepository profileRepository,
+	statisticsService statisticsService,
+	anomalyScorer anomalyScorer,
+	keystrokeAnalyzer antifroad_service.KeystrokeAnalyzer,
+	fraudMetrics fraudMetrics,
+	logger internal.Logger,
+	historyWindow time.Duration,
+	minKeystrokes int,
+) *Service {
+	return &Service{
+		reviewRepository:  reviewRepository,
+		profileRepository: profileRepository,
+		statisticsService: statisticsService,
+		anomalyScorer:     anomalyScorer,
+		keystrokeAnalyzer: keystrokeAnalyzer,
+		fraudMetrics:      fraudMetrics,
+		logger:            logger,
+		historyWindow:     historyWindow,
+		minKeystrokes:     minKeystrokes,
+	}
+}
+
+type CheckIn struct {
+	Result     *statistics_service.SaveIn
+	Keystrokes []models.Keystroke
+	Verified   bool // The result's session signature has been checked
+}
+
+func newHeldResult(in *statistics_service.SaveIn) *models.HeldResult {
+	return &models.HeldResult{
+		UserID:                   in.UserID,
+		WPM:                      in.WPM,
+		CPM:                      in.CPM,
+		Accuracy:                 in.Accuracy,
+		Duration:                 in.Duration,
+		Language:                 in.Language,
+		Mode:                     in.Mode,
+		SubMode:                  in.SubMode,
+		IsPunctuation:            in.IsPunctuation,
+		UncompletedTestsCount:    in.UncompletedTestsCount,
+		UncompletedTestsDuration: in.UncompletedTestsDurationMs,
+		UID:                      in.UID,
+		CreatedAt:                in.CreatedAt,
+		StartedAt:                in.StartedAt,
+		FinishedAt:               in.FinishedAt,
+	}
+}
+
+// history returns the user's recent results in the same language and mode.
+func (s *Service) history(ctx context.Context, result *statistics_service.SaveIn) ([]models.Statistics, error) {
+	dateFrom := time.Now().Add(-s.historyWindow)
+
+	stats, err := s.statisticsService.GetByUser(ctx, &statistics_service.GetByUserIn{
+		UserID:   result.UserID,
+		DateFrom: &dateFrom,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	history := make([]models.Statistics, 0, len(stats))
+	for _, stat := range stats {
+		if string(stat.Language) == result.Language &&
+			string(stat.Mode) == result.Mode &&
+			string(stat.SubMode) == result.SubMode {
+			history = append(history, stat)
+		}
+	}
+
+	return history, nil
+}
+
+// analyzeKeystrokes judges the keystrokes against the user's typing profile.
+func (s *Service) analyzeKeystrokes(ctx context.Context, in *CheckIn) (*antifroad_service.KeystrokeVerdict, error) {
+	profile, err := s.profileRepository.Get(ctx, in.Result.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.keystrokeAnalyzer.Analyze(&antifroad_service.KeystrokeIn{
+		Features: antifroad_service.ExtractKeystrokeFeatures(in.Keystrokes),
+		Profile:  profile,
+		CPM:      in.Result.CPM,
+		Duration: in.Result.Duration,
+	}), nil
+}
+
+// Check scores the result and queues it for review when it is flagged. It
+// returns nil if the result can be saved right away and ErrMachineInput if it
+// must not be saved at all. Approved results are stored without another
+// signature check, so only verified results are queued or rejected; a result
+// the analyzer does not pass gets ErrUnverifiedResult until it is verified.
+func (s *Service) Check(ctx context.Context, in *CheckIn) (_ *models.StatisticsReview, err error) {
+	ctx, span := tracing.Start(ctx, "statistics_review_service.Check")
+	defer func() { tracing.End(span, err) }()
+
+	verdict, err := s.analyzeKeystrokes(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+	if verdict.Action != antifroad_service.KeystrokePass && !in.Verified {
+		return nil, ErrUnverifiedResult
+	}
+	if verdict.Action == antifroad_service.KeystrokeReject {
+		ctx = s.logger.WithUserID(ctx, int64(in.Result.UserID))
+		ctx = s.logger.WithFields(ctx, map[string]any{
+			"security_event": "statistics_rejected",
+			"reasons":        verdict.Reasons,
+		})
+		s.logger.Warning(s.logger.WithMsg(ctx, "machine-generated keystrokes rejected"))
+		s.fraudMetrics.FraudRejected(metrics.FraudMachineInput)
+		return nil, ErrMachineInput
+	}
+
+	history, err := s.history(ctx, in.Result)
+	if err != nil {
+		return nil, err
+	}
+
+	report := s.anomalyScorer.Score(&antifroad_service.AnomalyIn{
+		WPM:                      in.Result.WPM,
+		Duration:                 in.Result.Duration,
+		StartedAt:                in.Result.StartedAt,
+		FinishedAt:               in.Result.FinishedAt,
+		UncompletedTestsCount:    in.Result.UncompletedTestsCount,
+		UncompletedTestsDuration: in.Result.UncompletedTestsDurationMs,
+		Keystrokes:               in.Keystrokes,
+		History:                  history,
+	})
+	if verdict.Action == antifroad_service.KeystrokeFlag {
+		report.Score += float64(len(verdict.Reasons))
+		report.Reasons = append(report.Reasons, verdict.Reasons...)
+		report.Flagged = true
+	}
+	if !report.Flagged {
+		return nil, nil
+	}
+	if !in.Verified {
+		return nil, ErrUnverifiedResult
+	}
+
+	payload, err := json.Marshal(newHeldResult(in.Result))
+	if err != nil {
+		return nil, err
+	}
+
+	review, err := s.reviewRepository.Create(ctx, &models.StatisticsReview{
+		UserID:    in.Result.UserID,
+		UID:       in.Result.UID,
+		Payload:   payload,
+		Score:     report.Score,
+		Reasons:   report.Reasons,
+		Status:    models.ReviewPending,
+		CreatedAt: time.Now(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if review == nil {
+		return nil, ErrResultAlreadyQueued
+	}
+
+	ctx = s.logger.WithUserID(ctx, int64(review.UserID))
+	ctx = s.logger.WithFields(ctx, map[string]any{
+		"security_event": "statistics_flagged",
+		"review_id":      review.ID,
+		"score":          review.Score,
+		"reasons":        review.Reasons,
+	})
+	s.logger.Warning(s.logger.WithMsg(ctx, "result held for review"))
+
+	return review, nil
+}
+
+// Learn folds the keystrokes of a saved result into the user's typing profile.
+func (s *Service) Learn(ctx context.Context, userID models.ID, keystrokes []models.Keystroke) error {
+	if len(keystrokes) < s.minKeystrokes {
+		return nil
+	}
+
+	profile, err := s.profileRepository.Get(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	features := antifroad_service.ExtractKeystrokeFeatures(keystrokes)
+	profile = antifroad_service.LearnKeystrokeProfile(profile, userID, features, time.Now())
+
+	return s.profileRepository.Save(ctx, profile)
+}
+
+type ListIn struct {
+	Status models.ReviewStatus
+	Limit  int
+}
+
+func (s *Service) List(ctx context.Context, in *ListIn) ([]*models.StatisticsReview, error) {
+	status := in.Status
+	if status == "" {
+		status = models.ReviewPending
+	}
+	if !status.IsValid() {
+		return nil, ErrInvalidStatus
+	}
+
+	limit := in.Limit
+	if limit <= 0 {
+		limit = defaultListLimit
+	}
+	if limit > maxListLimit {
+		limit = maxListLimit
+	}
+
+	return s.reviewRepository.GetByStatus(ctx, status, limit)
+}
+
+type ResolveIn struct {
+	ReviewerID models.ID
+	ReviewID   models.ID
+}
+
+func (s *Service) pending(ctx context.Context, id models.ID) (*models.StatisticsReview, error) {
+	review, err := s.reviewRepository.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if review == nil {
+		return nil, ErrReviewNotFound
+	}
+	if review.Status != models.ReviewPending {
+		return nil, ErrReviewAlreadyHandled
+	}
+
+	return review, nil
+}
+
+func (s *Service) logResolved(ctx context.Context, in *ResolveIn, status models.ReviewStatus) {
+	ctx = s.logger.WithUserID(ctx, int64(in.ReviewerID))
+	ctx = s.logger.WithFields(ctx, map[string]any{
+		"review_id":     in.ReviewID,
+		"review_status": status,
+	})
+	s.logger.Info(s.logger.WithMsg(ctx, "review resolved"))
+}
+
+func (s *Service) resolve(ctx context.Context, in *ResolveIn, status models.ReviewStatus) error {
+	resolved, err := s.reviewRepository.Resolve(ctx, in.ReviewID, status, in.ReviewerID, time.Now())
+	if err != nil {
+		return err
+	}
+	if !resolved {
+		return ErrReviewAlreadyHandled
+	}
+
+	s.logResolved(ctx, in, status)
+
+	return nil
+}
+
+// Approve stores the held result. Its signature was verified when it was
+// queued, so the approval does not depend on the signing key or the antifroad
+// session still being valid. The result does not go through
+// statistics_service.Save, so the personal bests and profile aggregates are
+// recomputed from the stored results afterwards.
+func (s *Service) Approve(ctx context.Context, in *ResolveIn) (err error) {
+	ctx, span := tracing.Start(ctx, "statistics_review_service.Approve")
+	defer func() { tracing.End(span, err) }()
+
+	review, err := s.pending(ctx, in.ReviewID)
+	if err != nil {
+		return err
+	}
+
+	var result models.HeldResult
+	if err = json.Unmarshal(review.Payload, &result); err != nil {
+		return err
+	}
+
+	approved, err := s.reviewRepository.Approve(ctx, review.ID, in.ReviewerID, time.Now(), &result)
+	if err != nil {
+		return err
+	}
+	if !approved {
+		return ErrReviewAlreadyHandled
+	}
+
+	s.logResolved(ctx, in, models.ReviewApproved)
+
+	// The review is already approved and can not be retried, so a failure only
+	// leaves the caches stale until they expire
+	if err := s.statisticsService.RecomputeForUser(ctx, uint64(review.UserID)); err != nil {
+		s.logger.Error(s.logger.WithError(ctx, err))
+	}
+
+	return nil
+}
+
+// Reject drops the held result for good.
+func (s *Service) Reject(ctx context.Context, in *ResolveIn) (err error) {
+	ctx, span := tracing.Start(ctx, "statistics_review_service.Reject")
+	defer func() { tracing.End(span, err) }()
+
+	if _, err := s.pending(ctx, in.ReviewID); err != nil {
+		return err
+	}
+
+	return s.resolve(ctx, in, models.ReviewRejected)
+}
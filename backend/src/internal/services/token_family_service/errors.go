@@ -0,0 +1,9 @@
+package token_family_service
+
+import "errors"
+
+var ErrRefreshTokenReused = errors.New("refresh token reused")
+
+func IsRefreshTokenReusedError(err error) bool {
+	return errors.Is(err, ErrRefreshTokenReused)
+}
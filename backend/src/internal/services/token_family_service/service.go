@@ -0,0 +1,156 @@
+package token_family_service
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/ruslanonly/blindtyping/src/internal"
+	"github.com/ruslanonly/blindtyping/src/internal/models"
+	"github.com/ruslanonly/blindtyping/src/internal/shared/tracing"
+)
+
+const securityEventRefreshTokenReuse = "refresh_token_reuse"
+
+type familyRepository interface {
+	Get(ctx context.Context, refreshToken string) (*models.SessionFamilyMember, error)
+	Start(ctx context.Context, refreshToken string, familyID uuid.UUID) error
+	Claim(ctx context.Context, refreshToken string, familyID uuid.UUID, rotatedAt time.Time) (*models.SessionFamilyMember, error)
+	Release(ctx context.Context, refreshToken string, rotatedAt time.Time) error
+	Revoke(ctx context.Context, familyID uuid.UUID, revokedAt time.Time) error
+	DeleteExpired(ctx context.Context, now time.Time) error
+}
+
+type revocationRepository interface {
+	RevokeBefore(ctx context.Context, userID models.ID, revokedAt time.Time) error
+	RevokedBefore(ctx context.Context, userID models.ID) (*time.Time, error)
+}
+
+type auditRecorder interface {
+	Record(ctx context.Context, event *models.AuditEvent)
+}
+
+// Service tracks refresh token families: every login starts a family and every
+// refresh rotates a token within it. Presenting a token that was already rotated
+// means it leaked, so the whole family and all user's access tokens are revoked.
+type Service struct {
+	familyRepository     familyRepository
+	revocationRepository revocationRepository
+	auditRecorder        auditRecorder
+	logger               internal.Logger
+}
+
+func New(
+	familyRepository familyRepository,
+	revocationRepository revocationRepository,
+	auditRecorder auditRecorder,
+	logger internal.Logger,
+) *Service {
+	return &Service{
+		familyRepository:     familyRepository,
+		revocationRepository: revocationRepository,
+		auditRecorder:        auditRecorder,
+		logger:               logger,
+	}
+}
+
+// Start opens a family for a refresh token issued by login or registration.
+func (s *Service) Start(ctx context.Context, refreshToken string) error {
+	return s.familyRepository.Start(ctx, refreshToken, uuid.New())
+}
+
+// Claim must be called before the refresh token is exchanged. It marks the
+// token as rotated in the same statement that checks it, so of concurrent
+// refreshes with one token only the first gets through. The others, and any
+// later use of the token, get ErrRefreshTokenReused and revoke the family.
+// It returns nil for tokens without a session.
+func (s *Service) Claim(ctx context.Context, refreshToken string) (_ *models.SessionFamilyMember, err error) {
+	ctx, span := tracing.Start(ctx, "token_family_service.Claim")
+	defer func() { tracing.End(span, err) }()
+
+	claimed, err := s.familyRepository.Claim(ctx, refreshToken, uuid.New(), time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if claimed != nil {
+		return claimed, nil
+	}
+
+	member, err := s.familyRepository.Get(ctx, refreshToken)
+	if err != nil {
+		return nil, err
+	}
+	if member == nil || !member.IsRotated() {
+		return nil, nil
+	}
+
+	if err = s.revoke(ctx, member); err != nil {
+		return nil, err
+	}
+
+	return nil, ErrRefreshTokenReused
+}
+
+// Complete adds the token the claimed one was exchanged for to its family.
+func (s *Service) Complete(ctx context.Context, claimed *models.SessionFamilyMember, newRefreshToken string) (err error) {
+	ctx, span := tracing.Start(ctx, "token_family_service.Complete")
+	defer func() { tracing.End(span, err) }()
+
+	return s.familyRepository.Start(ctx, newRefreshToken, claimed.FamilyID)
+}
+
+// Release undoes the claim when the token could not be exchanged, so the
+// user may retry with it.
+func (s *Service) Release(ctx context.Context, claimed *models.SessionFamilyMember) error {
+	return s.familyRepository.Release(ctx, claimed.RefreshToken, *claimed.RotatedAt)
+}
+
+func (s *Service) revoke(ctx context.Context, member *models.SessionFamilyMember) error {
+	now := time.Now()
+
+	if err := s.familyRepository.Revoke(ctx, member.FamilyID, now); err != nil {
+		return err
+	}
+
+	if err := s.revocationRepository.RevokeBefore(ctx, member.UserID, now); err != nil {
+		return err
+	}
+
+	ctx = s.logger.WithUserID(ctx, int64(member.UserID))
+	ctx = s.logger.WithFields(ctx, map[string]any{
+		"security_event": securityEventRefreshTokenReuse,
+		"family_id":      member.FamilyID.String(),
+		"rotated_at":     member.RotatedAt,
+	})
+	s.logger.Warning(s.logger.WithMsg(ctx, "rotated refresh token presented again, token family revoked"))
+
+	s.auditRecorder.Record(ctx, &models.AuditEvent{
+		Action: models.AuditSessionRevoked,
+		UserID: &member.UserID,
+		Details: map[string]any{
+			"reason":    securityEventRefreshTokenReuse,
+			"family_id": member.FamilyID.String(),
+		},
+	})
+
+	return nil
+}
+
+// IsAccessTokenRevoked reports whether an access token issued at issuedAt was
+// revoked by a detected refresh token reuse.
+func (s *Service) IsAccessTokenRevoked(ctx context.Context, userID models.ID, issuedAt time.Time) (bool, error) {
+	revokedBefore, err := s.revocationRepository.RevokedBefore(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+	if revokedBefore == nil {
+		return false, nil
+	}
+
+	return !issuedAt.After(*revokedBefore), nil
+}
+
+func (s *Service) DeleteExpired(ctx context.Context) error {
+	return s.familyRepository.DeleteExpired(ctx, time.Now())
+}
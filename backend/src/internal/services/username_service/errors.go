@@ -0,0 +1,72 @@
+package username_service
+
+import "errors"
+
+var (
+	ErrUserNotFound           = errors.New("user not found")
+	ErrSameUsername           = errors.New("username is the same as the current one")
+	ErrTooMuchUsernameChanges = errors.New("username can not be changed this often")
+)
+
+// Policy errors, the username can not be taken by anybody.
+var (
+	ErrUsernameTooShort     = errors.New("username is too short")
+	ErrUsernameTooLong      = errors.New("username is too long")
+	ErrUsernameCharacters   = errors.New("username contains characters that are not allowed")
+	ErrUsernameMixedScripts = errors.New("username mixes letters of different alphabets")
+	ErrUsernameNoLetters    = errors.New("username must contain a letter")
+	ErrUsernameForbidden    = errors.New("username is reserved for the service")
+	ErrUsernameProfane      = errors.New("username contains inappropriate words")
+)
+
+// Conflict errors, the username or a look-alike belongs to somebody else.
+var (
+	ErrUsernameTaken      = errors.New("username is already taken")
+	ErrUsernameConfusable = errors.New("username looks too similar to an existing one")
+	ErrUsernameReserved   = errors.New("username is reserved by another user")
+)
+
+// RejectedError is returned when the username can not be taken. It carries
+// available alternatives to offer instead.
+type RejectedError struct {
+	Reason      error
+	Suggestions []string
+}
+
+func (e *RejectedError) Error() string {
+	return e.Reason.Error()
+}
+
+func (e *RejectedError) Unwrap() error {
+	return e.Reason
+}
+
+func AsRejectedError(err error) (*RejectedError, bool) {
+	var rejected *RejectedError
+	ok := errors.As(err, &rejected)
+	return rejected, ok
+}
+
+func IsSameUsernameError(err error) bool {
+	return errors.Is(err, ErrSameUsername)
+}
+
+func IsTooMuchUsernameChangesError(err error) bool {
+	return errors.Is(err, ErrTooMuchUsernameChanges)
+}
+
+func IsUsernamePolicyError(err error) bool {
+	return errors.Is(err, ErrUsernameTooShort) ||
+		errors.Is(err, ErrUsernameTooLong) ||
+		errors.Is(err, ErrUsernameCharacters) ||
+		errors.Is(err, ErrUsernameMixedScripts) ||
+		errors.Is(err, ErrUsernameNoLetters) ||
+		errors.Is(err, ErrUsernameForbidden) ||
+		errors.Is(err, ErrUsernameProfane)
+}
+
+func IsUsernameConflictError(err error) bool {
+	return errors.Is(err, ErrUsernameTaken) ||
+		errors.Is(err, ErrUsernameConfusable) ||
+		errors.Is(err, ErrUsernameReserved)
+}
@@ -0,0 +1,185 @@
+package username_service
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/ruslanonly/blindtyping/src/internal/models"
+)
+
+const (
+	ScriptLatin    = "latin"
+	ScriptCyrillic = "cyrillic"
+)
+
+// candidatesCount is how many alternatives are tried for a rejected username.
+const candidatesCount = 10
+
+type PolicyConfig struct {
+	MinLength   int                 // Length is counted in characters, not bytes
+	MaxLength   int                 // Must fit users.nickname
+	Scripts     []string            // Alphabets letters can come from, a username uses only one of them
+	AllowDigits bool                // Whether 0-9 are allowed
+	Symbols     string              // Characters allowed besides letters and digits
+	Reserved    []string            // Usernames nobody can take, compared by skeleton
+	Profanity   map[string][]string // Words usernames must not contain, per language
+}
+
+// Policy decides whether a username is acceptable at all, whether it is taken
+// is up to the Service. Reserved names and profanity are compared by skeleton,
+// so look-alike spellings are caught too.
+type Policy struct {
+	cfg       PolicyConfig
+	reserved  map[string]struct{}
+	profanity map[string][]string
+}
+
+func NewPolicy(cfg PolicyConfig) *Policy {
+	reserved := make(map[string]struct{}, len(cfg.Reserved))
+	for _, username := range cfg.Reserved {
+		reserved[models.UsernameSkeleton(username)] = struct{}{}
+	}
+
+	profanity := make(map[string][]string, len(cfg.Profanity))
+	for language, words := range cfg.Profanity {
+		for _, word := range words {
+			profanity[language] = append(profanity[language], models.UsernameSkeleton(word))
+		}
+	}
+
+	return &Policy{
+		cfg:       cfg,
+		reserved:  reserved,
+		profanity: profanity,
+	}
+}
+
+func (p *Policy) Validate(username string) error {
+	length := utf8.RuneCountInString(username)
+	if length < p.cfg.MinLength {
+		return fmt.Errorf("%w: at least %d characters are required", ErrUsernameTooShort, p.cfg.MinLength)
+	}
+	if length > p.cfg.MaxLength {
+		return fmt.Errorf("%w: at most %d characters are allowed", ErrUsernameTooLong, p.cfg.MaxLength)
+	}
+
+	var script string
+	for _, r := range username {
+		switch {
+		case isDigit(r):
+			if !p.cfg.AllowDigits {
+				return fmt.Errorf("%w: digits are not allowed", ErrUsernameCharacters)
+			}
+		case strings.ContainsRune(p.cfg.Symbols, r):
+		default:
+			letterScript := scriptOf(r)
+			if !p.isAllowedScript(letterScript) {
+				return fmt.Errorf("%w: %q is not allowed", ErrUsernameCharacters, r)
+			}
+			if script != "" && script != letterScript {
+				return ErrUsernameMixedScripts
+			}
+			script = letterScript
+		}
+	}
+	if script == "" {
+		return ErrUsernameNoLetters
+	}
+
+	skeleton := models.UsernameSkeleton(username)
+	if _, ok := p.reserved[skeleton]; ok {
+		return ErrUsernameForbidden
+	}
+	for _, words := range p.profanity {
+		for _, word := range words {
+			if strings.Contains(skeleton, word) {
+				return ErrUsernameProfane
+			}
+		}
+	}
+
+	return nil
+}
+
+// Candidates returns alternatives to a rejected username: the username without
+// disallowed characters first, then with random numbers appended. They are not
+// validated.
+func (p *Policy) Candidates(username string) []string {
+	base := p.sanitize(username)
+	if base == "" || !p.cfg.AllowDigits {
+		return nil
+	}
+
+	candidates := make([]string, 0, candidatesCount)
+	if base != username {
+		candidates = append(candidates, base)
+	}
+	for len(candidates) < candidatesCount {
+		limit := 100
+		if len(candidates) >= candidatesCount/2 {
+			limit = 10000
+		}
+		suffix := strconv.Itoa(rand.IntN(limit-1) + 1)
+
+		candidate := []rune(base)
+		if keep := max(p.cfg.MaxLength-len(suffix), 0); len(candidate) > keep {
+			candidate = candidate[:keep]
+		}
+		candidates = append(candidates, string(candidate)+suffix)
+	}
+
+	return candidates
+}
+
+// sanitize drops the characters the policy does not allow. Letters of a script
+// other than the first one met are dropped as well.
+func (p *Policy) sanitize(username string) string {
+	var (
+		b      strings.Builder
+		script string
+	)
+	for _, r := range username {
+		switch {
+		case isDigit(r):
+			if p.cfg.AllowDigits {
+				b.WriteRune(r)
+			}
+		case strings.ContainsRune(p.cfg.Symbols, r):
+			b.WriteRune(r)
+		default:
+			letterScript := scriptOf(r)
+			if !p.isAllowedScript(letterScript) || (script != "" && script != letterScript) {
+				continue
+			}
+			script = letterScript
+			b.WriteRune(r)
+		}
+	}
+
+	return b.String()
+}
+
+func (p *Policy) isAllowedScript(script string) bool {
+	return script != "" && slices.Contains(p.cfg.Scripts, script)
+}
+
+func isDigit(r rune) bool {
+	return r >= '0' && r <= '9'
+}
+
+// scriptOf returns the alphabet of a letter. Only the basic Latin and Russian
+// alphabets are known, other letters add look-alikes the skeleton misses.
+func scriptOf(r rune) string {
+	switch {
+	case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z':
+		return ScriptLatin
+	case r >= 'а' && r <= 'я', r >= 'А' && r <= 'Я', r == 'ё', r == 'Ё':
+		return ScriptCyrillic
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,251 @@
+package username_service
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"github.com/ruslanonly/blindtyping/src/internal"
+	"github.com/ruslanonly/blindtyping/src/internal/models"
+	"github.com/ruslanonly/blindtyping/src/internal/shared/tracing"
+)
+
+// skeletonIndex keeps look-alike usernames unique in the users table.
+const skeletonIndex = "users_nickname_skeleton_idx"
+
+type accountRepository interface {
+	GetByID(ctx context.Context, id models.ID) (*models.UserAccount, error)
+	GetNicknameBySkeleton(ctx context.Context, skeleton string, exceptUserID *models.ID) (string, error)
+	ChangeNickname(ctx context.Context, change *models.UsernameChange, nickname string, changedBefore time.Time) (bool, error)
+}
+
+type historyRepository interface {
+	IsReserved(ctx context.Context, skeleton string, exceptUserID *models.ID, now time.Time) (bool, error)
+	GetCurrentUsername(ctx context.Context, previous string) (string, error)
+}
+
+type policy interface {
+	Validate(username string) error
+	Candidates(username string) []string
+}
+
+type profileCache interface {
+	Delete(ctx context.Context, usernames ...string) error
+}
+
+type availabilityService interface {
+	IsUsernameAvailable(ctx context.Context, username string) (bool, error)
+}
+
+type auditRecorder interface {
+	RecordUserAction(ctx context.Context, action models.AuditAction, userID models.ID, details map[string]any)
+}
+
+// Service decides who can take a username and keeps the history of usernames.
+// A username must pass the policy and must not look like a username of another
+// user. A username the user leaves stays reserved for them during the
+// reservation period: nobody else can take it, and its profile page points to
+// the user's current username. The username can be changed once per change
+// interval.
+type Service struct {
+	accountRepository   accountRepository
+	historyRepository   historyRepository
+	profileCache        profileCache
+	policy              policy
+	availabilityService availabilityService
+	auditRecorder       auditRecorder
+	logger              internal.Logger
+	reservationPeriod   time.Duration
+	changeInterval      time.Duration
+	suggestions         int
+}
+
+func New(
+	accountRepository accountRepository,
+	historyRepository historyRepository,
+	profileCache profileCache,
+	policy policy,
+	availabilityService availabilityService,
+	auditRecorder auditRecorder,
+	logger internal.Logger,
+	reservationPeriod time.Duration,
+	changeInterval time.Duration,
+	suggestions int,
+) *Service {
+	return &Service{
+		accountRepository:   accountRepository,
+		historyRepository:   historyRepository,
+		profileCache:        profileCache,
+		policy:              policy,
+		availabilityService: availabilityService,
+		auditRecorder:       auditRecorder,
+		logger:              logger,
+		reservationPeriod:   reservationPeriod,
+		changeInterval:      changeInterval,
+		suggestions:         suggestions,
+	}
+}
+
+// ChangeUsername changes the username and reserves the previous one in the
+// same statement. The user may always take back a name they had before.
+func (s *Service) ChangeUsername(ctx context.Context, userID uint64, username string) (err error) {
+	ctx, span := tracing.Start(ctx, "username_service.ChangeUsername")
+	defer func() { tracing.End(span, err) }()
+
+	id := models.ID(userID)
+
+	account, err := s.accountRepository.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if account == nil {
+		return ErrUserNotFound
+	}
+	if account.Nickname == username {
+		return ErrSameUsername
+	}
+
+	if err = s.Check(ctx, username, &id); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	change := &models.UsernameChange{
+		UserID:        id,
+		ChangedAt:     now,
+		ReservedUntil: now.Add(s.reservationPeriod),
+	}
+
+	renamed, err := s.accountRepository.ChangeNickname(ctx, change, username, now.Add(-s.changeInterval))
+	if constraint, ok := uniqueViolation(err); ok {
+		// Somebody took the username or a look-alike after it was checked
+		if constraint == skeletonIndex {
+			return ErrUsernameConfusable
+		}
+		return ErrUsernameTaken
+	}
+	if err != nil {
+		return err
+	}
+	if !renamed {
+		return ErrTooMuchUsernameChanges
+	}
+
+	// Profiles are cached by username: the new one must not serve a profile
+	// cached before it was taken, the previous one must not outlive the rename.
+	if err = s.profileCache.Delete(ctx, change.Username, username); err != nil {
+		// The username is already changed, the cached profiles expire on their own
+		s.logger.Error(s.logger.WithError(ctx, err))
+	}
+
+	s.auditRecorder.RecordUserAction(ctx, models.AuditUsernameChanged, id, map[string]any{
+		"username":          username,
+		"previous_username": change.Username,
+	})
+
+	return nil
+}
+
+// Check returns nil if the user can take the username, userID is nil for a new
+// user. A rejected username comes back as *RejectedError with suggestions.
+func (s *Service) Check(ctx context.Context, username string, userID *models.ID) error {
+	err := s.check(ctx, username, userID)
+	if err == nil || !isRejection(err) {
+		return err
+	}
+
+	rejected := &RejectedError{Reason: err}
+	if !errors.Is(err, ErrUsernameForbidden) && !errors.Is(err, ErrUsernameProfane) {
+		rejected.Suggestions = s.suggest(ctx, username, userID)
+	}
+
+	return rejected
+}
+
+func (s *Service) check(ctx context.Context, username string, userID *models.ID) error {
+	if err := s.policy.Validate(username); err != nil {
+		return err
+	}
+
+	skeleton := models.UsernameSkeleton(username)
+
+	nickname, err := s.accountRepository.GetNicknameBySkeleton(ctx, skeleton, userID)
+	if err != nil {
+		return err
+	}
+	switch {
+	case nickname == username:
+		return ErrUsernameTaken
+	case nickname != "":
+		return ErrUsernameConfusable
+	}
+
+	reserved, err := s.historyRepository.IsReserved(ctx, skeleton, userID, time.Now())
+	if err != nil {
+		return err
+	}
+	if reserved {
+		return ErrUsernameReserved
+	}
+
+	if userID == nil {
+		available, err := s.availabilityService.IsUsernameAvailable(ctx, username)
+		if err != nil {
+			return err
+		}
+		if !available {
+			return ErrUsernameTaken
+		}
+	}
+
+	return nil
+}
+
+// suggest returns alternatives to the username that pass every check.
+func (s *Service) suggest(ctx context.Context, username string, userID *models.ID) []string {
+	suggestions := make([]string, 0, s.suggestions)
+	for _, candidate := range s.policy.Candidates(username) {
+		if len(suggestions) == s.suggestions {
+			break
+		}
+		if slices.Contains(suggestions, candidate) {
+			continue
+		}
+
+		err := s.check(ctx, candidate, userID)
+		if err == nil {
+			suggestions = append(suggestions, candidate)
+			continue
+		}
+		if !isRejection(err) {
+			// Suggestions are optional, the rejection itself still stands.
+			s.logger.Error(s.logger.WithError(ctx, err))
+			break
+		}
+	}
+
+	return suggestions
+}
+
+// ResolveRenamed returns the current username of the user who had the given
+// one before, or an empty string if the username was not renamed.
+func (s *Service) ResolveRenamed(ctx context.Context, username string) (string, error) {
+	return s.historyRepository.GetCurrentUsername(ctx, username)
+}
+
+// uniqueViolation returns the name of the unique index the error violates.
+func uniqueViolation(err error) (string, bool) {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) || pgErr.Code != "23505" {
+		return "", false
+	}
+
+	return pgErr.ConstraintName, true
+}
+
+func isRejection(err error) bool {
+	return IsUsernamePolicyError(err) || IsUsernameConflictError(err)
+}
@@ -0,0 +1,59 @@
+package logger
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+)
+
+const (
+	OutputStdout = "stdout"
+	OutputStderr = "stderr"
+	OutputFile   = "file"
+
+	FormatJSON    = "json"
+	FormatConsole = "console"
+)
+
+type Config struct {
+	Enabled      bool
+	Level        string
+	Sinks        []Sink
+	Sampling     Sampling
+	RedactFields []string // Fields with these names are never written, case and "-" or "_" do not matter
+	Secrets      []string // Values that are never written wherever they appear
+}
+
+// Sink is a destination of the log. Every record goes to every sink.
+type Sink struct {
+	Output     string
+	Format     string
+	Path       string // For file output
+	MaxSizeMB  int    // File is rotated when it grows over the size
+	MaxAgeDays int    // Rotated files older than that are deleted
+	MaxBackups int    // At most that many rotated files are kept
+	Compress   bool   // Rotated files are gzipped
+}
+
+// Sampling limits 4xx warnings, which are mostly caused by clients, to First
+// per handler per Interval.
+type Sampling struct {
+	First    int // Zero disables sampling
+	Interval time.Duration
+}
+
+func ParseLevel(level string) (slog.Level, error) {
+	switch strings.ToLower(level) {
+	case "debug":
+		return slog.LevelDebug, nil
+	case "info", "":
+		return slog.LevelInfo, nil
+	case "warning", "warn":
+		return slog.LevelWarn, nil
+	case "error":
+		return slog.LevelError, nil
+	default:
+		return 0, fmt.Errorf("unknown log level %q", level)
+	}
+}
@@ -0,0 +1,122 @@
+package logger
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+)
+
+const (
+	requestIDKey   = "request_id"
+	userIDKey      = "user_id"
+	handlerNameKey = "handler"
+	statusCodeKey  = "status_code"
+	errorKey       = "error"
+)
+
+type fieldsKey struct{}
+
+// fields are kept in the context and copied on every change, so a context
+// passed to another goroutine never sees fields added later.
+type fields struct {
+	msg   string
+	attrs []slog.Attr
+}
+
+func fieldsFrom(ctx context.Context) *fields {
+	if f, ok := ctx.Value(fieldsKey{}).(*fields); ok {
+		return f
+	}
+	return &fields{}
+}
+
+func (f *fields) with(attrs ...slog.Attr) *fields {
+	next := &fields{
+		msg:   f.msg,
+		attrs: slices.Clone(f.attrs),
+	}
+
+	for _, attr := range attrs {
+		i := slices.IndexFunc(next.attrs, func(a slog.Attr) bool { return a.Key == attr.Key })
+		if i >= 0 {
+			next.attrs[i] = attr
+			continue
+		}
+		next.attrs = append(next.attrs, attr)
+	}
+
+	return next
+}
+
+func (f *fields) value(key string) (slog.Value, bool) {
+	for _, attr := range f.attrs {
+		if attr.Key == key {
+			return attr.Value, true
+		}
+	}
+	return slog.Value{}, false
+}
+
+func withFields(ctx context.Context, attrs ...slog.Attr) context.Context {
+	return context.WithValue(ctx, fieldsKey{}, fieldsFrom(ctx).with(attrs...))
+}
+
+func (l *Logger) WithField(ctx context.Context, k string, v any) context.Context {
+	return withFields(ctx, slog.Any(k, v))
+}
+
+func (l *Logger) WithFields(ctx context.Context, fields map[string]any) context.Context {
+	attrs := make([]slog.Attr, 0, len(fields))
+	for k, v := range fields {
+		attrs = append(attrs, slog.Any(k, v))
+	}
+	slices.SortFunc(attrs, func(a, b slog.Attr) int {
+		switch {
+		case a.Key < b.Key:
+			return -1
+		case a.Key > b.Key:
+			return 1
+		default:
+			return 0
+		}
+	})
+
+	return withFields(ctx, attrs...)
+}
+
+func (l *Logger) WithError(ctx context.Context, err error) context.Context {
+	if err == nil {
+		return ctx
+	}
+	return withFields(ctx, slog.String(errorKey, err.Error()))
+}
+
+func (l *Logger) WithRequestID(ctx context.Context, requestID string) context.Context {
+	return withFields(ctx, slog.String(requestIDKey, requestID))
+}
+
+func (l *Logger) WithUserID(ctx context.Context, userID int64) context.Context {
+	return withFields(ctx, slog.Int64(userIDKey, userID))
+}
+
+func (l *Logger) WithHandlerName(ctx context.Context, name string) context.Context {
+	return withFields(ctx, slog.String(handlerNameKey, name))
+}
+
+func (l *Logger) WithStatusCode(ctx context.Context, statusCode int) context.Context {
+	return withFields(ctx, slog.Int(statusCodeKey, statusCode))
+}
+
+func (l *Logger) WithMsg(ctx context.Context, msg string) context.Context {
+	f := fieldsFrom(ctx).with()
+	f.msg = msg
+	return context.WithValue(ctx, fieldsKey{}, f)
+}
+
+// RequestID returns the request id added to the context with WithRequestID.
+func RequestID(ctx context.Context) string {
+	if value, ok := fieldsFrom(ctx).value(requestIDKey); ok {
+		return value.String()
+	}
+	return ""
+}
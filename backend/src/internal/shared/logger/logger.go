@@ -0,0 +1,127 @@
+package logger
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+// Logger implements internal.Logger on top of slog. Fields are collected in
+// the context by the With* methods and written with the record.
+type Logger struct {
+	enabled  bool
+	level    *slog.LevelVar
+	handler  slog.Handler
+	sampler  *sampler
+	redactor *redactor
+}
+
+func New(cfg Config) (*Logger, error) {
+	level, err := ParseLevel(cfg.Level)
+	if err != nil {
+		return nil, err
+	}
+
+	l := &Logger{
+		enabled:  cfg.Enabled,
+		level:    &slog.LevelVar{},
+		sampler:  newSampler(cfg.Sampling),
+		redactor: newRedactor(cfg.RedactFields, cfg.Secrets),
+	}
+	l.level.Set(level)
+
+	handlers := make(fanout, 0, len(cfg.Sinks))
+	for _, sink := range cfg.Sinks {
+		handler, err := newHandler(sink, l.level)
+		if err != nil {
+			return nil, err
+		}
+		handlers = append(handlers, handler)
+	}
+	l.handler = handlers
+
+	return l, nil
+}
+
+func MustNew(cfg Config) *Logger {
+	l, err := New(cfg)
+	if err != nil {
+		panic(err)
+	}
+	return l
+}
+
+// SetLevel changes the minimum level of every sink.
+func (l *Logger) SetLevel(level string) error {
+	parsed, err := ParseLevel(level)
+	if err != nil {
+		return err
+	}
+
+	l.level.Set(parsed)
+	return nil
+}
+
+// isClientError tells whether the record is about a 4xx response.
+func isClientError(f *fields) bool {
+	status, ok := f.value(statusCodeKey)
+	if !ok || status.Kind() != slog.KindInt64 {
+		return false
+	}
+
+	code := status.Int64()
+	return code >= http.StatusBadRequest && code < http.StatusInternalServerError
+}
+
+func (l *Logger) log(ctx context.Context, level slog.Level, args []any) {
+	if !l.enabled || !l.handler.Enabled(ctx, level) {
+		return
+	}
+
+	var (
+		f   = fieldsFrom(ctx)
+		now = time.Now()
+		msg = f.msg
+	)
+	if len(args) > 0 {
+		msg = fmt.Sprint(args...)
+	}
+
+	record := slog.NewRecord(now, level, l.redactor.string(msg), 0)
+
+	if level == slog.LevelWarn && isClientError(f) {
+		handler, _ := f.value(handlerNameKey)
+
+		allowed, dropped := l.sampler.allow(handler.String(), now)
+		if !allowed {
+			return
+		}
+		if dropped > 0 {
+			record.AddAttrs(slog.Int("sampled_out", dropped))
+		}
+	}
+
+	for _, attr := range f.attrs {
+		record.AddAttrs(l.redactor.attr(attr))
+	}
+
+	_ = l.handler.Handle(ctx, record)
+}
+
+func (l *Logger) Debug(ctx context.Context, args ...any) {
+	l.log(ctx, slog.LevelDebug, args)
+}
+
+func (l *Logger) Info(ctx context.Context, args ...any) {
+	l.log(ctx, slog.LevelInfo, args)
+}
+
+func (l *Logger) Warning(ctx context.Context, args ...any) {
+	l.log(ctx, slog.LevelWarn, args)
+}
+
+func (l *Logger) Error(ctx context.Context, args ...any) {
+	l.log(ctx, slog.LevelError, args)
+}
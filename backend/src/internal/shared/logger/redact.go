@@ -0,0 +1,106 @@
+package logger
+
+import (
+	"fmt"
+	"log/slog"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+const (
+	redacted = "[REDACTED]"
+
+	// Shorter secrets are too likely to match ordinary words
+	minSecretLength = 8
+)
+
+var (
+	emailPattern = regexp.MustCompile(`[A-Za-z0-9._%+\-]+@[A-Za-z0-9.\-]+\.[A-Za-z]{2,}`)
+	jwtPattern   = regexp.MustCompile(`eyJ[A-Za-z0-9_\-]+\.[A-Za-z0-9_\-]+\.[A-Za-z0-9_\-]*`)
+	tokenPattern = regexp.MustCompile(`bt_pat_[A-Za-z0-9_\-]+`) // Personal access tokens
+)
+
+// redactor hides sensitive values before a record reaches any sink: values of
+// fields with sensitive keys, emails, JWTs, personal tokens and known secrets
+// in any string. Maps are redacted key by key, other values are formatted
+// first, so nothing reaches a sink without being checked.
+type redactor struct {
+	keys    []string
+	secrets []string
+}
+
+func newRedactor(keys, secrets []string) *redactor {
+	r := &redactor{}
+
+	for _, key := range keys {
+		r.keys = append(r.keys, normalizeKey(key))
+	}
+	for _, secret := range secrets {
+		if len(secret) >= minSecretLength {
+			r.secrets = append(r.secrets, secret)
+		}
+	}
+
+	return r
+}
+
+// normalizeKey makes header names like X-Service-Signature comparable with
+// field names like x_service_signature.
+func normalizeKey(key string) string {
+	return strings.ReplaceAll(strings.ToLower(key), "-", "_")
+}
+
+// isSensitiveKey matches whole field names, so token_id or assigned_role stay
+// readable while access_token and sign are hidden.
+func (r *redactor) isSensitiveKey(key string) bool {
+	return slices.Contains(r.keys, normalizeKey(key))
+}
+
+func (r *redactor) string(s string) string {
+	for _, secret := range r.secrets {
+		s = strings.ReplaceAll(s, secret, redacted)
+	}
+	s = jwtPattern.ReplaceAllString(s, redacted)
+	s = tokenPattern.ReplaceAllString(s, redacted)
+	return emailPattern.ReplaceAllString(s, redacted)
+}
+
+func (r *redactor) attr(attr slog.Attr) slog.Attr {
+	if r.isSensitiveKey(attr.Key) {
+		return slog.String(attr.Key, redacted)
+	}
+
+	value := attr.Value.Resolve()
+
+	switch value.Kind() {
+	case slog.KindString:
+		return slog.String(attr.Key, r.string(value.String()))
+	case slog.KindGroup:
+		group := value.Group()
+		attrs := make([]any, 0, len(group))
+		for _, a := range group {
+			attrs = append(attrs, r.attr(a))
+		}
+		return slog.Group(attr.Key, attrs...)
+	case slog.KindAny:
+		switch v := value.Any().(type) {
+		case nil:
+			return attr
+		case error:
+			return slog.String(attr.Key, r.string(v.Error()))
+		case map[string]any:
+			// Sorted like encoding/json does, so lines stay comparable
+			attrs := make([]any, 0, len(v))
+			for _, key := range slices.Sorted(maps.Keys(v)) {
+				attrs = append(attrs, r.attr(slog.Any(key, v[key])))
+			}
+			return slog.Group(attr.Key, attrs...)
+		default:
+			return slog.String(attr.Key, r.string(fmt.Sprintf("%+v", v)))
+		}
+	}
+
+	return attr
+}
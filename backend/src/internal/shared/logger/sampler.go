@@ -0,0 +1,59 @@
+package logger
+
+import (
+	"sync"
+	"time"
+)
+
+type sampleWindow struct {
+	start   time.Time
+	count   int
+	dropped int
+}
+
+type sampler struct {
+	first    int
+	interval time.Duration
+
+	mu      sync.Mutex
+	windows map[string]*sampleWindow
+}
+
+func newSampler(cfg Sampling) *sampler {
+	return &sampler{
+		first:    cfg.First,
+		interval: cfg.Interval,
+		windows:  make(map[string]*sampleWindow),
+	}
+}
+
+// allow tells whether a warning of the handler is written. The first record of
+// a window also reports how many were dropped in the previous one.
+func (s *sampler) allow(handler string, now time.Time) (bool, int) {
+	if s.first <= 0 {
+		return true, 0
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	window, ok := s.windows[handler]
+	if !ok {
+		window = &sampleWindow{start: now}
+		s.windows[handler] = window
+	}
+
+	var dropped int
+	if now.Sub(window.start) >= s.interval {
+		dropped = window.dropped
+		*window = sampleWindow{start: now}
+	}
+
+	window.count++
+	if window.count > s.first {
+		window.dropped++
+		return false, 0
+	}
+
+	return true, dropped
+}
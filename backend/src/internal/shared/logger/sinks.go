@@ -0,0 +1,87 @@
+package logger
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+
+	"gopkg.in/natefinch/lumberjack.v2"
+)
+
+func newWriter(sink Sink) (io.Writer, error) {
+	switch sink.Output {
+	case OutputStdout:
+		return os.Stdout, nil
+	case OutputStderr:
+		return os.Stderr, nil
+	case OutputFile:
+		return &lumberjack.Logger{
+			Filename:   sink.Path,
+			MaxSize:    sink.MaxSizeMB,
+			MaxAge:     sink.MaxAgeDays,
+			MaxBackups: sink.MaxBackups,
+			Compress:   sink.Compress,
+		}, nil
+	default:
+		return nil, fmt.Errorf("unknown log output %q", sink.Output)
+	}
+}
+
+func newHandler(sink Sink, level slog.Leveler) (slog.Handler, error) {
+	w, err := newWriter(sink)
+	if err != nil {
+		return nil, err
+	}
+
+	options := &slog.HandlerOptions{Level: level}
+
+	switch sink.Format {
+	case FormatJSON:
+		return slog.NewJSONHandler(w, options), nil
+	case FormatConsole:
+		return slog.NewTextHandler(w, options), nil
+	default:
+		return nil, fmt.Errorf("unknown log format %q", sink.Format)
+	}
+}
+
+// fanout writes every record to all sinks.
+type fanout []slog.Handler
+
+func (f fanout) Enabled(ctx context.Context, level slog.Level) bool {
+	for _, h := range f {
+		if h.Enabled(ctx, level) {
+			return true
+		}
+	}
+	return false
+}
+
+func (f fanout) Handle(ctx context.Context, record slog.Record) error {
+	var errs []error
+	for _, h := range f {
+		if h.Enabled(ctx, record.Level) {
+			errs = append(errs, h.Handle(ctx, record.Clone()))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (f fanout) WithAttrs(attrs []slog.Attr) slog.Handler {
+	next := make(fanout, 0, len(f))
+	for _, h := range f {
+		next = append(next, h.WithAttrs(attrs))
+	}
+	return next
+}
+
+func (f fanout) WithGroup(name string) slog.Handler {
+	next := make(fanout, 0, len(f))
+	for _, h := range f {
+		next = append(next, h.WithGroup(name))
+	}
+	return next
+}
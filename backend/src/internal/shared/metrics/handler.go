@@ -0,0 +1,42 @@
+package metrics
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Handler serves the metrics in the Prometheus text format behind basic auth.
+type Handler struct {
+	basicAuth gin.HandlerFunc
+	handler   http.Handler
+}
+
+func NewHandler(metrics *Metrics, login, password string) *Handler {
+	return &Handler{
+		basicAuth: gin.BasicAuth(gin.Accounts{login: password}),
+		handler:   promhttp.HandlerFor(metrics.registry, promhttp.HandlerOpts{}),
+	}
+}
+
+func (h *Handler) Handle(c *gin.Context) {
+	h.basicAuth(c)
+	if c.IsAborted() {
+		return
+	}
+
+	h.handler.ServeHTTP(c.Writer, c.Request)
+}
+
+func (h *Handler) Method() string {
+	return http.MethodGet
+}
+
+func (h *Handler) Path() string {
+	return "/metrics"
+}
+
+func (h *Handler) Middleware() []string {
+	return nil
+}
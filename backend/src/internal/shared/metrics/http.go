@@ -0,0 +1,36 @@
+package metrics
+
+import (
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const notFoundHandler = "not_found"
+
+// handlerName returns the package name of the handler that served the request,
+// which is the same name handlers put into logs.
+func handlerName(c *gin.Context) string {
+	if c.FullPath() == "" {
+		return notFoundHandler
+	}
+
+	name := c.HandlerName()
+	name = name[strings.LastIndex(name, "/")+1:]
+	name, _, _ = strings.Cut(name, ".")
+
+	return name
+}
+
+// Middleware counts requests and measures their latency, including the time
+// spent in handler middleware.
+func (m *Metrics) Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		startedAt := time.Now()
+
+		c.Next()
+
+		m.observeRequest(handlerName(c), c.Request.Method, c.Writer.Status(), time.Since(startedAt))
+	}
+}
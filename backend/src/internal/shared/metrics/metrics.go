@@ -0,0 +1,148 @@
+package metrics
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+)
+
+const namespace = "blindtyping"
+
+type FraudReason string
+
+const (
+	FraudSignature        FraudReason = "signature"         // Result signed with the global key does not match
+	FraudSessionSignature FraudReason = "session_signature" // Result signed with the session secret does not match
+	FraudMachineInput     FraudReason = "machine_input"     // Keystrokes look like a bot or pasted text
+)
+
+const (
+	jobSucceeded = "succeeded"
+	jobFailed    = "failed"
+)
+
+// Metrics keeps every collector of the application in its own registry, so
+// only our metrics and the go runtime ones are exposed.
+type Metrics struct {
+	registry *prometheus.Registry
+
+	httpRequests *prometheus.CounterVec
+	httpDuration *prometheus.HistogramVec
+	jobRuns      *prometheus.CounterVec
+	jobDuration  *prometheus.HistogramVec
+
+	resultsSaved    prometheus.Counter
+	fraudRejections *prometheus.CounterVec
+	personalBests   prometheus.Counter
+	registrations   prometheus.Counter
+}
+
+func New() *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		httpRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "http",
+			Name:      "requests_total",
+			Help:      "HTTP requests by handler, method and status code.",
+		}, []string{"handler", "method", "status"}),
+		httpDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "http",
+			Name:      "request_duration_seconds",
+			Help:      "HTTP request latency by handler and method.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"handler", "method"}),
+		jobRuns: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "scheduler",
+			Name:      "job_runs_total",
+			Help:      "Scheduler job runs by job and outcome.",
+		}, []string{"job", "outcome"}),
+		jobDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "scheduler",
+			Name:      "job_duration_seconds",
+			Help:      "Scheduler job run time by job.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"job"}),
+		resultsSaved: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "statistics",
+			Name:      "results_saved_total",
+			Help:      "Typing test results saved.",
+		}),
+		fraudRejections: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "statistics",
+			Name:      "fraud_rejections_total",
+			Help:      "Typing test results rejected by the antifroad checks by reason.",
+		}, []string{"reason"}),
+		personalBests: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "statistics",
+			Name:      "personal_bests_total",
+			Help:      "Personal bests set.",
+		}),
+		registrations: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "users",
+			Name:      "registrations_total",
+			Help:      "Users registered.",
+		}),
+	}
+
+	m.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.httpRequests,
+		m.httpDuration,
+		m.jobRuns,
+		m.jobDuration,
+		m.resultsSaved,
+		m.fraudRejections,
+		m.personalBests,
+		m.registrations,
+	)
+
+	return m
+}
+
+// MustRegister adds collectors of other components, such as connection pools.
+func (m *Metrics) MustRegister(cs ...prometheus.Collector) {
+	m.registry.MustRegister(cs...)
+}
+
+func (m *Metrics) observeRequest(handler, method string, status int, duration time.Duration) {
+	m.httpRequests.WithLabelValues(handler, method, strconv.Itoa(status)).Inc()
+	m.httpDuration.WithLabelValues(handler, method).Observe(duration.Seconds())
+}
+
+// ObserveJob records a finished scheduler job run.
+func (m *Metrics) ObserveJob(job string, startedAt time.Time, err error) {
+	outcome := jobSucceeded
+	if err != nil {
+		outcome = jobFailed
+	}
+
+	m.jobRuns.WithLabelValues(job, outcome).Inc()
+	m.jobDuration.WithLabelValues(job).Observe(time.Since(startedAt).Seconds())
+}
+
+func (m *Metrics) ResultSaved() {
+	m.resultsSaved.Inc()
+}
+
+func (m *Metrics) FraudRejected(reason FraudReason) {
+	m.fraudRejections.WithLabelValues(string(reason)).Inc()
+}
+
+func (m *Metrics) PersonalBestSet() {
+	m.personalBests.Inc()
+}
+
+func (m *Metrics) UserRegistered() {
+	m.registrations.Inc()
+}
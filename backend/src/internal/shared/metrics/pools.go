@@ -0,0 +1,116 @@
+package metrics
+
+import (
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/redis/go-redis/v9"
+)
+
+type postgresPool interface {
+	Stat() *pgxpool.Stat
+}
+
+type redisPool interface {
+	PoolStats() *redis.PoolStats
+}
+
+func newPoolDesc(subsystem, name, help string) *prometheus.Desc {
+	return prometheus.NewDesc(prometheus.BuildFQName(namespace, subsystem, name), help, nil, nil)
+}
+
+// PostgresCollector exposes the state of the pgx connection pool. Stats are read
+// once per scrape.
+type PostgresCollector struct {
+	pool postgresPool
+
+	acquiredConns   *prometheus.Desc
+	idleConns       *prometheus.Desc
+	totalConns      *prometheus.Desc
+	maxConns        *prometheus.Desc
+	acquires        *prometheus.Desc
+	emptyAcquires   *prometheus.Desc
+	canceledAcquire *prometheus.Desc
+	acquireDuration *prometheus.Desc
+}
+
+func NewPostgresCollector(pool postgresPool) *PostgresCollector {
+	return &PostgresCollector{
+		pool:            pool,
+		acquiredConns:   newPoolDesc("postgres", "acquired_connections", "Connections currently in use."),
+		idleConns:       newPoolDesc("postgres", "idle_connections", "Idle connections in the pool."),
+		totalConns:      newPoolDesc("postgres", "connections", "Connections in the pool."),
+		maxConns:        newPoolDesc("postgres", "max_connections", "Maximum size of the pool."),
+		acquires:        newPoolDesc("postgres", "acquires_total", "Successful connection acquires."),
+		emptyAcquires:   newPoolDesc("postgres", "empty_acquires_total", "Acquires that waited for a connection."),
+		canceledAcquire: newPoolDesc("postgres", "canceled_acquires_total", "Acquires canceled by the context."),
+		acquireDuration: newPoolDesc("postgres", "acquire_duration_seconds_total", "Time spent acquiring connections."),
+	}
+}
+
+func (pc *PostgresCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- pc.acquiredConns
+	ch <- pc.idleConns
+	ch <- pc.totalConns
+	ch <- pc.maxConns
+	ch <- pc.acquires
+	ch <- pc.emptyAcquires
+	ch <- pc.canceledAcquire
+	ch <- pc.acquireDuration
+}
+
+func (pc *PostgresCollector) Collect(ch chan<- prometheus.Metric) {
+	stat := pc.pool.Stat()
+
+	ch <- prometheus.MustNewConstMetric(pc.acquiredConns, prometheus.GaugeValue, float64(stat.AcquiredConns()))
+	ch <- prometheus.MustNewConstMetric(pc.idleConns, prometheus.GaugeValue, float64(stat.IdleConns()))
+	ch <- prometheus.MustNewConstMetric(pc.totalConns, prometheus.GaugeValue, float64(stat.TotalConns()))
+	ch <- prometheus.MustNewConstMetric(pc.maxConns, prometheus.GaugeValue, float64(stat.MaxConns()))
+	ch <- prometheus.MustNewConstMetric(pc.acquires, prometheus.CounterValue, float64(stat.AcquireCount()))
+	ch <- prometheus.MustNewConstMetric(pc.emptyAcquires, prometheus.CounterValue, float64(stat.EmptyAcquireCount()))
+	ch <- prometheus.MustNewConstMetric(pc.canceledAcquire, prometheus.CounterValue, float64(stat.CanceledAcquireCount()))
+	ch <- prometheus.MustNewConstMetric(pc.acquireDuration, prometheus.CounterValue, stat.AcquireDuration().Seconds())
+}
+
+// RedisCollector exposes the state of the go-redis connection pool.
+type RedisCollector struct {
+	client redisPool
+
+	hits       *prometheus.Desc
+	misses     *prometheus.Desc
+	timeouts   *prometheus.Desc
+	totalConns *prometheus.Desc
+	idleConns  *prometheus.Desc
+	staleConns *prometheus.Desc
+}
+
+func NewRedisCollector(client redisPool) *RedisCollector {
+	return &RedisCollector{
+		client:     client,
+		hits:       newPoolDesc("redis", "pool_hits_total", "Times a free connection was found in the pool."),
+		misses:     newPoolDesc("redis", "pool_misses_total", "Times a free connection was not found in the pool."),
+		timeouts:   newPoolDesc("redis", "pool_timeouts_total", "Times waiting for a connection timed out."),
+		totalConns: newPoolDesc("redis", "connections", "Connections in the pool."),
+		idleConns:  newPoolDesc("redis", "idle_connections", "Idle connections in the pool."),
+		staleConns: newPoolDesc("redis", "stale_connections_total", "Stale connections removed from the pool."),
+	}
+}
+
+func (rc *RedisCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- rc.hits
+	ch <- rc.misses
+	ch <- rc.timeouts
+	ch <- rc.totalConns
+	ch <- rc.idleConns
+	ch <- rc.staleConns
+}
+
+func (rc *RedisCollector) Collect(ch chan<- prometheus.Metric) {
+	stats := rc.client.PoolStats()
+
+	ch <- prometheus.MustNewConstMetric(rc.hits, prometheus.CounterValue, float64(stats.Hits))
+	ch <- prometheus.MustNewConstMetric(rc.misses, prometheus.CounterValue, float64(stats.Misses))
+	ch <- prometheus.MustNewConstMetric(rc.timeouts, prometheus.CounterValue, float64(stats.Timeouts))
+	ch <- prometheus.MustNewConstMetric(rc.totalConns, prometheus.GaugeValue, float64(stats.TotalConns))
+	ch <- prometheus.MustNewConstMetric(rc.idleConns, prometheus.GaugeValue, float64(stats.IdleConns))
+	ch <- prometheus.MustNewConstMetric(rc.staleConns, prometheus.CounterValue, float64(stats.StaleConns))
+}
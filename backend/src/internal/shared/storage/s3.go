@@ -0,0 +1,202 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	s3Service       = "s3"
+	s3Algorithm     = "AWS4-HMAC-SHA256"
+	s3DateFormat    = "20060102"
+	s3TimeFormat    = "20060102T150405Z"
+	s3Timeout       = 30 * time.Second
+	s3MaxErrorBytes = 1024
+)
+
+// S3 keeps objects in a bucket of an S3-compatible storage. Requests are made
+// with path-style URLs and signed with AWS Signature Version 4, which MinIO,
+// Ceph and the other S3-compatible storages accept as well. The endpoint may
+// have a path, e.g. when the storage is served behind a reverse proxy; the
+// bucket goes after it.
+type S3 struct {
+	origin    string // Scheme and host of the endpoint
+	prefix    string // Escaped path of the endpoint without the trailing slash
+	region    string
+	bucket    string
+	accessKey string
+	secretKey string
+	client    *http.Client
+}
+
+func NewS3(endpoint, region, bucket, accessKey, secretKey string) (*S3, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme == "" || u.Host == "" || u.RawQuery != "" {
+		return nil, fmt.Errorf("s3 endpoint must be an absolute URL without a query, got %q", endpoint)
+	}
+
+	return &S3{
+		origin:    u.Scheme + "://" + u.Host,
+		prefix:    escapePath(strings.TrimSuffix(u.Path, "/")),
+		region:    region,
+		bucket:    bucket,
+		accessKey: accessKey,
+		secretKey: secretKey,
+		client:    &http.Client{Timeout: s3Timeout},
+	}, nil
+}
+
+func (s *S3) Put(ctx context.Context, key, contentType string, data []byte) error {
+	resp, err := s.do(ctx, http.MethodPut, key, contentType, data)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	return s.checkStatus(resp, http.StatusOK)
+}
+
+func (s *S3) Get(ctx context.Context, key string) ([]byte, string, error) {
+	resp, err := s.do(ctx, http.MethodGet, key, "", nil)
+	if err != nil {
+		return nil, "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, "", ErrNotFound
+	}
+	if err = s.checkStatus(resp, http.StatusOK); err != nil {
+		return nil, "", err
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return data, resp.Header.Get("Content-Type"), nil
+}
+
+// Delete succeeds for missing objects too, S3 does not tell them apart.
+func (s *S3) Delete(ctx context.Context, key string) error {
+	resp, err := s.do(ctx, http.MethodDelete, key, "", nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	return s.checkStatus(resp, http.StatusNoContent, http.StatusOK)
+}
+
+func (s *S3) checkStatus(resp *http.Response, expected ...int) error {
+	for _, status := range expected {
+		if resp.StatusCode == status {
+			return nil
+		}
+	}
+
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, s3MaxErrorBytes))
+	return fmt.Errorf("s3 responded with %d: %s", resp.StatusCode, body)
+}
+
+func (s *S3) do(ctx context.Context, method, key, contentType string, data []byte) (*http.Response, error) {
+	// The path is signed exactly as it is sent, prefix included
+	path := s.prefix + "/" + escapePath(s.bucket) + "/" + escapePath(key)
+
+	req, err := http.NewRequestWithContext(ctx, method, s.origin+path, bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+
+	s.sign(req, path, data, time.Now().UTC())
+
+	return s.client.Do(req)
+}
+
+// sign adds the Authorization header of Signature Version 4. Only the headers
+// set by do are signed, the query string is always empty.
+func (s *S3) sign(req *http.Request, path string, data []byte, now time.Time) {
+	payloadHash := sha256Hex(data)
+	req.Header.Set("X-Amz-Date", now.Format(s3TimeFormat))
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	signedHeaders := []string{"host", "x-amz-content-sha256", "x-amz-date"}
+	canonicalHeaders := "host:" + req.URL.Host + "\n" +
+		"x-amz-content-sha256:" + payloadHash + "\n" +
+		"x-amz-date:" + now.Format(s3TimeFormat) + "\n"
+	if contentType := req.Header.Get("Content-Type"); contentType != "" {
+		signedHeaders = append([]string{"content-type"}, signedHeaders...)
+		canonicalHeaders = "content-type:" + contentType + "\n" + canonicalHeaders
+	}
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		path,
+		"",
+		canonicalHeaders,
+		strings.Join(signedHeaders, ";"),
+		payloadHash,
+	}, "\n")
+
+	scope := strings.Join([]string{now.Format(s3DateFormat), s.region, s3Service, "aws4_request"}, "/")
+	stringToSign := strings.Join([]string{
+		s3Algorithm,
+		now.Format(s3TimeFormat),
+		scope,
+		sha256Hex([]byte(canonicalRequest)),
+	}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+s.secretKey), now.Format(s3DateFormat))
+	key = hmacSHA256(key, s.region)
+	key = hmacSHA256(key, s3Service)
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf(
+		"%s Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s3Algorithm, s.accessKey, scope, strings.Join(signedHeaders, ";"), signature,
+	))
+}
+
+// escapePath escapes everything but unreserved characters the way Signature
+// Version 4 expects, keeping the slashes between segments.
+func escapePath(key string) string {
+	var b strings.Builder
+	for _, c := range []byte(key) {
+		switch {
+		case c >= 'A' && c <= 'Z', c >= 'a' && c <= 'z', c >= '0' && c <= '9',
+			c == '-', c == '_', c == '.', c == '~', c == '/':
+			b.WriteByte(c)
+		default:
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+	return b.String()
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
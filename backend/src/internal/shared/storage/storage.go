@@ -0,0 +1,49 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+type Backend string
+
+const (
+	BackendLocal Backend = "local" // Files in a directory on the server
+	BackendS3    Backend = "s3"    // Any S3-compatible object storage
+)
+
+var ErrNotFound = errors.New("object not found")
+
+type Config struct {
+	Backend   Backend
+	Path      string // Directory for the local backend
+	Endpoint  string // Base URL of the S3 API, e.g. https://s3.eu-central-1.amazonaws.com, may have a path
+	Region    string
+	Bucket    string
+	AccessKey string
+	SecretKey string
+}
+
+// Storage keeps binary objects by key. Keys are slash separated paths made by
+// the server, never taken from the client as is.
+type Storage interface {
+	Put(ctx context.Context, key, contentType string, data []byte) error
+	Get(ctx context.Context, key string) (data []byte, contentType string, err error)
+	Delete(ctx context.Context, key string) error
+}
+
+func MustNew(cfg Config) Storage {
+	switch cfg.Backend {
+	case BackendLocal:
+		return NewLocal(cfg.Path)
+	case BackendS3:
+		s3, err := NewS3(cfg.Endpoint, cfg.Region, cfg.Bucket, cfg.AccessKey, cfg.SecretKey)
+		if err != nil {
+			panic(err)
+		}
+		return s3
+	default:
+		panic(fmt.Errorf("unknown storage backend %q", cfg.Backend))
+	}
+}
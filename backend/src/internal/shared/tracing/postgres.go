@@ -0,0 +1,113 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type database interface {
+	Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
+// Postgres wraps the database given to repositories and records a span for
+// every statement. Spans of queries end when their rows are closed or scanned,
+// so they include reading the result.
+type Postgres struct {
+	db     database
+	tracer trace.Tracer
+}
+
+func NewPostgres(db database, tracer trace.Tracer) *Postgres {
+	return &Postgres{
+		db:     db,
+		tracer: tracer,
+	}
+}
+
+func (p *Postgres) start(ctx context.Context, sql string) (context.Context, trace.Span) {
+	operation := "QUERY"
+	if fields := strings.Fields(sql); len(fields) > 0 {
+		operation = strings.ToUpper(fields[0])
+	}
+
+	return p.tracer.Start(ctx, "postgres "+operation,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("db.system", "postgresql"),
+			attribute.String("db.operation.name", operation),
+			attribute.String("db.query.text", strings.TrimSpace(sql)),
+		),
+	)
+}
+
+func end(span trace.Span, err error) {
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+func (p *Postgres) Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error) {
+	ctx, span := p.start(ctx, sql)
+
+	tag, err := p.db.Exec(ctx, sql, arguments...)
+	if err == nil {
+		span.SetAttributes(attribute.Int64("db.rows_affected", tag.RowsAffected()))
+	}
+	end(span, err)
+
+	return tag, err
+}
+
+func (p *Postgres) Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error) {
+	ctx, span := p.start(ctx, sql)
+
+	rows, err := p.db.Query(ctx, sql, args...)
+	if err != nil {
+		end(span, err)
+		return nil, err
+	}
+
+	return &tracedRows{Rows: rows, span: span}, nil
+}
+
+func (p *Postgres) QueryRow(ctx context.Context, sql string, args ...any) pgx.Row {
+	ctx, span := p.start(ctx, sql)
+
+	return &tracedRow{row: p.db.QueryRow(ctx, sql, args...), span: span}
+}
+
+type tracedRows struct {
+	pgx.Rows
+	span  trace.Span
+	ended bool
+}
+
+func (r *tracedRows) Close() {
+	r.Rows.Close()
+	if !r.ended {
+		r.ended = true
+		end(r.span, r.Rows.Err())
+	}
+}
+
+type tracedRow struct {
+	row  pgx.Row
+	span trace.Span
+}
+
+func (r *tracedRow) Scan(dest ...any) error {
+	err := r.row.Scan(dest...)
+	end(r.span, err)
+	return err
+}
@@ -0,0 +1,111 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+const instrumentationName = "github.com/ruslanonly/blindtyping"
+
+type Exporter string
+
+const (
+	ExporterNone   Exporter = "none"
+	ExporterStdout Exporter = "stdout" // For local development
+	ExporterOTLP   Exporter = "otlp"   // OTLP over HTTP
+)
+
+type Config struct {
+	Exporter    Exporter
+	Endpoint    string // host:port of the OTLP collector
+	Insecure    bool
+	ServiceName string
+	SampleRatio float64
+}
+
+// Tracing owns the tracer provider and installs it as the global one for
+// service spans. Spans are exported in batches, so the provider has to be
+// shut down to flush the last of them.
+type Tracing struct {
+	provider    trace.TracerProvider
+	sdkProvider *sdktrace.TracerProvider
+	tracer      trace.Tracer
+	propagator  propagation.TextMapPropagator
+}
+
+func newExporter(ctx context.Context, cfg Config) (sdktrace.SpanExporter, error) {
+	switch cfg.Exporter {
+	case ExporterStdout:
+		return stdouttrace.New(stdouttrace.WithPrettyPrint())
+	case ExporterOTLP:
+		opts := []otlptracehttp.Option{otlptracehttp.WithEndpoint(cfg.Endpoint)}
+		if cfg.Insecure {
+			opts = append(opts, otlptracehttp.WithInsecure())
+		}
+		return otlptracehttp.New(ctx, opts...)
+	default:
+		return nil, fmt.Errorf("unknown tracing exporter %q", cfg.Exporter)
+	}
+}
+
+func MustNew(ctx context.Context, cfg Config) *Tracing {
+	t := &Tracing{
+		propagator: propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}),
+	}
+
+	if cfg.Exporter == "" || cfg.Exporter == ExporterNone {
+		t.provider = noop.NewTracerProvider()
+		t.tracer = t.provider.Tracer(instrumentationName)
+		otel.SetTracerProvider(t.provider)
+		return t
+	}
+
+	exporter, err := newExporter(ctx, cfg)
+	if err != nil {
+		panic(err)
+	}
+
+	t.sdkProvider = sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.SampleRatio))),
+		sdktrace.WithResource(resource.NewSchemaless(attribute.String("service.name", cfg.ServiceName))),
+	)
+	t.provider = t.sdkProvider
+	t.tracer = t.provider.Tracer(instrumentationName)
+	otel.SetTracerProvider(t.provider)
+
+	return t
+}
+
+func (t *Tracing) TracerProvider() trace.TracerProvider {
+	return t.provider
+}
+
+func (t *Tracing) Tracer() trace.Tracer {
+	return t.tracer
+}
+
+func (t *Tracing) Propagator() propagation.TextMapPropagator {
+	return t.propagator
+}
+
+// MustShutdown flushes spans that are not exported yet.
+func (t *Tracing) MustShutdown(ctx context.Context) {
+	if t.sdkProvider == nil {
+		return
+	}
+
+	if err := t.sdkProvider.Shutdown(ctx); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS session_families;
\ No newline at end of file
@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS session_families (
+    refresh_token VARCHAR(64) NOT NULL PRIMARY KEY,
+    family_id UUID NOT NULL,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    rotated_at TIMESTAMPTZ,
+    expires_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_session_families_family_id ON session_families USING btree (family_id);
\ No newline at end of file
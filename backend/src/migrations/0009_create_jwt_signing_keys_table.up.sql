@@ -0,0 +1,7 @@
+CREATE TABLE IF NOT EXISTS jwt_signing_keys (
+    kid VARCHAR(64) NOT NULL PRIMARY KEY,
+    algorithm VARCHAR(16) NOT NULL,
+    private_key TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    retired_at TIMESTAMPTZ
+);
\ No newline at end of file
@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS user_sanctions (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    kind VARCHAR(16) NOT NULL,
+    reason VARCHAR(512) NOT NULL,
+    actor_id INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    expires_at TIMESTAMPTZ,
+    lifted_at TIMESTAMPTZ,
+    lifted_by INTEGER REFERENCES users(id) ON DELETE SET NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_sanctions_user_id ON user_sanctions USING btree (user_id);
+CREATE INDEX IF NOT EXISTS idx_user_sanctions_expires_at ON user_sanctions USING btree (expires_at) WHERE lifted_at IS NULL;
\ No newline at end of file
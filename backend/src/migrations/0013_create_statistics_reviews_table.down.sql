@@ -0,0 +1 @@
+DROP TABLE IF EXISTS statistics_reviews;
\ No newline at end of file
@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS statistics_reviews (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    uid VARCHAR(128) NOT NULL,
+    payload JSONB NOT NULL,
+    score DOUBLE PRECISION NOT NULL,
+    reasons TEXT[] NOT NULL,
+    status VARCHAR(16) NOT NULL DEFAULT 'pending',
+    created_at TIMESTAMPTZ NOT NULL,
+    reviewed_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    reviewed_at TIMESTAMPTZ,
+    UNIQUE (user_id, uid)
+);
+
+CREATE INDEX IF NOT EXISTS idx_statistics_reviews_status ON statistics_reviews USING btree (status, created_at);
\ No newline at end of file
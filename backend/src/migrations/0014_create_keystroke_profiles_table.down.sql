@@ -0,0 +1 @@
+DROP TABLE IF EXISTS keystroke_profiles;
\ No newline at end of file
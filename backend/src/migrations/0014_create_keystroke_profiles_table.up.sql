@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS keystroke_profiles (
+    user_id INTEGER PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    samples INTEGER NOT NULL,
+    interval_mean DOUBLE PRECISION NOT NULL,
+    interval_cv DOUBLE PRECISION NOT NULL,
+    dwell_mean DOUBLE PRECISION NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL
+);
\ No newline at end of file
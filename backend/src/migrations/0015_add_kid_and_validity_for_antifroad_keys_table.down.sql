@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS antifroad_key_rotations;
+
+ALTER TABLE antifroad_keys
+    DROP COLUMN IF EXISTS kid,
+    DROP COLUMN IF EXISTS not_before,
+    DROP COLUMN IF EXISTS not_after;
\ No newline at end of file
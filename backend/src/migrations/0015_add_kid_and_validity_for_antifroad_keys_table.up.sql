@@ -0,0 +1,14 @@
+ALTER TABLE antifroad_keys
+    ADD COLUMN IF NOT EXISTS kid VARCHAR(64) NOT NULL UNIQUE DEFAULT md5(random()::text || clock_timestamp()::text),
+    ADD COLUMN IF NOT EXISTS not_before TIMESTAMPTZ NOT NULL DEFAULT now(),
+    ADD COLUMN IF NOT EXISTS not_after TIMESTAMPTZ;
+
+UPDATE antifroad_keys SET not_before = created_at;
+
+CREATE TABLE IF NOT EXISTS antifroad_key_rotations (
+    id SERIAL PRIMARY KEY,
+    kid VARCHAR(64) NOT NULL,
+    retired_kids TEXT[] NOT NULL,
+    trigger VARCHAR(16) NOT NULL,
+    rotated_at TIMESTAMPTZ NOT NULL
+);
\ No newline at end of file
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS analytics_events;
\ No newline at end of file
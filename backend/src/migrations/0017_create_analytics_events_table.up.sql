@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS analytics_events (
+    id BIGSERIAL PRIMARY KEY,
+    name VARCHAR(64) NOT NULL,
+    user_id INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    properties JSONB NOT NULL DEFAULT '{}',
+    occurred_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS analytics_events_name_occurred_at_idx ON analytics_events (name, occurred_at);
+CREATE INDEX IF NOT EXISTS analytics_events_user_id_name_idx ON analytics_events (user_id, name);
\ No newline at end of file
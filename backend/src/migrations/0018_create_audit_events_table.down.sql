@@ -0,0 +1,3 @@
+DROP TRIGGER IF EXISTS audit_events_immutable ON audit_events;
+DROP FUNCTION IF EXISTS audit_events_reject_change();
+DROP TABLE IF EXISTS audit_events;
\ No newline at end of file
@@ -0,0 +1,26 @@
+CREATE TABLE IF NOT EXISTS audit_events (
+    id BIGSERIAL PRIMARY KEY,
+    action VARCHAR(64) NOT NULL,
+    user_id INTEGER,
+    actor_id INTEGER,
+    actor_service VARCHAR(64),
+    ip VARCHAR(64) NOT NULL DEFAULT '',
+    user_agent TEXT NOT NULL DEFAULT '',
+    request_id VARCHAR(128) NOT NULL DEFAULT '',
+    details JSONB NOT NULL DEFAULT '{}',
+    created_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS audit_events_user_id_id_idx ON audit_events (user_id, id);
+CREATE INDEX IF NOT EXISTS audit_events_actor_id_id_idx ON audit_events (actor_id, id);
+CREATE INDEX IF NOT EXISTS audit_events_action_id_idx ON audit_events (action, id);
+
+CREATE OR REPLACE FUNCTION audit_events_reject_change() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'audit events are immutable';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER audit_events_immutable
+    BEFORE UPDATE OR DELETE ON audit_events
+    FOR EACH ROW EXECUTE FUNCTION audit_events_reject_change();
\ No newline at end of file
@@ -0,0 +1,8 @@
+ALTER TABLE statistics
+    DROP CONSTRAINT IF EXISTS statistics_user_id_fkey,
+    ADD CONSTRAINT statistics_user_id_fkey FOREIGN KEY (user_id) REFERENCES users(id);
+
+DROP INDEX IF EXISTS idx_users_deletion_scheduled_at;
+
+ALTER TABLE users
+    DROP COLUMN IF EXISTS deletion_scheduled_at;
\ No newline at end of file
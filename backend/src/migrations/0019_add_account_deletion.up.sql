@@ -0,0 +1,8 @@
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS deletion_scheduled_at TIMESTAMPTZ;
+
+CREATE INDEX IF NOT EXISTS idx_users_deletion_scheduled_at ON users USING btree (deletion_scheduled_at) WHERE deletion_scheduled_at IS NOT NULL;
+
+ALTER TABLE statistics
+    DROP CONSTRAINT IF EXISTS statistics_user_id_fkey,
+    ADD CONSTRAINT statistics_user_id_fkey FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE;
\ No newline at end of file
@@ -0,0 +1,3 @@
+ALTER TABLE statistics
+    DROP COLUMN IF EXISTS deleted_at,
+    DROP COLUMN IF EXISTS is_wiped;
\ No newline at end of file
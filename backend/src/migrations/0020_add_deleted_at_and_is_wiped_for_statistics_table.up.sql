@@ -0,0 +1,3 @@
+ALTER TABLE statistics
+    ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMPTZ,
+    ADD COLUMN IF NOT EXISTS is_wiped BOOLEAN NOT NULL DEFAULT FALSE;
\ No newline at end of file
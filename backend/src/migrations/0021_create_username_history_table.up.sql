@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS username_history (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    username VARCHAR(32) NOT NULL,
+    changed_at TIMESTAMPTZ NOT NULL,
+    reserved_until TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS username_history_username_idx ON username_history (username, changed_at);
+CREATE INDEX IF NOT EXISTS username_history_user_id_idx ON username_history (user_id);
\ No newline at end of file
@@ -0,0 +1 @@
+DROP INDEX IF EXISTS users_nickname_skeleton_idx;
\ No newline at end of file
@@ -0,0 +1,8 @@
+DROP TABLE IF EXISTS provider_avatars;
+
+ALTER TABLE users
+    DROP COLUMN IF EXISTS avatar_key,
+    DROP COLUMN IF EXISTS bio,
+    DROP COLUMN IF EXISTS country,
+    DROP COLUMN IF EXISTS keyboard,
+    DROP COLUMN IF EXISTS layout;
\ No newline at end of file
@@ -0,0 +1,12 @@
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS avatar_key VARCHAR(128),
+    ADD COLUMN IF NOT EXISTS bio VARCHAR(300) NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS country VARCHAR(2) NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS keyboard VARCHAR(64) NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS layout VARCHAR(32) NOT NULL DEFAULT '';
+
+CREATE TABLE IF NOT EXISTS provider_avatars (
+    email VARCHAR(128) PRIMARY KEY,
+    avatar_url TEXT NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL
+);
\ No newline at end of file
@@ -0,0 +1,6 @@
+ALTER TABLE users
+    DROP COLUMN IF EXISTS profile_visibility,
+    DROP COLUMN IF EXISTS hide_personal_bests,
+    DROP COLUMN IF EXISTS hide_time_played,
+    DROP COLUMN IF EXISTS hide_language_stats,
+    DROP COLUMN IF EXISTS hide_activity;
\ No newline at end of file
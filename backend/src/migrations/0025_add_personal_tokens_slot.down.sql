@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_personal_tokens_user_id_slot;
+
+ALTER TABLE personal_tokens DROP COLUMN IF EXISTS slot;
\ No newline at end of file
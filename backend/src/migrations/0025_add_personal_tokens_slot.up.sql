@@ -0,0 +1,13 @@
+ALTER TABLE personal_tokens ADD COLUMN IF NOT EXISTS slot INTEGER;
+
+UPDATE personal_tokens AS t
+SET slot = numbered.slot
+FROM (
+    SELECT id, ROW_NUMBER() OVER (PARTITION BY user_id ORDER BY id) AS slot
+    FROM personal_tokens
+) AS numbered
+WHERE t.id = numbered.id;
+
+ALTER TABLE personal_tokens ALTER COLUMN slot SET NOT NULL;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_personal_tokens_user_id_slot ON personal_tokens USING btree (user_id, slot);
\ No newline at end of file
@@ -0,0 +1 @@
+DROP VIEW IF EXISTS leaderboard_users;
\ No newline at end of file
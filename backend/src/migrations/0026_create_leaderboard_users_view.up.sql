@@ -0,0 +1,13 @@
+CREATE OR REPLACE VIEW leaderboard_users AS
+SELECT u.id AS user_id
+FROM users AS u
+WHERE u.banned_at IS NULL
+    AND u.deletion_scheduled_at IS NULL
+    AND NOT EXISTS (
+        SELECT 1
+        FROM user_sanctions AS s
+        WHERE s.user_id = u.id
+            AND s.kind = 'shadow_ban'
+            AND s.lifted_at IS NULL
+            AND (s.expires_at IS NULL OR s.expires_at > NOW())
+    );
\ No newline at end of file
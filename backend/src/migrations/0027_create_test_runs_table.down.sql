@@ -0,0 +1 @@
+DROP TABLE IF EXISTS test_runs;
\ No newline at end of file
@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS test_runs (
+    id VARCHAR(32) PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    properties JSONB NOT NULL DEFAULT '{}',
+    started_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS test_runs_user_id_idx ON test_runs (user_id);
+CREATE INDEX IF NOT EXISTS test_runs_started_at_idx ON test_runs (started_at);
\ No newline at end of file
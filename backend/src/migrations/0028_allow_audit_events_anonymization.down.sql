@@ -0,0 +1,5 @@
+CREATE OR REPLACE FUNCTION audit_events_reject_change() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'audit events are immutable';
+END;
+$$ LANGUAGE plpgsql;
\ No newline at end of file
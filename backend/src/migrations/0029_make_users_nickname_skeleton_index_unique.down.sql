@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS users_nickname_skeleton_idx;
+
+CREATE INDEX IF NOT EXISTS users_nickname_skeleton_idx ON users (translate(lower(nickname), 'авеёкмнорстух015i-.АВЕЁКМНОРСТУХБГДЖЗИЙЛПФЦЧШЩЪЫЬЭЮЯ', 'abeekmhopctyxolsl__abeekmhopctyxбгджзийлпфцчшщъыьэюя'));
\ No newline at end of file
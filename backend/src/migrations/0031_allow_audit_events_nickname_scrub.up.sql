@@ -0,0 +1,15 @@
+CREATE OR REPLACE FUNCTION audit_events_reject_change() RETURNS trigger AS $$
+BEGIN
+    IF TG_OP = 'UPDATE'
+        AND NEW.ip = ''
+        AND NEW.user_agent = ''
+        AND NEW.details IN (OLD.details, OLD.details - ARRAY['nickname', 'username', 'previous_username'])
+        AND (NEW.id, NEW.action, NEW.user_id, NEW.actor_id, NEW.actor_service, NEW.request_id, NEW.created_at)
+            IS NOT DISTINCT FROM (OLD.id, OLD.action, OLD.user_id, OLD.actor_id, OLD.actor_service, OLD.request_id, OLD.created_at)
+    THEN
+        RETURN NEW;
+    END IF;
+
+    RAISE EXCEPTION 'audit events are immutable';
+END;
+$$ LANGUAGE plpgsql;
\ No newline at end of file